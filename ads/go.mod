@@ -7,19 +7,24 @@ require (
 	github.com/iotaledger/hive.go/ierrors v0.0.0-20240315104458-b689cbcfddbd
 	github.com/iotaledger/hive.go/kvstore v0.0.0-20240315104458-b689cbcfddbd
 	github.com/iotaledger/hive.go/lo v0.0.0-20240315104458-b689cbcfddbd
+	github.com/iotaledger/hive.go/runtime v0.0.0-20240315104458-b689cbcfddbd
 	github.com/iotaledger/hive.go/serializer/v2 v2.0.0-rc.1.0.20240223135607-4704e82184c0
 	github.com/pokt-network/smt v0.9.2
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/crypto v0.17.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/ethereum/go-ethereum v1.13.14 // indirect
+	github.com/holiman/uint256 v1.2.4 // indirect
+	github.com/iancoleman/orderedmap v0.3.0 // indirect
 	github.com/iotaledger/hive.go/constraints v0.0.0-20240315104458-b689cbcfddbd // indirect
-	github.com/iotaledger/hive.go/runtime v0.0.0-20240315104458-b689cbcfddbd // indirect
 	github.com/iotaledger/hive.go/stringify v0.0.0-20240315104458-b689cbcfddbd // indirect
 	github.com/kr/text v0.2.0 // indirect
 	github.com/petermattis/goid v0.0.0-20231207134359-e60b3f734c67 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/sasha-s/go-deadlock v0.3.1 // indirect
+	golang.org/x/sys v0.16.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,36 @@
+package ads
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/hive.go/lo"
+)
+
+var (
+	// SHA256 is the SHA-256 hash function.
+	SHA256 = &HashFunction{name: "sha256", newHash: sha256.New}
+
+	// BLAKE2b256 is the BLAKE2b-256 hash function.
+	BLAKE2b256 = &HashFunction{name: "blake2b-256", newHash: func() hash.Hash {
+		return lo.PanicOnErr(blake2b.New256(nil))
+	}}
+)
+
+// HashFunction is a hash function that can be used to build the trees of a Map or Set.
+type HashFunction struct {
+	name    string
+	newHash func() hash.Hash
+}
+
+// Name returns the name of the hash function.
+func (h *HashFunction) Name() string {
+	return h.name
+}
+
+// New returns a new hash.Hash instance of the hash function.
+func (h *HashFunction) New() hash.Hash {
+	return h.newHash()
+}
@@ -0,0 +1,156 @@
+package ads
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/typeutils"
+)
+
+func TestMapHashFunction(t *testing.T) {
+	newTestMap := func(store kvstore.KVStore, opts ...Option) *authenticatedMap[[32]byte, testKey, testValue] {
+		return newAuthenticatedMap[[32]byte](store,
+			typeutils.ByteArray32ToBytes,
+			typeutils.ByteArray32FromBytes,
+			testKey.Bytes,
+			testKeyFromBytes,
+			testValue.Bytes,
+			testValueFromBytes,
+			opts...,
+		)
+	}
+
+	opts := []Option{WithHashFunction(BLAKE2b256), WithValueHashing(true), WithRetainedVersions(1)}
+
+	sha256Map := newTestMap(mapdb.NewMapDB())
+	blake2bMap := newTestMap(mapdb.NewMapDB(), opts...)
+	for _, m := range []*authenticatedMap[[32]byte, testKey, testValue]{sha256Map, blake2bMap} {
+		require.NoError(t, m.Set(testKey{'a'}, testValueFromString("value a")))
+		require.NoError(t, m.Set(testKey{'b'}, testValueFromString("value b")))
+
+		// uncommitted values can be read before they are committed
+		value, exists, err := m.Get(testKey{'a'})
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, testValueFromString("value a"), value)
+
+		require.NoError(t, m.Commit())
+	}
+	require.NotEqual(t, sha256Map.Root(), blake2bMap.Root())
+
+	// the values can be read, streamed and proven
+	value, exists, err := blake2bMap.Get(testKey{'b'})
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, testValueFromString("value b"), value)
+	require.Equal(t, map[testKey]testValue{{'a'}: testValueFromString("value a"), {'b'}: testValueFromString("value b")}, streamMap(t, blake2bMap))
+
+	proof, err := blake2bMap.Proof(testKey{'a'})
+	require.NoError(t, err)
+	require.True(t, lo.PanicOnErr(VerifyProof(blake2bMap.Root(), []byte{'a'}, []byte("value a"), proof, opts...)))
+	require.False(t, lo.PanicOnErr(VerifyProof(blake2bMap.Root(), []byte{'a'}, []byte("value a"), proof)))
+	require.False(t, lo.PanicOnErr(VerifyProof(blake2bMap.Root(), []byte{'a'}, []byte("value b"), proof, opts...)))
+
+	nonMembershipProof, err := blake2bMap.Proof(testKey{'c'})
+	require.NoError(t, err)
+	require.True(t, lo.PanicOnErr(VerifyNonMembershipProof(blake2bMap.Root(), []byte{'c'}, nonMembershipProof, opts...)))
+
+	// older versions keep their values until they are pruned
+	require.NoError(t, blake2bMap.Set(testKey{'a'}, testValueFromString("new value a")))
+	require.NoError(t, blake2bMap.Commit())
+
+	value, exists, err = blake2bMap.GetAt(1, testKey{'a'})
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, testValueFromString("value a"), value)
+
+	require.NoError(t, blake2bMap.Rollback(1))
+	value, exists, err = blake2bMap.Get(testKey{'a'})
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, testValueFromString("value a"), value)
+
+	// discarded values are not persisted
+	require.NoError(t, blake2bMap.Set(testKey{'c'}, testValueFromString("value c")))
+	blake2bMap.Discard()
+	require.Empty(t, blake2bMap.treeStore.pendingValues)
+
+	// imported maps produce the same tree
+	importedMap := newTestMap(mapdb.NewMapDB(), opts...)
+	require.NoError(t, importedMap.Import(func(consumer func(key testKey, value testValue) error) error {
+		if err := consumer(testKey{'a'}, testValueFromString("value a")); err != nil {
+			return err
+		}
+
+		return consumer(testKey{'b'}, testValueFromString("value b"))
+	}))
+	require.Equal(t, blake2bMap.Root(), importedMap.Root())
+
+	value, exists, err = importedMap.Get(testKey{'b'})
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, testValueFromString("value b"), value)
+
+	// the diff fetches the values of the remote leaves
+	diffMap := newTestMap(mapdb.NewMapDB(), opts...)
+	require.NoError(t, diffMap.Set(testKey{'a'}, testValueFromString("other value a")))
+	require.NoError(t, diffMap.Commit())
+
+	differences := make(map[testKey]string)
+	require.NoError(t, diffMap.Diff(NewLocalSyncTransport[[32]byte](blake2bMap), blake2bMap.Root(), func(key testKey, remoteValue testValue, existsRemotely bool) error {
+		require.True(t, existsRemotely)
+		differences[key] = string(remoteValue)
+
+		return nil
+	}))
+	require.Equal(t, map[testKey]string{{'a'}: "value a", {'b'}: "value b"}, differences)
+}
+
+func TestMapSettingsMismatch(t *testing.T) {
+	newTestMap := func(store kvstore.KVStore, opts ...Option) *authenticatedMap[[32]byte, testKey, testValue] {
+		return newAuthenticatedMap[[32]byte](store,
+			typeutils.ByteArray32ToBytes,
+			typeutils.ByteArray32FromBytes,
+			testKey.Bytes,
+			testKeyFromBytes,
+			testValue.Bytes,
+			testValueFromBytes,
+			opts...,
+		)
+	}
+
+	store := mapdb.NewMapDB()
+	blake2bMap := newTestMap(store, WithHashFunction(BLAKE2b256))
+	require.NoError(t, blake2bMap.Set(testKey{'a'}, testValueFromString("value a")))
+	require.NoError(t, blake2bMap.Commit())
+
+	requireSettingsMismatch := func(store kvstore.KVStore, opts ...Option) {
+		defer func() {
+			err, isError := recover().(error)
+			require.True(t, isError)
+			require.True(t, ierrors.Is(err, ErrSettingsMismatch))
+		}()
+
+		newTestMap(store, opts...)
+	}
+	requireSettingsMismatch(store)
+	requireSettingsMismatch(store, WithHashFunction(BLAKE2b256), WithValueHashing(true))
+
+	restoredMap := newTestMap(store, WithHashFunction(BLAKE2b256))
+	require.Equal(t, blake2bMap.Root(), restoredMap.Root())
+
+	// maps that were created before the settings were stored use the default settings
+	legacyStore := mapdb.NewMapDB()
+	legacyMap := newTestMap(legacyStore)
+	require.NoError(t, legacyMap.Set(testKey{'a'}, testValueFromString("value a")))
+	require.NoError(t, legacyMap.Commit())
+	require.NoError(t, legacyStore.Delete([]byte{prefixSettingsKey}))
+
+	requireSettingsMismatch(legacyStore, WithHashFunction(BLAKE2b256))
+	require.Equal(t, legacyMap.Root(), newTestMap(legacyStore).Root())
+}
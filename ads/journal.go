@@ -0,0 +1,88 @@
+package ads
+
+import (
+	"bytes"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/typeutils"
+)
+
+// journalEntryType is the type of an entry in the journal.
+type journalEntryType uint8
+
+const (
+	// journalCreatedNode marks a node that was created or revived by a version.
+	journalCreatedNode journalEntryType = iota
+	// journalOrphanedNode marks a node that is no longer referenced since a version.
+	journalOrphanedNode
+	// journalAddedKey marks a raw key that was added by a version.
+	journalAddedKey
+	// journalDeletedKey marks a raw key that was deleted by a version.
+	journalDeletedKey
+)
+
+// journalEntry is an entry in the journal.
+type journalEntry struct {
+	key   []byte
+	value []byte
+}
+
+// journal records the changes of each version that are required to roll back or prune it.
+type journal struct {
+	store kvstore.KVStore
+}
+
+// newJournal creates a new journal that is stored in the given store.
+func newJournal(store kvstore.KVStore) *journal {
+	return &journal{
+		store: store,
+	}
+}
+
+// Record adds an entry of the given type to the given version.
+func (j *journal) Record(version uint64, entryType journalEntryType, key []byte, value []byte) error {
+	if err := j.store.Set(byteutils.ConcatBytes(j.prefix(version, entryType), key), value); err != nil {
+		return ierrors.Wrapf(err, "failed to record journal entry for version %d", version)
+	}
+
+	return nil
+}
+
+// Entries returns all entries of the given type of the given version.
+func (j *journal) Entries(version uint64, entryType journalEntryType) (entries []*journalEntry, err error) {
+	prefix := j.prefix(version, entryType)
+	if err = j.store.Iterate(prefix, func(key kvstore.Key, value kvstore.Value) bool {
+		entries = append(entries, &journalEntry{
+			key:   bytes.Clone(key[len(prefix):]),
+			value: bytes.Clone(value),
+		})
+
+		return true
+	}); err != nil {
+		return nil, ierrors.Wrapf(err, "failed to iterate journal entries of version %d", version)
+	}
+
+	return entries, nil
+}
+
+// Drop removes all entries of the given version.
+func (j *journal) Drop(version uint64) error {
+	if err := j.store.DeletePrefix(j.prefix(version)); err != nil {
+		return ierrors.Wrapf(err, "failed to drop journal of version %d", version)
+	}
+
+	return nil
+}
+
+// prefix returns the key prefix of the entries of the given version (and type).
+func (j *journal) prefix(version uint64, entryType ...journalEntryType) []byte {
+	prefix := lo.PanicOnErr(typeutils.Uint64ToBytes(version))
+	for _, t := range entryType {
+		prefix = append(prefix, byte(t))
+	}
+
+	return prefix
+}
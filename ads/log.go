@@ -0,0 +1,53 @@
+package ads
+
+import (
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+// Log is an append-only list that can produce proofs for its elements and for its append-only growth which can be
+// verified against a known merkle root that is formed using a merkle mountain range.
+type Log[IdentifierType types.IdentifierType, V any] interface {
+	// Append appends the given value to the log and returns its index.
+	Append(value V) (index uint64, err error)
+
+	// Get returns the value at the given index.
+	Get(index uint64) (value V, exists bool, err error)
+
+	// Stream streams the values of the log in the order of their indexes to the given consumer function.
+	Stream(consumerFunc func(index uint64, value V) error) error
+
+	// Root returns the root of the log.
+	Root() IdentifierType
+
+	// RootAt returns the root that the log had when it contained the given number of elements.
+	RootAt(size uint64) (root IdentifierType, err error)
+
+	// Size returns the number of elements in the log.
+	Size() uint64
+
+	// Proof returns a proof for the inclusion of the element at the given index that can be verified against the
+	// current root using VerifyLogInclusionProof.
+	Proof(index uint64) (proof *LogInclusionProof[IdentifierType], err error)
+
+	// ConsistencyProof returns a proof that the log with the given new size is an extension of the log with the
+	// given old size that can be verified against both roots using VerifyLogConsistencyProof.
+	ConsistencyProof(oldSize uint64, newSize uint64) (proof *LogConsistencyProof[IdentifierType], err error)
+
+	// WasRestoredFromStorage returns true if the log was restored from an existing storage.
+	WasRestoredFromStorage() bool
+}
+
+// NewLog creates a new authenticated Log.
+//
+// Only the hash function of the options is used. It panics if the log is restored from storage with a different
+// hash function (ErrSettingsMismatch) or if the size of the hash function does not match the size of the
+// IdentifierType.
+func NewLog[IdentifierType types.IdentifierType, V any](
+	store kvstore.KVStore,
+	valueToBytes kvstore.ObjectToBytes[V],
+	bytesToValue kvstore.BytesToObject[V],
+	opts ...Option,
+) Log[IdentifierType, V] {
+	return newAuthenticatedLog[IdentifierType](store, valueToBytes, bytesToValue, opts...)
+}
@@ -0,0 +1,392 @@
+package ads
+
+import (
+	"bytes"
+	"hash"
+	"math/bits"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/typeutils"
+)
+
+const (
+	prefixLogSizeKey uint8 = iota
+	prefixLogSettingsKey
+	prefixLogValuesStorage
+	prefixLogNodesStorage
+)
+
+// ErrOutOfRange is returned if an index or size exceeds the size of a Log.
+var ErrOutOfRange = ierrors.New("out of range")
+
+// authenticatedLog is an append-only list that is authenticated by a merkle mountain range.
+//
+// The log stores the roots of the perfect binary subtrees of its elements (the mountains) and every node below them.
+// The root of the log is formed by bagging the mountains from right to left, which results in the same root as the
+// merkle tree of RFC 9162 and allows to use its inclusion and consistency proofs.
+type authenticatedLog[IdentifierType types.IdentifierType, V any] struct {
+	values   kvstore.KVStore
+	nodes    kvstore.KVStore
+	sizeKey  *kvstore.TypedValue[uint64]
+	size     uint64
+	settings *settings
+	mutex    sync.RWMutex
+
+	valueToBytes kvstore.ObjectToBytes[V]
+	bytesToValue kvstore.BytesToObject[V]
+}
+
+// newAuthenticatedLog creates a new authenticated log.
+func newAuthenticatedLog[IdentifierType types.IdentifierType, V any](
+	store kvstore.KVStore,
+	valueToBytes kvstore.ObjectToBytes[V],
+	bytesToValue kvstore.BytesToObject[V],
+	opts ...Option,
+) *authenticatedLog[IdentifierType, V] {
+	newLog := &authenticatedLog[IdentifierType, V]{
+		values:       lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixLogValuesStorage})),
+		nodes:        lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixLogNodesStorage})),
+		sizeKey:      kvstore.NewTypedValue(store, []byte{prefixLogSizeKey}, typeutils.Uint64ToBytes, typeutils.Uint64FromBytes),
+		settings:     newSettings(opts),
+		valueToBytes: valueToBytes,
+		bytesToValue: bytesToValue,
+	}
+
+	if err := newLog.checkSettings(store); err != nil {
+		panic(err)
+	}
+
+	if size, err := newLog.sizeKey.Get(); err == nil {
+		newLog.size = size
+	} else if !ierrors.Is(err, kvstore.ErrKeyNotFound) {
+		panic(ierrors.Wrap(err, "failed to get size"))
+	}
+
+	return newLog
+}
+
+// Append appends the given value to the log and returns its index.
+func (l *authenticatedLog[IdentifierType, V]) Append(value V) (index uint64, err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	valueBytes, err := l.valueToBytes(value)
+	if err != nil {
+		return 0, ierrors.Wrap(err, "failed to serialize value")
+	}
+
+	index = l.size
+	if err = l.values.Set(lo.PanicOnErr(typeutils.Uint64ToBytes(index)), valueBytes); err != nil {
+		return 0, ierrors.Wrapf(err, "failed to store value %d", index)
+	}
+
+	// store the new leaf and merge the mountains of equal height on its left
+	hasher := l.settings.hashFunction.New()
+	nodeHash := digest(hasher, leafPrefix, valueBytes)
+	for height, position := 0, index; ; height, position = height+1, position>>1 {
+		if err = l.nodes.Set(logNodeKey(height, position), nodeHash); err != nil {
+			return 0, ierrors.Wrapf(err, "failed to store node %d at height %d", position, height)
+		}
+
+		if position&1 == 0 {
+			break
+		}
+
+		leftSibling, err := l.node(height, position-1)
+		if err != nil {
+			return 0, err
+		}
+
+		nodeHash = digest(hasher, innerPrefix, leftSibling, nodeHash)
+	}
+
+	if err = l.sizeKey.Set(index + 1); err != nil {
+		return 0, ierrors.Wrap(err, "failed to store size")
+	}
+	l.size = index + 1
+
+	return index, nil
+}
+
+// Get returns the value at the given index.
+func (l *authenticatedLog[IdentifierType, V]) Get(index uint64) (value V, exists bool, err error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if index >= l.size {
+		return value, false, nil
+	}
+
+	if value, err = l.get(index); err != nil {
+		return value, false, err
+	}
+
+	return value, true, nil
+}
+
+// Stream streams the values of the log in the order of their indexes to the given consumer function.
+func (l *authenticatedLog[IdentifierType, V]) Stream(consumerFunc func(index uint64, value V) error) error {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	for index := uint64(0); index < l.size; index++ {
+		value, err := l.get(index)
+		if err != nil {
+			return err
+		}
+
+		if err = consumerFunc(index, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Root returns the root of the log.
+func (l *authenticatedLog[IdentifierType, V]) Root() IdentifierType {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return lo.PanicOnErr(l.rootAt(l.size))
+}
+
+// RootAt returns the root that the log had when it contained the given number of elements.
+func (l *authenticatedLog[IdentifierType, V]) RootAt(size uint64) (root IdentifierType, err error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if size > l.size {
+		return root, ierrors.Wrapf(ErrOutOfRange, "size %d exceeds size of log %d", size, l.size)
+	}
+
+	return l.rootAt(size)
+}
+
+// Size returns the number of elements in the log.
+func (l *authenticatedLog[IdentifierType, V]) Size() uint64 {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.size
+}
+
+// Proof returns a proof for the inclusion of the element at the given index.
+func (l *authenticatedLog[IdentifierType, V]) Proof(index uint64) (proof *LogInclusionProof[IdentifierType], err error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if index >= l.size {
+		return nil, ierrors.Wrapf(ErrOutOfRange, "index %d exceeds size of log %d", index, l.size)
+	}
+
+	hashes, err := l.inclusionPath(l.settings.hashFunction.New(), index, 0, l.size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogInclusionProof[IdentifierType]{
+		Index:  index,
+		Size:   l.size,
+		Hashes: identifiers[IdentifierType](hashes),
+	}, nil
+}
+
+// ConsistencyProof returns a proof that the log with the given new size is an extension of the log with the given
+// old size.
+func (l *authenticatedLog[IdentifierType, V]) ConsistencyProof(oldSize uint64, newSize uint64) (proof *LogConsistencyProof[IdentifierType], err error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if oldSize > newSize || newSize > l.size {
+		return nil, ierrors.Wrapf(ErrOutOfRange, "invalid sizes %d and %d for log of size %d", oldSize, newSize, l.size)
+	}
+
+	var hashes [][]byte
+	if oldSize != 0 {
+		if hashes, err = l.consistencyPath(l.settings.hashFunction.New(), oldSize, 0, newSize, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return &LogConsistencyProof[IdentifierType]{
+		OldSize: oldSize,
+		NewSize: newSize,
+		Hashes:  identifiers[IdentifierType](hashes),
+	}, nil
+}
+
+// WasRestoredFromStorage returns true if the log has been restored from storage.
+func (l *authenticatedLog[IdentifierType, V]) WasRestoredFromStorage() bool {
+	return lo.PanicOnErr(l.sizeKey.Has())
+}
+
+// get returns the value at the given index.
+func (l *authenticatedLog[IdentifierType, V]) get(index uint64) (value V, err error) {
+	valueBytes, err := l.values.Get(lo.PanicOnErr(typeutils.Uint64ToBytes(index)))
+	if err != nil {
+		return value, ierrors.Wrapf(err, "failed to get value %d", index)
+	}
+
+	if value, _, err = l.bytesToValue(valueBytes); err != nil {
+		return value, ierrors.Wrapf(err, "failed to deserialize value %d", index)
+	}
+
+	return value, nil
+}
+
+// rootAt returns the root of the first elements of the log up to the given size.
+func (l *authenticatedLog[IdentifierType, V]) rootAt(size uint64) (root IdentifierType, err error) {
+	if size == 0 {
+		return root, nil
+	}
+
+	rootBytes, err := l.subtreeHash(l.settings.hashFunction.New(), 0, size)
+	if err != nil {
+		return root, err
+	}
+	copy(root[:], rootBytes)
+
+	return root, nil
+}
+
+// subtreeHash returns the hash of the elements in the range [start, end), where start is aligned to the largest
+// power of two that is smaller than the number of elements.
+func (l *authenticatedLog[IdentifierType, V]) subtreeHash(hasher hash.Hash, start uint64, end uint64) ([]byte, error) {
+	// perfect subtrees are stored as the nodes of the mountains
+	if size := end - start; size&(size-1) == 0 {
+		return l.node(bits.TrailingZeros64(size), start/size)
+	}
+
+	split := logSplit(end - start)
+	leftHash, err := l.node(bits.TrailingZeros64(split), start/split)
+	if err != nil {
+		return nil, err
+	}
+
+	rightHash, err := l.subtreeHash(hasher, start+split, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return digest(hasher, innerPrefix, leftHash, rightHash), nil
+}
+
+// inclusionPath returns the hashes that are required to compute the hash of the range [start, end) from the leaf
+// at the given index (ordered from the leaf to the root).
+func (l *authenticatedLog[IdentifierType, V]) inclusionPath(hasher hash.Hash, index uint64, start uint64, end uint64) ([][]byte, error) {
+	if end-start == 1 {
+		return nil, nil
+	}
+
+	split := start + logSplit(end-start)
+	if index < split {
+		return l.extendPath(hasher, func() ([][]byte, error) {
+			return l.inclusionPath(hasher, index, start, split)
+		}, split, end)
+	}
+
+	return l.extendPath(hasher, func() ([][]byte, error) {
+		return l.inclusionPath(hasher, index, split, end)
+	}, start, split)
+}
+
+// consistencyPath returns the hashes that are required to prove that the range [start, end) is an extension of the
+// range [start, start+oldSize). The hash of the old range is omitted if it is known to the verifier (complete).
+func (l *authenticatedLog[IdentifierType, V]) consistencyPath(hasher hash.Hash, oldSize uint64, start uint64, end uint64, complete bool) ([][]byte, error) {
+	if oldSize == end-start {
+		if complete {
+			return nil, nil
+		}
+
+		subtreeHash, err := l.subtreeHash(hasher, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]byte{subtreeHash}, nil
+	}
+
+	split := logSplit(end - start)
+	if oldSize <= split {
+		return l.extendPath(hasher, func() ([][]byte, error) {
+			return l.consistencyPath(hasher, oldSize, start, start+split, complete)
+		}, start+split, end)
+	}
+
+	return l.extendPath(hasher, func() ([][]byte, error) {
+		return l.consistencyPath(hasher, oldSize-split, start+split, end, false)
+	}, start, start+split)
+}
+
+// extendPath appends the hash of the sibling range [start, end) to the path that is returned by the given function.
+func (l *authenticatedLog[IdentifierType, V]) extendPath(hasher hash.Hash, path func() ([][]byte, error), start uint64, end uint64) ([][]byte, error) {
+	hashes, err := path()
+	if err != nil {
+		return nil, err
+	}
+
+	siblingHash, err := l.subtreeHash(hasher, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(hashes, siblingHash), nil
+}
+
+// node returns the hash of the node at the given height and position.
+func (l *authenticatedLog[IdentifierType, V]) node(height int, position uint64) ([]byte, error) {
+	nodeHash, err := l.nodes.Get(logNodeKey(height, position))
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to get node %d at height %d", position, height)
+	}
+
+	return nodeHash, nil
+}
+
+// checkSettings stores the hash function of new logs and verifies that restored logs use the same hash function.
+func (l *authenticatedLog[IdentifierType, V]) checkSettings(store kvstore.KVStore) error {
+	var identifier IdentifierType
+	if hashSize := l.settings.hashFunction.New().Size(); hashSize != len(identifier) {
+		return ierrors.Errorf("hash size %d of %s does not match identifier size %d", hashSize, l.settings.hashFunction.Name(), len(identifier))
+	}
+
+	storedSettings, err := store.Get([]byte{prefixLogSettingsKey})
+	if err != nil && !ierrors.Is(err, kvstore.ErrKeyNotFound) {
+		return ierrors.Wrap(err, "failed to get settings")
+	}
+
+	if storedSettings != nil && !bytes.Equal(storedSettings, []byte(l.settings.hashFunction.Name())) {
+		return ierrors.Wrapf(ErrSettingsMismatch, "stored hash function %s does not match %s", storedSettings, l.settings.hashFunction.Name())
+	}
+
+	if err = store.Set([]byte{prefixLogSettingsKey}, []byte(l.settings.hashFunction.Name())); err != nil {
+		return ierrors.Wrap(err, "failed to store settings")
+	}
+
+	return nil
+}
+
+// logNodeKey returns the storage key of the node at the given height and position.
+func logNodeKey(height int, position uint64) []byte {
+	return append([]byte{byte(height)}, lo.PanicOnErr(typeutils.Uint64ToBytes(position))...)
+}
+
+// logSplit returns the largest power of two that is smaller than the given size (which must be larger than 1).
+func logSplit(size uint64) uint64 {
+	return 1 << (bits.Len64(size-1) - 1)
+}
+
+// identifiers converts the given hashes to identifiers.
+func identifiers[IdentifierType types.IdentifierType](hashes [][]byte) []IdentifierType {
+	result := make([]IdentifierType, len(hashes))
+	for i, hashBytes := range hashes {
+		copy(result[i][:], hashBytes)
+	}
+
+	return result
+}
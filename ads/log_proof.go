@@ -0,0 +1,145 @@
+package ads
+
+import (
+	"bytes"
+
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// maxLogProofHashes is the maximum number of hashes of a log proof (one per level of the tree and the old root).
+	maxLogProofHashes = 65
+)
+
+// LogInclusionProof is a proof for the inclusion of an element in a Log.
+// It can be serialized using serix.
+type LogInclusionProof[IdentifierType types.IdentifierType] struct {
+	// Index is the index of the element.
+	Index uint64 `serix:"index"`
+	// Size is the size of the log that the proof was created for.
+	Size uint64 `serix:"size"`
+	// Hashes contains the hashes of the sibling subtrees along the path from the element to the root.
+	Hashes []IdentifierType `serix:"hashes,lenPrefix=uint8"`
+}
+
+// LogConsistencyProof is a proof that a Log is an extension of a smaller version of itself.
+// It can be serialized using serix.
+type LogConsistencyProof[IdentifierType types.IdentifierType] struct {
+	// OldSize is the size of the smaller version of the log.
+	OldSize uint64 `serix:"oldSize"`
+	// NewSize is the size of the larger version of the log.
+	NewSize uint64 `serix:"newSize"`
+	// Hashes contains the hashes of the subtrees that are required to compute both roots.
+	Hashes []IdentifierType `serix:"hashes,lenPrefix=uint8"`
+}
+
+// VerifyLogInclusionProof verifies that the given value is the element at the index of the proof in the Log with the
+// given root. The options need to match the options that the Log was created with.
+func VerifyLogInclusionProof[IdentifierType types.IdentifierType](root IdentifierType, value []byte, proof *LogInclusionProof[IdentifierType], opts ...Option) (bool, error) {
+	if proof.Index >= proof.Size {
+		return false, ierrors.Wrapf(ErrInvalidProof, "index %d exceeds size %d", proof.Index, proof.Size)
+	}
+
+	if len(proof.Hashes) > maxLogProofHashes {
+		return false, ierrors.Wrapf(ErrInvalidProof, "too many hashes: %d", len(proof.Hashes))
+	}
+
+	hasher := newSettings(opts).hashFunction.New()
+	currentHash := digest(hasher, leafPrefix, value)
+
+	// walk up the tree as described in RFC 9162, section 2.1.3.2
+	index, lastIndex := proof.Index, proof.Size-1
+	for _, siblingHash := range proof.Hashes {
+		if lastIndex == 0 {
+			return false, ierrors.Wrap(ErrInvalidProof, "too many hashes")
+		}
+
+		if index&1 == 1 || index == lastIndex {
+			currentHash = digest(hasher, innerPrefix, siblingHash[:], currentHash)
+
+			// skip the levels where the node has no sibling
+			for index&1 == 0 && index != 0 {
+				index, lastIndex = index>>1, lastIndex>>1
+			}
+		} else {
+			currentHash = digest(hasher, innerPrefix, currentHash, siblingHash[:])
+		}
+
+		index, lastIndex = index>>1, lastIndex>>1
+	}
+
+	if lastIndex != 0 {
+		return false, ierrors.Wrap(ErrInvalidProof, "too few hashes")
+	}
+
+	return bytes.Equal(currentHash, root[:]), nil
+}
+
+// VerifyLogConsistencyProof verifies that the Log with the given new root is an extension of the Log with the given
+// old root. The options need to match the options that the Log was created with.
+func VerifyLogConsistencyProof[IdentifierType types.IdentifierType](oldRoot IdentifierType, newRoot IdentifierType, proof *LogConsistencyProof[IdentifierType], opts ...Option) (bool, error) {
+	if proof.OldSize > proof.NewSize {
+		return false, ierrors.Wrapf(ErrInvalidProof, "old size %d exceeds new size %d", proof.OldSize, proof.NewSize)
+	}
+
+	if len(proof.Hashes) > maxLogProofHashes {
+		return false, ierrors.Wrapf(ErrInvalidProof, "too many hashes: %d", len(proof.Hashes))
+	}
+
+	// every log is an extension of the empty log and of itself
+	if proof.OldSize == 0 || proof.OldSize == proof.NewSize {
+		if len(proof.Hashes) != 0 {
+			return false, ierrors.Wrap(ErrInvalidProof, "too many hashes")
+		}
+
+		var emptyRoot IdentifierType
+
+		return (proof.OldSize == 0 && oldRoot == emptyRoot) || (proof.OldSize != 0 && oldRoot == newRoot), nil
+	}
+
+	hashes := proof.Hashes
+
+	// the old root is omitted from the proof if it is the root of a perfect subtree
+	if proof.OldSize&(proof.OldSize-1) == 0 {
+		hashes = append([]IdentifierType{oldRoot}, hashes...)
+	}
+
+	if len(hashes) == 0 {
+		return false, ierrors.Wrap(ErrInvalidProof, "too few hashes")
+	}
+
+	// compute both roots as described in RFC 9162, section 2.1.4.2
+	hasher := newSettings(opts).hashFunction.New()
+	index, lastIndex := proof.OldSize-1, proof.NewSize-1
+	for index&1 == 1 {
+		index, lastIndex = index>>1, lastIndex>>1
+	}
+
+	oldHash, newHash := hashes[0][:], hashes[0][:]
+	for _, nodeHash := range hashes[1:] {
+		if lastIndex == 0 {
+			return false, ierrors.Wrap(ErrInvalidProof, "too many hashes")
+		}
+
+		if index&1 == 1 || index == lastIndex {
+			oldHash = digest(hasher, innerPrefix, nodeHash[:], oldHash)
+			newHash = digest(hasher, innerPrefix, nodeHash[:], newHash)
+
+			// skip the levels where the node has no sibling
+			for index&1 == 0 && index != 0 {
+				index, lastIndex = index>>1, lastIndex>>1
+			}
+		} else {
+			newHash = digest(hasher, innerPrefix, newHash, nodeHash[:])
+		}
+
+		index, lastIndex = index>>1, lastIndex>>1
+	}
+
+	if lastIndex != 0 {
+		return false, ierrors.Wrap(ErrInvalidProof, "too few hashes")
+	}
+
+	return bytes.Equal(oldHash, oldRoot[:]) && bytes.Equal(newHash, newRoot[:]), nil
+}
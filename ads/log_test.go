@@ -0,0 +1,164 @@
+package ads
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+func TestLog(t *testing.T) {
+	store := mapdb.NewMapDB()
+	newLog := newAuthenticatedLog[[32]byte](store, testValue.Bytes, testValueFromBytes)
+	require.False(t, newLog.WasRestoredFromStorage())
+	require.Equal(t, uint64(0), newLog.Size())
+	require.Equal(t, [32]byte{}, newLog.Root())
+
+	_, exists, err := newLog.Get(0)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	_, err = newLog.Proof(0)
+	require.True(t, ierrors.Is(err, ErrOutOfRange))
+
+	values := make([][]byte, 0)
+	roots := [][32]byte{newLog.Root()}
+	for i := 0; i < 33; i++ {
+		value := []byte{byte(i)}
+		index, err := newLog.Append(value)
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), index)
+
+		values = append(values, value)
+		roots = append(roots, newLog.Root())
+		require.Equal(t, expectedLogRoot(values), roots[len(roots)-1])
+
+		// every element can be proven against the current root
+		for j, value := range values {
+			proof, err := newLog.Proof(uint64(j))
+			require.NoError(t, err)
+			require.True(t, lo.PanicOnErr(VerifyLogInclusionProof(newLog.Root(), value, proof)))
+			require.False(t, lo.PanicOnErr(VerifyLogInclusionProof(newLog.Root(), []byte("other value"), proof)))
+
+			if len(values) > 1 {
+				require.False(t, lo.PanicOnErr(VerifyLogInclusionProof(roots[len(roots)-2], value, proof)))
+			}
+		}
+
+		// the current log is an extension of every previous log
+		for oldSize := range roots {
+			proof, err := newLog.ConsistencyProof(uint64(oldSize), newLog.Size())
+			require.NoError(t, err)
+			require.True(t, lo.PanicOnErr(VerifyLogConsistencyProof(roots[oldSize], newLog.Root(), proof)))
+
+			if oldSize != 0 && oldSize != len(values) {
+				require.False(t, lo.PanicOnErr(VerifyLogConsistencyProof(roots[oldSize-1], newLog.Root(), proof)))
+			}
+		}
+	}
+
+	for size, root := range roots {
+		require.Equal(t, root, lo.PanicOnErr(newLog.RootAt(uint64(size))))
+	}
+
+	_, err = newLog.RootAt(uint64(len(roots)))
+	require.True(t, ierrors.Is(err, ErrOutOfRange))
+
+	_, err = newLog.ConsistencyProof(2, 1)
+	require.True(t, ierrors.Is(err, ErrOutOfRange))
+
+	// proofs between previous sizes can be verified against the previous roots
+	proof, err := newLog.ConsistencyProof(5, 11)
+	require.NoError(t, err)
+	require.True(t, lo.PanicOnErr(VerifyLogConsistencyProof(roots[5], roots[11], proof)))
+	require.False(t, lo.PanicOnErr(VerifyLogConsistencyProof(roots[5], roots[12], proof)))
+
+	// malformed proofs are rejected
+	_, err = VerifyLogInclusionProof(newLog.Root(), values[0], &LogInclusionProof[[32]byte]{Index: 1, Size: 1})
+	require.True(t, ierrors.Is(err, ErrInvalidProof))
+	_, err = VerifyLogInclusionProof(newLog.Root(), values[0], &LogInclusionProof[[32]byte]{Index: 0, Size: 4})
+	require.True(t, ierrors.Is(err, ErrInvalidProof))
+	_, err = VerifyLogConsistencyProof(roots[3], roots[4], &LogConsistencyProof[[32]byte]{OldSize: 3, NewSize: 4})
+	require.True(t, ierrors.Is(err, ErrInvalidProof))
+
+	// the values can be read and streamed
+	value, exists, err := newLog.Get(7)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, testValue{7}, value)
+
+	streamedValues := make([][]byte, 0)
+	require.NoError(t, newLog.Stream(func(index uint64, value testValue) error {
+		require.Equal(t, uint64(len(streamedValues)), index)
+		streamedValues = append(streamedValues, value)
+
+		return nil
+	}))
+	require.Equal(t, values, streamedValues)
+
+	// the log can be restored
+	restoredLog := newAuthenticatedLog[[32]byte](store, testValue.Bytes, testValueFromBytes)
+	require.True(t, restoredLog.WasRestoredFromStorage())
+	require.Equal(t, newLog.Size(), restoredLog.Size())
+	require.Equal(t, newLog.Root(), restoredLog.Root())
+
+	require.Panics(t, func() {
+		newAuthenticatedLog[[32]byte](store, testValue.Bytes, testValueFromBytes, WithHashFunction(BLAKE2b256))
+	})
+}
+
+func TestLogProofSerialization(t *testing.T) {
+	newLog := newAuthenticatedLog[[32]byte](mapdb.NewMapDB(), testValue.Bytes, testValueFromBytes, WithHashFunction(BLAKE2b256))
+	for i := 0; i < 10; i++ {
+		_, err := newLog.Append(testValue{byte(i)})
+		require.NoError(t, err)
+	}
+
+	api := serix.NewAPI()
+
+	inclusionProof, err := newLog.Proof(3)
+	require.NoError(t, err)
+
+	decodedInclusionProof := new(LogInclusionProof[[32]byte])
+	_, err = api.Decode(context.Background(), lo.PanicOnErr(api.Encode(context.Background(), inclusionProof)), decodedInclusionProof)
+	require.NoError(t, err)
+	require.Equal(t, inclusionProof, decodedInclusionProof)
+	require.True(t, lo.PanicOnErr(VerifyLogInclusionProof(newLog.Root(), []byte{3}, decodedInclusionProof, WithHashFunction(BLAKE2b256))))
+	require.False(t, lo.PanicOnErr(VerifyLogInclusionProof(newLog.Root(), []byte{3}, decodedInclusionProof)))
+
+	consistencyProof, err := newLog.ConsistencyProof(3, 10)
+	require.NoError(t, err)
+
+	decodedConsistencyProof := new(LogConsistencyProof[[32]byte])
+	_, err = api.Decode(context.Background(), lo.PanicOnErr(api.Encode(context.Background(), consistencyProof)), decodedConsistencyProof)
+	require.NoError(t, err)
+	require.Equal(t, consistencyProof, decodedConsistencyProof)
+	require.True(t, lo.PanicOnErr(VerifyLogConsistencyProof(lo.PanicOnErr(newLog.RootAt(3)), newLog.Root(), decodedConsistencyProof, WithHashFunction(BLAKE2b256))))
+}
+
+// expectedLogRoot computes the root of the given values as defined in RFC 9162.
+func expectedLogRoot(values [][]byte) (root [32]byte) {
+	var treeHash func(values [][]byte) []byte
+	treeHash = func(values [][]byte) []byte {
+		if len(values) == 1 {
+			return digest(sha256.New(), leafPrefix, values[0])
+		}
+
+		split := 1
+		for split*2 < len(values) {
+			split *= 2
+		}
+
+		return digest(sha256.New(), innerPrefix, treeHash(values[:split]), treeHash(values[split:]))
+	}
+
+	copy(root[:], treeHash(values))
+
+	return root
+}
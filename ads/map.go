@@ -20,15 +20,57 @@ type Map[IdentifierType types.IdentifierType, K, V any] interface {
 	// Delete deletes the given key.
 	Delete(key K) (deleted bool, err error)
 
-	// Stream streams all key-value pairs to the given consumer function.
+	// Stream streams all key-value pairs to the given consumer function in the order of their serialized keys.
 	Stream(consumerFunc func(key K, value V) error) error
 
-	// Commit persists the changes to the underlying store.
+	// StreamPage streams the key-value pairs that match the given options to the given consumer function in the order
+	// of their serialized keys and returns the cursor at which the next page starts (nil if there are no more pairs).
+	// The map is not locked while the page is streamed.
+	StreamPage(consumerFunc func(key K, value V) error, opts ...StreamOption) (cursor []byte, err error)
+
+	// Import bulk-loads the key-value pairs of the given iterator (e.g. the Stream method of another Map) into the
+	// empty map and commits them as a new version.
+	Import(iterator func(consumerFunc func(key K, value V) error) error) error
+
+	// Commit persists the staged changes to the underlying store and records them as a new version.
 	Commit() error
 
+	// Version returns the latest committed version.
+	Version() uint64
+
+	// RootAt returns the root of the sparse merkle tree at the given retained version.
+	RootAt(version uint64) (root IdentifierType, err error)
+
+	// GetAt returns the value for the given key at the given retained version.
+	GetAt(version uint64, key K) (value V, exists bool, err error)
+
+	// ProofAt returns a proof for the membership or non-membership of the given key that can be verified against
+	// the root of the given retained version.
+	ProofAt(version uint64, key K) (proof *Proof[IdentifierType], err error)
+
+	// Rollback reverts the map to the given retained version and discards all uncommitted changes.
+	Rollback(version uint64) error
+
+	// Discard discards all uncommitted changes.
+	Discard() error
+
+	// Diff compares the latest committed version with the remote map that is reachable through the given transport
+	// and streams the keys whose values differ from the remote map with the given root to the consumer.
+	// The map is not locked while the transport or the consumer is called, so the consumer can repair the map with
+	// Set and Delete. The changes must not be committed or rolled back before Diff returns, otherwise it fails with
+	// ErrVersionChanged.
+	Diff(remote SyncTransport[IdentifierType], remoteRoot IdentifierType, consumer func(key K, remoteValue V, existsRemotely bool) error) error
+
+	// HandleSyncRequest returns the requested nodes and keys of the committed versions to a remote map.
+	HandleSyncRequest(request *SyncRequest[IdentifierType]) (response *SyncResponse, err error)
+
 	// Root returns the root of the sparse merkle tree.
 	Root() IdentifierType
 
+	// Proof returns a proof for the membership or non-membership of the given key that can be verified against
+	// the current root using VerifyProof or VerifyNonMembershipProof.
+	Proof(key K) (proof *Proof[IdentifierType], err error)
+
 	// Size returns the number of elements in the map.
 	Size() int
 
@@ -37,6 +79,9 @@ type Map[IdentifierType types.IdentifierType, K, V any] interface {
 }
 
 // NewMap creates a new AuthenticatedMap.
+//
+// It panics if the map is restored from storage with a different hash function or value hashing setting
+// (ErrSettingsMismatch) or if the size of the hash function does not match the size of the IdentifierType.
 func NewMap[IdentifierType types.IdentifierType, K, V any](
 	store kvstore.KVStore,
 	identifierToBytes kvstore.ObjectToBytes[IdentifierType],
@@ -45,6 +90,7 @@ func NewMap[IdentifierType types.IdentifierType, K, V any](
 	bytesToKey kvstore.BytesToObject[K],
 	valueToBytes kvstore.ObjectToBytes[V],
 	bytesToValue kvstore.BytesToObject[V],
+	opts ...Option,
 ) Map[IdentifierType, K, V] {
-	return newAuthenticatedMap[IdentifierType](store, identifierToBytes, bytesToIdentifier, keyToBytes, bytesToKey, valueToBytes, bytesToValue)
+	return newAuthenticatedMap[IdentifierType](store, identifierToBytes, bytesToIdentifier, keyToBytes, bytesToKey, valueToBytes, bytesToValue, opts...)
 }
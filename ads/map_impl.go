@@ -1,8 +1,9 @@
 package ads
 
 import (
-	"crypto/sha256"
+	"bytes"
 	"sync"
+	"sync/atomic"
 
 	"github.com/pokt-network/smt"
 
@@ -18,17 +19,59 @@ const (
 	prefixTreeStorage
 	prefixRootKey
 	prefixSizeKey
+	prefixLatestVersionKey
+	prefixOldestVersionKey
+	prefixVersionRootsStorage
+	prefixVersionSizesStorage
+	prefixOrphanedNodesStorage
+	prefixJournalStorage
+	prefixKeyPathsStorage
+	prefixSettingsKey
+	prefixLeafValuesStorage
+	prefixKeyPathsIndexedKey
+)
+
+var (
+	// ErrVersionNotRetained is returned if a version is requested that is not retained (anymore).
+	ErrVersionNotRetained = ierrors.New("version not retained")
+
+	// ErrSettingsMismatch is returned if a map is restored with different settings than it was created with.
+	ErrSettingsMismatch = ierrors.New("settings mismatch")
 )
 
 // AuthenticatedMap is a sparse merkle tree based map.
 type authenticatedMap[IdentifierType types.IdentifierType, K, V any] struct {
 	rawKeysStore *kvstore.TypedStore[K, types.Empty]
 	tree         *smt.SMT
-	size         *kvstore.TypedValue[uint64]
-	root         *kvstore.TypedValue[IdentifierType]
-	mutex        sync.RWMutex
+	treeStore    *mapStoreAdapter
+	// treeReadMutex serializes the reads of the tree that only hold the read lock of the map.
+	treeReadMutex sync.Mutex
+	size          *kvstore.TypedValue[uint64]
+	root          *kvstore.TypedValue[IdentifierType]
+	mutex         sync.RWMutex
+
+	latestVersion *kvstore.TypedValue[uint64]
+	oldestVersion *kvstore.TypedValue[uint64]
+	versionRoots  *kvstore.TypedStore[uint64, IdentifierType]
+	versionSizes  *kvstore.TypedStore[uint64, uint64]
+	journal       *journal
+	settings      *settings
+
+	// keyPaths maps the paths of the keys in the tree to the raw keys, the index is only built once the map is synced.
+	keyPaths kvstore.KVStore
+	// keyPathsIndexedMarker is stored once the key paths are indexed.
+	keyPathsIndexedMarker *kvstore.TypedValue[types.Empty]
+	// keyPathsIndexed is true if the key paths are indexed and need to be maintained.
+	keyPathsIndexed atomic.Bool
+
+	// uncommittedKeys contains the raw keys that were added (true) or deleted (false) since the latest commit.
+	uncommittedKeys map[string]bool
+
+	// uncommittedSizeDelta contains the change of the size since the latest commit.
+	uncommittedSizeDelta int
 
 	keyToBytes   kvstore.ObjectToBytes[K]
+	bytesToKey   kvstore.BytesToObject[K]
 	valueToBytes kvstore.ObjectToBytes[V]
 	bytesToValue kvstore.BytesToObject[V]
 }
@@ -42,24 +85,55 @@ func newAuthenticatedMap[IdentifierType types.IdentifierType, K, V any](
 	bytesToKey kvstore.BytesToObject[K],
 	valueToBytes kvstore.ObjectToBytes[V],
 	bytesToValue kvstore.BytesToObject[V],
+	opts ...Option,
 ) *authenticatedMap[IdentifierType, K, V] {
+	settings := newSettings(opts)
+	journal := newJournal(lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixJournalStorage})))
+	leafValues := lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixLeafValuesStorage}))
+
 	newMap := &authenticatedMap[IdentifierType, K, V]{
 		rawKeysStore: kvstore.NewTypedStore(lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixRawKeysStorage})), keyToBytes, bytesToKey, types.Empty.Bytes, types.EmptyFromBytes),
+		treeStore:    newMapStoreAdapter(lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixTreeStorage})), lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixOrphanedNodesStorage})), journal, leafValues, settings.hashFunction.New().Size(), settings.hashValues, settings.retainedVersions > 0),
 		size:         kvstore.NewTypedValue(store, []byte{prefixSizeKey}, typeutils.Uint64ToBytes, typeutils.Uint64FromBytes),
 		root:         kvstore.NewTypedValue(store, []byte{prefixRootKey}, identifierToBytes, bytesToIdentifier),
 
+		latestVersion:   kvstore.NewTypedValue(store, []byte{prefixLatestVersionKey}, typeutils.Uint64ToBytes, typeutils.Uint64FromBytes),
+		oldestVersion:   kvstore.NewTypedValue(store, []byte{prefixOldestVersionKey}, typeutils.Uint64ToBytes, typeutils.Uint64FromBytes),
+		versionRoots:    kvstore.NewTypedStore(lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixVersionRootsStorage})), typeutils.Uint64ToBytes, typeutils.Uint64FromBytes, identifierToBytes, bytesToIdentifier),
+		versionSizes:    kvstore.NewTypedStore(lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixVersionSizesStorage})), typeutils.Uint64ToBytes, typeutils.Uint64FromBytes, typeutils.Uint64ToBytes, typeutils.Uint64FromBytes),
+		journal:         journal,
+		settings:        settings,
+		uncommittedKeys: make(map[string]bool),
+
+		keyPaths:              lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixKeyPathsStorage})),
+		keyPathsIndexedMarker: kvstore.NewTypedValue(store, []byte{prefixKeyPathsIndexedKey}, types.Empty.Bytes, types.EmptyFromBytes),
+
 		keyToBytes:   keyToBytes,
+		bytesToKey:   bytesToKey,
 		valueToBytes: valueToBytes,
 		bytesToValue: bytesToValue,
 	}
 
-	mapStoreAdapter := newMapStoreAdapter(lo.PanicOnErr(store.WithExtendedRealm([]byte{prefixTreeStorage})))
+	if err := newMap.checkSettings(store); err != nil {
+		panic(err)
+	}
+
 	if root, err := newMap.root.Get(); err == nil {
-		newMap.tree = smt.ImportSparseMerkleTrie(mapStoreAdapter, sha256.New(), root[:], smt.WithValueHasher(nil))
+		newMap.tree = newMap.importTree(root[:])
 	} else {
-		newMap.tree = smt.NewSparseMerkleTrie(mapStoreAdapter, sha256.New(), smt.WithValueHasher(nil))
+		newMap.tree = smt.NewSparseMerkleTrie(newMap.treeStore, settings.hashFunction.New(), newMap.treeOptions()...)
+	}
+
+	if err := newMap.initVersions(); err != nil {
+		panic(ierrors.Wrap(err, "failed to initialize versions"))
 	}
 
+	keyPathsIndexed, err := newMap.keyPathsIndexedMarker.Has()
+	if err != nil {
+		panic(ierrors.Wrap(err, "failed to check if key paths are indexed"))
+	}
+	newMap.keyPathsIndexed.Store(keyPathsIndexed)
+
 	return newMap
 }
 
@@ -77,6 +151,134 @@ func (m *authenticatedMap[IdentifierType, K, V]) Root() (root IdentifierType) {
 	return IdentifierType(m.tree.Root())
 }
 
+// Proof returns a proof for the membership or non-membership of the given key.
+func (m *authenticatedMap[IdentifierType, K, V]) Proof(key K) (proof *Proof[IdentifierType], err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.proof(m.tree, key)
+}
+
+// Version returns the latest committed version of the map.
+func (m *authenticatedMap[IdentifierType, K, V]) Version() uint64 {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	latestVersion, err := m.latestVersion.Get()
+	if err != nil {
+		return 0
+	}
+
+	return latestVersion
+}
+
+// RootAt returns the root of the sparse merkle tree at the given committed version.
+func (m *authenticatedMap[IdentifierType, K, V]) RootAt(version uint64) (root IdentifierType, err error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.rootAt(version)
+}
+
+// ProofAt returns a proof for the membership or non-membership of the given key at the given committed version.
+func (m *authenticatedMap[IdentifierType, K, V]) ProofAt(version uint64, key K) (proof *Proof[IdentifierType], err error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	tree, err := m.treeAt(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.proof(tree, key)
+}
+
+// HasAt returns true if the key existed at the given committed version.
+func (m *authenticatedMap[IdentifierType, K, V]) HasAt(version uint64, key K) (has bool, err error) {
+	_, has, err = m.GetAt(version, key)
+
+	return has, err
+}
+
+// GetAt returns the value for the given key at the given committed version.
+func (m *authenticatedMap[IdentifierType, K, V]) GetAt(version uint64, key K) (value V, exists bool, err error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	tree, err := m.treeAt(version)
+	if err != nil {
+		return value, false, err
+	}
+
+	return m.get(tree, key)
+}
+
+// Rollback reverts the map to the given committed version and discards all uncommitted changes.
+func (m *authenticatedMap[IdentifierType, K, V]) Rollback(version uint64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	oldestVersion, latestVersion, err := m.retainedVersionRange()
+	if err != nil {
+		return err
+	}
+
+	if version < oldestVersion || version > latestVersion {
+		return ierrors.Wrapf(ErrVersionNotRetained, "failed to roll back to version %d", version)
+	}
+
+	m.discardUncommittedChanges()
+
+	for rolledBackVersion := latestVersion; rolledBackVersion > version; rolledBackVersion-- {
+		if err = m.rollbackVersion(rolledBackVersion, oldestVersion); err != nil {
+			return ierrors.Wrapf(err, "failed to roll back version %d", rolledBackVersion)
+		}
+	}
+
+	root, err := m.versionRoots.Get(version)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to get root of version %d", version)
+	}
+
+	size, err := m.versionSizes.Get(version)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to get size of version %d", version)
+	}
+
+	if err = m.root.Set(root); err != nil {
+		return ierrors.Wrap(err, "failed to set root")
+	}
+
+	if err = m.size.Set(size); err != nil {
+		return ierrors.Wrap(err, "failed to set size")
+	}
+
+	m.tree = m.importTree(root[:])
+
+	return nil
+}
+
+// Discard discards all changes since the latest commit.
+func (m *authenticatedMap[IdentifierType, K, V]) Discard() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	latestVersion, err := m.latestVersion.Get()
+	if err != nil {
+		return ierrors.Wrap(err, "failed to get latest version")
+	}
+
+	root, err := m.versionRoots.Get(latestVersion)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to get root of version %d", latestVersion)
+	}
+
+	m.discardUncommittedChanges()
+	m.tree = m.importTree(root[:])
+
+	return nil
+}
+
 // Set sets the output to unspent outputs set.
 func (m *authenticatedMap[IdentifierType, K, V]) Set(key K, value V) error {
 	m.mutex.Lock()
@@ -97,18 +299,17 @@ func (m *authenticatedMap[IdentifierType, K, V]) Set(key K, value V) error {
 		return ierrors.Wrap(err, "failed to check if key exists")
 	}
 
-	if err := m.tree.Update(keyBytes, valueBytes); err != nil {
-		return ierrors.Wrap(err, "failed to update tree")
+	if m.settings.hashValues {
+		m.treeStore.StageValue(digest(m.settings.hashFunction.New(), valueBytes), valueBytes)
 	}
 
-	if err := m.rawKeysStore.Set(key, types.Void); err != nil {
-		return ierrors.Wrap(err, "failed to set raw key")
+	if err := m.tree.Update(keyBytes, valueBytes); err != nil {
+		return ierrors.Wrap(err, "failed to update tree")
 	}
 
 	if !has {
-		if err := m.addSize(1); err != nil {
-			return ierrors.Wrap(err, "failed to increase size")
-		}
+		m.trackKeyChange(keyBytes, true)
+		m.uncommittedSizeDelta++
 	}
 
 	return nil
@@ -120,23 +321,70 @@ func (m *authenticatedMap[IdentifierType, K, V]) Size() int {
 	defer m.mutex.RUnlock()
 
 	size, err := m.size.Get()
-	if err != nil {
+	if err != nil && !ierrors.Is(err, kvstore.ErrKeyNotFound) {
 		return 0
 	}
 
-	return int(size)
+	return int(size) + m.uncommittedSizeDelta
 }
 
-// Commit persists the current state of the map to the storage.
+// Commit persists the current state of the map to the storage and records it as a new version.
 func (m *authenticatedMap[IdentifierType, K, V]) Commit() error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	if err := m.root.Set(IdentifierType(m.tree.Root())); err != nil {
+	return m.commit()
+}
+
+// commit persists the current state of the map to the storage and records it as a new version.
+func (m *authenticatedMap[IdentifierType, K, V]) commit() error {
+	latestVersion, err := m.latestVersion.Get()
+	if err != nil {
+		return ierrors.Wrap(err, "failed to get latest version")
+	}
+
+	version := latestVersion + 1
+
+	m.treeStore.version = version
+	if err = m.tree.Commit(); err != nil {
+		return ierrors.Wrap(err, "failed to commit tree")
+	}
+	m.treeStore.DiscardValues()
+
+	if err = m.commitUncommittedKeys(version); err != nil {
+		return ierrors.Wrap(err, "failed to commit raw keys")
+	}
+
+	size, err := m.size.Get()
+	if err != nil && !ierrors.Is(err, kvstore.ErrKeyNotFound) {
+		return ierrors.Wrap(err, "failed to get size")
+	}
+
+	size = uint64(int(size) + m.uncommittedSizeDelta)
+	if err = m.size.Set(size); err != nil {
+		return ierrors.Wrap(err, "failed to set size")
+	}
+
+	m.uncommittedSizeDelta = 0
+
+	root := IdentifierType(m.tree.Root())
+	if err = m.root.Set(root); err != nil {
 		return ierrors.Wrap(err, "failed to set root")
 	}
 
-	return m.tree.Commit()
+	if err = m.versionRoots.Set(version, root); err != nil {
+		return ierrors.Wrap(err, "failed to set version root")
+	}
+
+	if err = m.versionSizes.Set(version, size); err != nil {
+		return ierrors.Wrap(err, "failed to set version size")
+	}
+
+	if err = m.latestVersion.Set(version); err != nil {
+		return ierrors.Wrap(err, "failed to set latest version")
+	}
+
+	return m.pruneVersions(version)
 }
 
 // Delete removes the key from the map.
@@ -162,15 +410,8 @@ func (m *authenticatedMap[IdentifierType, K, V]) Delete(key K) (deleted bool, er
 		return false, ierrors.Wrap(err, "failed to delete from tree")
 	}
 
-	if err := m.rawKeysStore.Delete(key); err != nil {
-		return false, ierrors.Wrap(err, "failed to delete from raw keys store")
-	}
-
-	if has {
-		if err := m.addSize(-1); err != nil {
-			return false, ierrors.Wrap(err, "failed to decrease size")
-		}
-	}
+	m.trackKeyChange(keyBytes, false)
+	m.uncommittedSizeDelta--
 
 	return true, nil
 }
@@ -193,14 +434,19 @@ func (m *authenticatedMap[IdentifierType, K, V]) Get(key K) (value V, exists boo
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	return m.get(m.tree, key)
+}
+
+// get returns the value for the given key from the given tree.
+func (m *authenticatedMap[IdentifierType, K, V]) get(tree *smt.SMT, key K) (value V, exists bool, err error) {
 	keyBytes, err := m.keyToBytes(key)
 	if err != nil {
 		return value, false, ierrors.Wrap(err, "failed to serialize key")
 	}
 
-	valueBytes, err := m.tree.Get(keyBytes)
+	valueBytes, err := m.valueBytes(tree, keyBytes)
 	if err != nil {
-		return value, false, ierrors.Wrap(err, "failed to get from tree")
+		return value, false, err
 	}
 
 	if valueBytes == nil {
@@ -219,67 +465,372 @@ func (m *authenticatedMap[IdentifierType, K, V]) Get(key K) (value V, exists boo
 	return v, true, err
 }
 
-// Stream streams all the keys and values.
+// Stream streams all the keys and values in the order of the serialized keys.
 func (m *authenticatedMap[IdentifierType, K, V]) Stream(callback func(key K, value V) error) error {
+	_, err := m.StreamPage(callback)
+
+	return err
+}
+
+// valueBytes returns the serialized value of the given key in the given tree (nil if the key does not exist).
+func (m *authenticatedMap[IdentifierType, K, V]) valueBytes(tree *smt.SMT, keyBytes []byte) ([]byte, error) {
+	valueData, err := tree.Get(keyBytes)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to get from tree")
+	}
+
+	if valueData == nil || !m.settings.hashValues {
+		return valueData, nil
+	}
+
+	leafValue, err := m.treeStore.LeafValue(digest(m.settings.hashFunction.New(), leafPrefix, m.keyPath(keyBytes), valueData), valueData)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to get leaf value")
+	}
+
+	return leafValue, nil
+}
+
+// has returns true if the key is in the map.
+func (m *authenticatedMap[IdentifierType, K, V]) has(keyBytes []byte) (has bool, err error) {
+	value, err := m.tree.Get(keyBytes)
+	if err != nil {
+		return false, ierrors.Wrap(err, "failed to get from tree")
+	}
+
+	return value != nil, nil
+}
+
+// proof returns a proof for the membership or non-membership of the given key in the given tree.
+func (m *authenticatedMap[IdentifierType, K, V]) proof(tree *smt.SMT, key K) (proof *Proof[IdentifierType], err error) {
+	keyBytes, err := m.keyToBytes(key)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to serialize key")
+	}
+
+	treeProof, err := tree.Prove(keyBytes)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to create proof")
+	}
+
+	return newProof[IdentifierType](treeProof, tree.Spec())
+}
+
+// importTree returns the tree with the given root.
+func (m *authenticatedMap[IdentifierType, K, V]) importTree(root []byte) *smt.SMT {
+	return smt.ImportSparseMerkleTrie(m.treeStore, m.settings.hashFunction.New(), root, m.treeOptions()...)
+}
+
+// treeOptions returns the options of the tree.
+func (m *authenticatedMap[IdentifierType, K, V]) treeOptions() []smt.Option {
+	if m.settings.hashValues {
+		return nil
+	}
+
+	return []smt.Option{smt.WithValueHasher(nil)}
+}
+
+// checkSettings stores the settings of new maps and verifies that restored maps use the same settings.
+func (m *authenticatedMap[IdentifierType, K, V]) checkSettings(store kvstore.KVStore) error {
+	var identifier IdentifierType
+	if hashSize := m.settings.hashFunction.New().Size(); hashSize != len(identifier) {
+		return ierrors.Errorf("hash size %d of %s does not match identifier size %d", hashSize, m.settings.hashFunction.Name(), len(identifier))
+	}
+
+	storedSettings, err := store.Get([]byte{prefixSettingsKey})
+	if err != nil {
+		if !ierrors.Is(err, kvstore.ErrKeyNotFound) {
+			return ierrors.Wrap(err, "failed to get settings")
+		}
+
+		// maps that were created before the settings were stored use the default settings
+		if m.WasRestoredFromStorage() {
+			storedSettings = newSettings(nil).treeSettingsBytes()
+		}
+	}
+
+	if storedSettings != nil && !bytes.Equal(storedSettings, m.settings.treeSettingsBytes()) {
+		return ierrors.Wrapf(ErrSettingsMismatch, "stored settings %x do not match %x", storedSettings, m.settings.treeSettingsBytes())
+	}
+
+	if err = store.Set([]byte{prefixSettingsKey}, m.settings.treeSettingsBytes()); err != nil {
+		return ierrors.Wrap(err, "failed to store settings")
+	}
+
+	return nil
+}
+
+// initVersions records the initial version of maps that were created without versioning.
+func (m *authenticatedMap[IdentifierType, K, V]) initVersions() error {
+	if hasVersion, err := m.latestVersion.Has(); err != nil {
+		return ierrors.Wrap(err, "failed to check latest version")
+	} else if hasVersion {
+		return nil
+	}
+
+	size, err := m.size.Get()
+	if err != nil && !ierrors.Is(err, kvstore.ErrKeyNotFound) {
+		return ierrors.Wrap(err, "failed to get size")
+	}
+
+	if err = m.versionRoots.Set(0, IdentifierType(m.tree.Root())); err != nil {
+		return ierrors.Wrap(err, "failed to set version root")
+	}
+
+	if err = m.versionSizes.Set(0, size); err != nil {
+		return ierrors.Wrap(err, "failed to set version size")
+	}
+
+	if err = m.oldestVersion.Set(0); err != nil {
+		return ierrors.Wrap(err, "failed to set oldest version")
+	}
+
+	return m.latestVersion.Set(0)
+}
+
+// ensureKeyPaths indexes the raw keys by their path if the map is synced for the first time.
+// The index is maintained by all later changes of the raw keys.
+func (m *authenticatedMap[IdentifierType, K, V]) ensureKeyPaths() (err error) {
+	if m.keyPathsIndexed.Load() {
+		return nil
+	}
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	if m.keyPathsIndexed.Load() {
+		return nil
+	}
+
+	// drop the entries of an incomplete index
+	if err = m.keyPaths.Clear(); err != nil {
+		return ierrors.Wrap(err, "failed to clear key paths")
+	}
+
 	var innerErr error
-	if iterationErr := m.rawKeysStore.IterateKeys([]byte{}, func(key K) bool {
-		keyBytes, err := m.keyToBytes(key)
-		if err != nil {
-			innerErr = ierrors.Wrapf(err, "failed to serialize key %s", keyBytes)
+	if err = m.rawKeysStore.KVStore().IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		innerErr = m.keyPaths.Set(m.keyPath(key), bytes.Clone(key))
+
+		return innerErr == nil
+	}); err != nil {
+		return ierrors.Wrap(err, "failed to iterate raw keys")
+	} else if innerErr != nil {
+		return ierrors.Wrap(innerErr, "failed to set key path")
+	}
+
+	if err = m.keyPathsIndexedMarker.Set(types.Void); err != nil {
+		return ierrors.Wrap(err, "failed to mark key paths as indexed")
+	}
+
+	m.keyPathsIndexed.Store(true)
+
+	return nil
+}
+
+// retainedVersionRange returns the oldest and the latest retained version.
+func (m *authenticatedMap[IdentifierType, K, V]) retainedVersionRange() (oldestVersion uint64, latestVersion uint64, err error) {
+	if oldestVersion, err = m.oldestVersion.Get(); err != nil {
+		return 0, 0, ierrors.Wrap(err, "failed to get oldest version")
+	}
+
+	if latestVersion, err = m.latestVersion.Get(); err != nil {
+		return 0, 0, ierrors.Wrap(err, "failed to get latest version")
+	}
+
+	return oldestVersion, latestVersion, nil
+}
+
+// rootAt returns the root of the given retained version.
+func (m *authenticatedMap[IdentifierType, K, V]) rootAt(version uint64) (root IdentifierType, err error) {
+	oldestVersion, latestVersion, err := m.retainedVersionRange()
+	if err != nil {
+		return root, err
+	}
+
+	if version < oldestVersion || version > latestVersion {
+		return root, ierrors.Wrapf(ErrVersionNotRetained, "failed to get root of version %d", version)
+	}
+
+	if root, err = m.versionRoots.Get(version); err != nil {
+		return root, ierrors.Wrapf(err, "failed to get root of version %d", version)
+	}
+
+	return root, nil
+}
 
-			return false
+// treeAt returns a read-only view of the tree at the given retained version.
+func (m *authenticatedMap[IdentifierType, K, V]) treeAt(version uint64) (*smt.SMT, error) {
+	root, err := m.rootAt(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.importTree(root[:]), nil
+}
+
+// pruneVersions removes the versions that exceed the retention window.
+func (m *authenticatedMap[IdentifierType, K, V]) pruneVersions(latestVersion uint64) error {
+	oldestVersion, err := m.oldestVersion.Get()
+	if err != nil {
+		return ierrors.Wrap(err, "failed to get oldest version")
+	}
+
+	for ; latestVersion-oldestVersion > m.settings.retainedVersions; oldestVersion++ {
+		// the journal of the new oldest version is only needed to roll back to the pruned version.
+		// Versions that are committed without retaining older versions have no journal.
+		if m.settings.retainedVersions > 0 || oldestVersion+1 < latestVersion {
+			if err = m.treeStore.Prune(oldestVersion + 1); err != nil {
+				return ierrors.Wrapf(err, "failed to prune nodes of version %d", oldestVersion)
+			}
+
+			if err = m.journal.Drop(oldestVersion + 1); err != nil {
+				return err
+			}
 		}
 
-		valueBytes, valueErr := m.tree.Get(keyBytes)
-		if valueErr != nil {
-			innerErr = ierrors.Wrapf(valueErr, "failed to get value for key %s", keyBytes)
+		if err = m.versionRoots.Delete(oldestVersion); err != nil {
+			return ierrors.Wrapf(err, "failed to delete root of version %d", oldestVersion)
+		}
 
-			return false
+		if err = m.versionSizes.Delete(oldestVersion); err != nil {
+			return ierrors.Wrapf(err, "failed to delete size of version %d", oldestVersion)
 		}
 
-		value, _, valueErr := m.bytesToValue(valueBytes)
-		if valueErr != nil {
-			innerErr = ierrors.Wrapf(valueErr, "failed to deserialize value %s", valueBytes)
+		if err = m.oldestVersion.Set(oldestVersion + 1); err != nil {
+			return ierrors.Wrap(err, "failed to set oldest version")
+		}
+	}
+
+	return nil
+}
+
+// rollbackVersion reverts the changes of the given version.
+func (m *authenticatedMap[IdentifierType, K, V]) rollbackVersion(version uint64, oldestVersion uint64) error {
+	if err := m.treeStore.Rollback(version, oldestVersion); err != nil {
+		return ierrors.Wrap(err, "failed to roll back nodes")
+	}
+
+	addedKeys, err := m.journal.Entries(version, journalAddedKey)
+	if err != nil {
+		return err
+	}
 
-			return false
+	for _, addedKey := range addedKeys {
+		if err = m.deleteRawKey(addedKey.key); err != nil {
+			return err
 		}
+	}
 
-		if callbackErr := callback(key, value); callbackErr != nil {
-			innerErr = ierrors.Wrapf(callbackErr, "failed to execute callback for key %s", keyBytes)
+	deletedKeys, err := m.journal.Entries(version, journalDeletedKey)
+	if err != nil {
+		return err
+	}
 
-			return false
+	for _, deletedKey := range deletedKeys {
+		if err = m.setRawKey(deletedKey.key); err != nil {
+			return err
 		}
+	}
+
+	if err = m.journal.Drop(version); err != nil {
+		return err
+	}
+
+	if err = m.versionRoots.Delete(version); err != nil {
+		return ierrors.Wrap(err, "failed to delete version root")
+	}
 
-		return true
-	}); iterationErr != nil {
-		return ierrors.Wrap(iterationErr, "failed to iterate over raw keys")
+	if err = m.versionSizes.Delete(version); err != nil {
+		return ierrors.Wrap(err, "failed to delete version size")
 	}
 
-	return innerErr
+	return m.latestVersion.Set(version - 1)
 }
 
-// has returns true if the key is in the map.
-func (m *authenticatedMap[IdentifierType, K, V]) has(keyBytes []byte) (has bool, err error) {
-	value, err := m.tree.Get(keyBytes)
-	if err != nil {
-		return false, ierrors.Wrap(err, "failed to get from tree")
+// trackKeyChange records that the given raw key was added or deleted since the latest commit.
+func (m *authenticatedMap[IdentifierType, K, V]) trackKeyChange(keyBytes []byte, added bool) {
+	// the change reverts an earlier uncommitted change
+	if wasAdded, tracked := m.uncommittedKeys[string(keyBytes)]; tracked && wasAdded != added {
+		delete(m.uncommittedKeys, string(keyBytes))
+
+		return
 	}
 
-	return value != nil, nil
+	m.uncommittedKeys[string(keyBytes)] = added
 }
 
-func (m *authenticatedMap[IdentifierType, K, V]) addSize(delta int) error {
-	size, err := m.size.Get()
-	if err != nil && !ierrors.Is(err, kvstore.ErrKeyNotFound) {
-		return ierrors.Wrap(err, "failed to get size")
+// commitUncommittedKeys applies the raw key changes since the latest commit and records them in the journal of the
+// given version if versions are retained.
+func (m *authenticatedMap[IdentifierType, K, V]) commitUncommittedKeys(version uint64) error {
+	for keyBytes, added := range m.uncommittedKeys {
+		// the journal is only needed to roll back to retained versions
+		if m.settings.retainedVersions > 0 {
+			entryType := journalDeletedKey
+			if added {
+				entryType = journalAddedKey
+			}
+
+			if err := m.journal.Record(version, entryType, []byte(keyBytes), []byte{}); err != nil {
+				return err
+			}
+		}
+
+		if added {
+			if err := m.setRawKey([]byte(keyBytes)); err != nil {
+				return err
+			}
+		} else if err := m.deleteRawKey([]byte(keyBytes)); err != nil {
+			return err
+		}
 	}
 
-	if err := m.size.Set(uint64(int(size) + delta)); err != nil {
-		return ierrors.Wrap(err, "failed to set size")
+	m.uncommittedKeys = make(map[string]bool)
+
+	return nil
+}
+
+// discardUncommittedChanges discards the staged raw key, size and value changes since the latest commit.
+func (m *authenticatedMap[IdentifierType, K, V]) discardUncommittedChanges() {
+	m.uncommittedKeys = make(map[string]bool)
+	m.uncommittedSizeDelta = 0
+	m.treeStore.DiscardValues()
+}
+
+// setRawKey adds the given raw key and indexes it by its path if the key paths are indexed.
+func (m *authenticatedMap[IdentifierType, K, V]) setRawKey(keyBytes []byte) error {
+	if err := m.rawKeysStore.KVStore().Set(keyBytes, lo.PanicOnErr(types.Void.Bytes())); err != nil {
+		return ierrors.Wrap(err, "failed to set raw key")
+	}
+
+	if !m.keyPathsIndexed.Load() {
+		return nil
+	}
+
+	if err := m.keyPaths.Set(m.keyPath(keyBytes), keyBytes); err != nil {
+		return ierrors.Wrap(err, "failed to set key path")
+	}
+
+	return nil
+}
+
+// deleteRawKey removes the given raw key and its path from the index if the key paths are indexed.
+func (m *authenticatedMap[IdentifierType, K, V]) deleteRawKey(keyBytes []byte) error {
+	if err := m.rawKeysStore.KVStore().Delete(keyBytes); err != nil {
+		return ierrors.Wrap(err, "failed to delete raw key")
+	}
+
+	if !m.keyPathsIndexed.Load() {
+		return nil
+	}
+
+	if err := m.keyPaths.Delete(m.keyPath(keyBytes)); err != nil {
+		return ierrors.Wrap(err, "failed to delete key path")
 	}
 
 	return nil
 }
+
+// keyPath returns the path of the given key in the tree.
+func (m *authenticatedMap[IdentifierType, K, V]) keyPath(keyBytes []byte) []byte {
+	return digest(m.settings.hashFunction.New(), keyBytes)
+}
@@ -0,0 +1,231 @@
+package ads
+
+import (
+	"bytes"
+	"hash"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// importParallelDepth is the depth up to which the subtrees are built in parallel during an import.
+	importParallelDepth = 4
+
+	// importParallelThreshold is the minimum number of entries of a subtree to build its children in parallel.
+	importParallelThreshold = 1024
+)
+
+var (
+	// ErrNotEmpty is returned if entries are imported into a Map or Set that is not empty.
+	ErrNotEmpty = ierrors.New("not empty")
+
+	extensionPrefix = []byte{2}
+)
+
+// importEntry is an entry that is bulk-loaded into the tree.
+type importEntry struct {
+	keyBytes  []byte
+	path      []byte
+	valueData []byte
+}
+
+// Import bulk-loads the entries of the given iterator into the empty map and commits them as a new version.
+func (m *authenticatedMap[IdentifierType, K, V]) Import(iterator func(consumer func(key K, value V) error) error) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var emptyRoot IdentifierType
+	if IdentifierType(m.tree.Root()) != emptyRoot || len(m.uncommittedKeys) != 0 {
+		return ierrors.Wrap(ErrNotEmpty, "failed to import entries")
+	}
+
+	entries := make([]*importEntry, 0)
+	hasher := m.settings.hashFunction.New()
+	if err := iterator(func(key K, value V) error {
+		keyBytes, err := m.keyToBytes(key)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to serialize key")
+		}
+
+		valueBytes, err := m.valueToBytes(value)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to serialize value")
+		}
+
+		valueData := valueBytes
+		if m.settings.hashValues {
+			valueData = digest(hasher, valueBytes)
+			m.treeStore.StageValue(valueData, valueBytes)
+		}
+
+		entries = append(entries, &importEntry{
+			keyBytes:  keyBytes,
+			path:      digest(hasher, keyBytes),
+			valueData: valueData,
+		})
+
+		return nil
+	}); err != nil {
+		m.treeStore.DiscardValues()
+
+		return ierrors.Wrap(err, "failed to iterate entries")
+	}
+
+	// sort the entries by their path and keep the last value of duplicate keys
+	slices.SortStableFunc(entries, func(a, b *importEntry) int {
+		return bytes.Compare(a.path, b.path)
+	})
+	uniqueEntries := entries[:0]
+	for i, entry := range entries {
+		if i+1 < len(entries) && bytes.Equal(entry.path, entries[i+1].path) {
+			continue
+		}
+
+		uniqueEntries = append(uniqueEntries, entry)
+	}
+	entries = uniqueEntries
+
+	latestVersion, err := m.latestVersion.Get()
+	if err != nil {
+		m.treeStore.DiscardValues()
+
+		return ierrors.Wrap(err, "failed to get latest version")
+	}
+
+	m.treeStore.version = latestVersion + 1
+	root, err := newTreeBuilder(m.treeStore, m.settings.hashFunction.New).Build(entries)
+	if err != nil {
+		m.treeStore.DiscardValues()
+
+		return ierrors.Wrap(err, "failed to build tree")
+	}
+
+	for _, entry := range entries {
+		m.trackKeyChange(entry.keyBytes, true)
+	}
+	m.uncommittedSizeDelta = len(entries)
+	m.tree = m.importTree(root)
+
+	return m.commit()
+}
+
+// treeBuilder builds a sparse merkle tree bottom-up from sorted entries.
+type treeBuilder struct {
+	store     *mapStoreAdapter
+	newHasher func() hash.Hash
+}
+
+// newTreeBuilder creates a new treeBuilder that writes the nodes to the given store.
+func newTreeBuilder(store *mapStoreAdapter, newHasher func() hash.Hash) *treeBuilder {
+	return &treeBuilder{
+		store:     store,
+		newHasher: newHasher,
+	}
+}
+
+// Build builds the tree of the given entries (sorted by path) and returns its root.
+func (b *treeBuilder) Build(entries []*importEntry) ([]byte, error) {
+	return b.buildSubtree(b.newHasher(), entries, 0)
+}
+
+// buildSubtree builds the subtree of the given entries that starts at the given depth and returns its hash.
+func (b *treeBuilder) buildSubtree(hasher hash.Hash, entries []*importEntry, depth int) ([]byte, error) {
+	switch len(entries) {
+	case 0:
+		return make([]byte, hasher.Size()), nil
+	case 1:
+		return b.storeNode(hasher, leafPrefix, entries[0].path, entries[0].valueData)
+	}
+
+	// the entries share the bits up to the depth at which the subtree splits
+	splitDepth := commonPrefixBits(entries[0].path, entries[len(entries)-1].path, depth)
+	splitIndex := sort.Search(len(entries), func(i int) bool {
+		return pathBit(entries[i].path, splitDepth) == 1
+	})
+
+	leftHash, rightHash, err := b.buildChildren(hasher, entries[:splitIndex], entries[splitIndex:], splitDepth+1)
+	if err != nil {
+		return nil, err
+	}
+
+	innerHash, err := b.storeNode(hasher, innerPrefix, leftHash, rightHash)
+	if err != nil || splitDepth == depth {
+		return innerHash, err
+	}
+
+	// an extension represents the chain of inner nodes with a single child above the split
+	extensionHash := innerHash
+	placeholder := make([]byte, hasher.Size())
+	for i := splitDepth - 1; i >= depth; i-- {
+		if pathBit(entries[0].path, i) == 0 {
+			extensionHash = digest(hasher, innerPrefix, extensionHash, placeholder)
+		} else {
+			extensionHash = digest(hasher, innerPrefix, placeholder, extensionHash)
+		}
+	}
+
+	if err = b.store.Set(extensionHash, slices.Concat(extensionPrefix, []byte{byte(depth), byte(splitDepth)}, entries[0].path, innerHash)); err != nil {
+		return nil, ierrors.Wrap(err, "failed to store extension node")
+	}
+
+	return extensionHash, nil
+}
+
+// buildChildren builds the left and right subtree at the given depth (in parallel for large subtrees near the root).
+func (b *treeBuilder) buildChildren(hasher hash.Hash, leftEntries []*importEntry, rightEntries []*importEntry, depth int) (leftHash []byte, rightHash []byte, err error) {
+	if depth > importParallelDepth || len(leftEntries)+len(rightEntries) < importParallelThreshold {
+		if leftHash, err = b.buildSubtree(hasher, leftEntries, depth); err != nil {
+			return nil, nil, err
+		}
+
+		if rightHash, err = b.buildSubtree(hasher, rightEntries, depth); err != nil {
+			return nil, nil, err
+		}
+
+		return leftHash, rightHash, nil
+	}
+
+	var leftErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		leftHash, leftErr = b.buildSubtree(b.newHasher(), leftEntries, depth)
+	}()
+
+	rightHash, err = b.buildSubtree(hasher, rightEntries, depth)
+	wg.Wait()
+
+	if leftErr != nil {
+		return nil, nil, leftErr
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	return leftHash, rightHash, nil
+}
+
+// storeNode stores the node with the given preimage and returns its hash.
+func (b *treeBuilder) storeNode(hasher hash.Hash, preimage ...[]byte) ([]byte, error) {
+	nodeBytes := slices.Concat(preimage...)
+	nodeHash := digest(hasher, nodeBytes)
+	if err := b.store.Set(nodeHash, nodeBytes); err != nil {
+		return nil, ierrors.Wrap(err, "failed to store node")
+	}
+
+	return nodeHash, nil
+}
+
+// commonPrefixBits returns the number of bits (starting at the given position) that are equal in both paths.
+func commonPrefixBits(path1 []byte, path2 []byte, position int) int {
+	count := position
+	for count < len(path1)*8 && pathBit(path1, count) == pathBit(path2, count) {
+		count++
+	}
+
+	return count
+}
@@ -0,0 +1,115 @@
+package ads
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/typeutils"
+)
+
+func TestMapImport(t *testing.T) {
+	newTestMap := func(store kvstore.KVStore, opts ...Option) *authenticatedMap[[32]byte, uint64, testValue] {
+		return newAuthenticatedMap[[32]byte](store,
+			typeutils.ByteArray32ToBytes,
+			typeutils.ByteArray32FromBytes,
+			typeutils.Uint64ToBytes,
+			typeutils.Uint64FromBytes,
+			testValue.Bytes,
+			testValueFromBytes,
+			opts...,
+		)
+	}
+
+	const entriesCount = 5000
+
+	referenceMap := newTestMap(mapdb.NewMapDB())
+	for i := uint64(0); i < entriesCount; i++ {
+		require.NoError(t, referenceMap.Set(i, testValueFromString(fmt.Sprintf("value-%d", i))))
+	}
+	require.NoError(t, referenceMap.Commit())
+
+	importedMap := newTestMap(mapdb.NewMapDB(), WithRetainedVersions(1))
+	require.NoError(t, importedMap.Import(func(consumer func(key uint64, value testValue) error) error {
+		// the last value of duplicate keys is imported
+		if err := consumer(0, testValueFromString("outdated")); err != nil {
+			return err
+		}
+
+		return referenceMap.Stream(consumer)
+	}))
+
+	require.Equal(t, referenceMap.Root(), importedMap.Root())
+	require.Equal(t, entriesCount, importedMap.Size())
+	require.Equal(t, uint64(1), importedMap.Version())
+
+	for i := uint64(0); i < entriesCount; i += 97 {
+		value, exists, err := importedMap.Get(i)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, testValueFromString(fmt.Sprintf("value-%d", i)), value)
+
+		proof, err := importedMap.Proof(i)
+		require.NoError(t, err)
+		require.True(t, lo.PanicOnErr(VerifyProof(importedMap.Root(), lo.PanicOnErr(typeutils.Uint64ToBytes(i)), value, proof)))
+	}
+
+	streamedEntries := 0
+	require.NoError(t, importedMap.Stream(func(key uint64, value testValue) error {
+		streamedEntries++
+		require.Equal(t, testValueFromString(fmt.Sprintf("value-%d", key)), value)
+
+		return nil
+	}))
+	require.Equal(t, entriesCount, streamedEntries)
+
+	// the imported tree can be modified like a tree that was built by single inserts
+	for _, m := range []*authenticatedMap[[32]byte, uint64, testValue]{referenceMap, importedMap} {
+		for i := uint64(0); i < 200; i++ {
+			require.NoError(t, lo.Return2(m.Delete(i*7)))
+			require.NoError(t, m.Set(entriesCount+i, testValueFromString("new")))
+			require.NoError(t, m.Set(i*11+1, testValueFromString("updated")))
+		}
+		require.NoError(t, m.Commit())
+	}
+	require.Equal(t, referenceMap.Root(), importedMap.Root())
+	require.Equal(t, referenceMap.Size(), importedMap.Size())
+
+	require.ErrorIs(t, importedMap.Import(referenceMap.Stream), ErrNotEmpty)
+
+	// the import can be rolled back without leaving nodes behind
+	emptyMap := newTestMap(mapdb.NewMapDB(), WithRetainedVersions(1))
+	require.NoError(t, emptyMap.Import(referenceMap.Stream))
+	require.NoError(t, emptyMap.Rollback(0))
+	require.Equal(t, [32]byte{}, emptyMap.Root())
+	require.Equal(t, 0, emptyMap.Size())
+	require.Equal(t, 0, emptyMap.treeStore.Len())
+	require.Equal(t, 0, len(streamMap(t, emptyMap)))
+}
+
+func TestSetImport(t *testing.T) {
+	newTestSet := func() Set[[32]byte, testKey] {
+		return newAuthenticatedSet[[32]byte](mapdb.NewMapDB(),
+			typeutils.ByteArray32ToBytes,
+			typeutils.ByteArray32FromBytes,
+			testKey.Bytes,
+			testKeyFromBytes,
+		)
+	}
+
+	referenceSet := newTestSet()
+	for i := 0; i < 256; i++ {
+		require.NoError(t, referenceSet.Add(testKey{byte(i)}))
+	}
+	require.NoError(t, referenceSet.Commit())
+
+	importedSet := newTestSet()
+	require.NoError(t, importedSet.Import(referenceSet.Stream))
+	require.Equal(t, referenceSet.Root(), importedSet.Root())
+	require.Equal(t, 256, importedSet.Size())
+	require.True(t, lo.PanicOnErr(importedSet.Has(testKey{42})))
+}
@@ -1,23 +1,63 @@
 package ads
 
 import (
+	"bytes"
+
 	"github.com/pokt-network/smt/kvstore"
 
 	"github.com/iotaledger/hive.go/ierrors"
 	hivekvstore "github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/typeutils"
 )
 
 var _ kvstore.MapStore = &mapStoreAdapter{}
 
 // mapStoreAdapter is a wrapper around a hive KVStore that implements the MapStore interface
 // from pokt-network/smt/kvstore.
+//
+// If versions are retained, nodes that are deleted by the tree are not removed right away but marked as orphaned in the
+// version that is currently committed, so that they can still be accessed by the retained versions until they are pruned.
+//
+// If the values are hashed, the value of each leaf is stored next to the leaf and shares its lifecycle.
 type mapStoreAdapter struct {
 	underlying hivekvstore.KVStore
+
+	// orphanedNodes maps the hashes of orphaned nodes to the last version that still references them.
+	orphanedNodes hivekvstore.KVStore
+
+	// journal records the created and orphaned nodes of each version.
+	journal *journal
+
+	// version is the version that is currently committed.
+	version uint64
+
+	// leafValues maps the hashes of leaves to their values if the values are hashed.
+	leafValues hivekvstore.KVStore
+
+	// pendingValues maps the hashes of uncommitted values to the values if the values are hashed.
+	pendingValues map[string][]byte
+
+	// hashSize is the size of the hashes of the tree.
+	hashSize int
+
+	// hashValues is true if the leaves contain the hashes of the values.
+	hashValues bool
+
+	// retainVersions is true if older versions are retained, which requires the journal and the orphaned nodes.
+	retainVersions bool
 }
 
-func newMapStoreAdapter(store hivekvstore.KVStore) *mapStoreAdapter {
+func newMapStoreAdapter(store hivekvstore.KVStore, orphanedNodes hivekvstore.KVStore, journal *journal, leafValues hivekvstore.KVStore, hashSize int, hashValues bool, retainVersions bool) *mapStoreAdapter {
 	return &mapStoreAdapter{
-		underlying: store,
+		underlying:     store,
+		orphanedNodes:  orphanedNodes,
+		journal:        journal,
+		leafValues:     leafValues,
+		pendingValues:  make(map[string][]byte),
+		hashSize:       hashSize,
+		hashValues:     hashValues,
+		retainVersions: retainVersions,
 	}
 }
 
@@ -28,12 +68,59 @@ func (k *mapStoreAdapter) Get(key []byte) ([]byte, error) {
 
 // Set sets/updates the value for a given key.
 func (k *mapStoreAdapter) Set(key, value []byte) error {
+	// revive the node if it is still retained as an orphan
+	// (nodes can still be orphaned by versions that were retained before)
+	lastReferencedVersion, err := k.orphanedNodes.Get(key)
+	if err == nil {
+		if k.retainVersions {
+			if err = k.journal.Record(k.version, journalCreatedNode, key, lastReferencedVersion); err != nil {
+				return err
+			}
+		}
+
+		return k.orphanedNodes.Delete(key)
+	} else if !ierrors.Is(err, hivekvstore.ErrKeyNotFound) {
+		return ierrors.Wrap(err, "failed to check if node is orphaned")
+	}
+
+	if has, err := k.underlying.Has(key); err != nil {
+		return ierrors.Wrap(err, "failed to check if node exists")
+	} else if has {
+		return nil
+	}
+
+	if k.retainVersions {
+		if err = k.journal.Record(k.version, journalCreatedNode, key, []byte{}); err != nil {
+			return err
+		}
+	}
+
+	if k.hashValues && bytes.HasPrefix(value, leafPrefix) {
+		leafValue, exists := k.pendingValues[string(value[len(leafPrefix)+k.hashSize:])]
+		if !exists {
+			return ierrors.Errorf("missing value of leaf %x", key)
+		}
+
+		if err = k.leafValues.Set(key, leafValue); err != nil {
+			return ierrors.Wrap(err, "failed to set leaf value")
+		}
+	}
+
 	return k.underlying.Set(key, value)
 }
 
 // Delete removes a key.
 func (k *mapStoreAdapter) Delete(key []byte) error {
-	return k.underlying.Delete(key)
+	// the node is not referenced by any retained version anymore
+	if !k.retainVersions {
+		return k.removeNode(key)
+	}
+
+	if err := k.journal.Record(k.version, journalOrphanedNode, key, []byte{}); err != nil {
+		return err
+	}
+
+	return k.orphanedNodes.Set(key, lo.PanicOnErr(typeutils.Uint64ToBytes(k.version-1)))
 }
 
 // Len returns the number of key-value pairs in the store.
@@ -51,9 +138,151 @@ func (k *mapStoreAdapter) Len() int {
 	return count
 }
 
+// StageValue stores the given value until the leaf that contains its hash is committed.
+func (k *mapStoreAdapter) StageValue(valueHash []byte, value []byte) {
+	k.pendingValues[string(valueHash)] = value
+}
+
+// DiscardValues drops the values of the uncommitted leaves.
+func (k *mapStoreAdapter) DiscardValues() {
+	k.pendingValues = make(map[string][]byte)
+}
+
+// LeafValue returns the value of the leaf with the given hash that contains the given value hash.
+func (k *mapStoreAdapter) LeafValue(leafHash []byte, valueHash []byte) ([]byte, error) {
+	if leafValue, exists := k.pendingValues[string(valueHash)]; exists {
+		return leafValue, nil
+	}
+
+	return k.leafValues.Get(leafHash)
+}
+
+// Prune removes the nodes that were orphaned by the given version, which is no longer retained.
+func (k *mapStoreAdapter) Prune(version uint64) error {
+	orphanedNodes, err := k.journal.Entries(version, journalOrphanedNode)
+	if err != nil {
+		return err
+	}
+
+	for _, orphanedNode := range orphanedNodes {
+		lastReferencedVersion, isOrphaned, err := k.lastReferencedVersion(orphanedNode.key)
+		if err != nil {
+			return err
+		}
+
+		// the node was revived by a later version
+		if !isOrphaned || lastReferencedVersion != version-1 {
+			continue
+		}
+
+		if err = k.deleteNode(orphanedNode.key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rollback reverts the node changes of the given version, where oldestVersion is the oldest retained version.
+func (k *mapStoreAdapter) Rollback(version uint64, oldestVersion uint64) error {
+	createdNodes, err := k.journal.Entries(version, journalCreatedNode)
+	if err != nil {
+		return err
+	}
+
+	for _, createdNode := range createdNodes {
+		// the node did not exist before
+		if len(createdNode.value) == 0 {
+			if err = k.deleteNode(createdNode.key); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// the node was revived, but the version that references it is no longer retained
+		if lastReferencedVersion, _, err := typeutils.Uint64FromBytes(createdNode.value); err != nil {
+			return ierrors.Wrap(err, "failed to parse last referenced version")
+		} else if lastReferencedVersion < oldestVersion {
+			if err = k.deleteNode(createdNode.key); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err = k.orphanedNodes.Set(createdNode.key, createdNode.value); err != nil {
+			return ierrors.Wrap(err, "failed to mark node as orphaned")
+		}
+	}
+
+	orphanedNodes, err := k.journal.Entries(version, journalOrphanedNode)
+	if err != nil {
+		return err
+	}
+
+	for _, orphanedNode := range orphanedNodes {
+		if err = k.orphanedNodes.Delete(orphanedNode.key); err != nil {
+			return ierrors.Wrap(err, "failed to revive orphaned node")
+		}
+	}
+
+	return nil
+}
+
+// lastReferencedVersion returns the last version that references the given node if it is orphaned.
+func (k *mapStoreAdapter) lastReferencedVersion(key []byte) (lastReferencedVersion uint64, isOrphaned bool, err error) {
+	lastReferencedVersionBytes, err := k.orphanedNodes.Get(key)
+	if err != nil {
+		if ierrors.Is(err, hivekvstore.ErrKeyNotFound) {
+			return 0, false, nil
+		}
+
+		return 0, false, ierrors.Wrap(err, "failed to get last referenced version")
+	}
+
+	if lastReferencedVersion, _, err = typeutils.Uint64FromBytes(lastReferencedVersionBytes); err != nil {
+		return 0, false, ierrors.Wrap(err, "failed to parse last referenced version")
+	}
+
+	return lastReferencedVersion, true, nil
+}
+
+// deleteNode removes the orphaned node with the given hash from the store.
+func (k *mapStoreAdapter) deleteNode(key []byte) error {
+	if err := k.orphanedNodes.Delete(key); err != nil {
+		return ierrors.Wrap(err, "failed to delete orphan marker")
+	}
+
+	return k.removeNode(key)
+}
+
+// removeNode removes the node with the given hash and its leaf value from the store.
+func (k *mapStoreAdapter) removeNode(key []byte) error {
+	if k.hashValues {
+		if err := k.leafValues.Delete(key); err != nil {
+			return ierrors.Wrap(err, "failed to delete leaf value")
+		}
+	}
+
+	if err := k.underlying.Delete(key); err != nil {
+		return ierrors.Wrap(err, "failed to delete node")
+	}
+
+	return nil
+}
+
 // --- Debug ---
 
 // ClearAll deletes all key-value pairs in the store.
 func (k *mapStoreAdapter) ClearAll() error {
+	if err := k.orphanedNodes.Clear(); err != nil {
+		return err
+	}
+
+	if err := k.leafValues.Clear(); err != nil {
+		return err
+	}
+
 	return k.underlying.Clear()
 }
@@ -0,0 +1,131 @@
+package ads
+
+import (
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// HandleSyncRequest returns the requested nodes, keys and values of the committed versions.
+func (m *authenticatedMap[IdentifierType, K, V]) HandleSyncRequest(request *SyncRequest[IdentifierType]) (response *SyncResponse, err error) {
+	if err = m.ensureKeyPaths(); err != nil {
+		return nil, ierrors.Wrap(err, "failed to index key paths")
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	response = &SyncResponse{
+		Nodes:  make([][]byte, len(request.NodeHashes)),
+		Keys:   make([][]byte, len(request.KeyPaths)),
+		Values: make([][]byte, len(request.LeafHashes)),
+	}
+
+	for i, nodeHash := range request.NodeHashes {
+		if response.Nodes[i], err = m.treeStore.Get(nodeHash[:]); err != nil {
+			return nil, ierrors.Wrapf(err, "failed to get node %x", nodeHash)
+		}
+	}
+
+	for i, keyPath := range request.KeyPaths {
+		if response.Keys[i], err = m.keyPaths.Get(keyPath[:]); err != nil {
+			return nil, ierrors.Wrapf(err, "failed to get key of path %x", keyPath)
+		}
+	}
+
+	for i, leafHash := range request.LeafHashes {
+		if response.Values[i], err = m.treeStore.leafValues.Get(leafHash[:]); err != nil {
+			return nil, ierrors.Wrapf(err, "failed to get value of leaf %x", leafHash)
+		}
+	}
+
+	return response, nil
+}
+
+// Diff compares the latest committed version of the map with the remote map that is reachable through the given
+// transport and streams the keys whose values differ from the remote map with the given root to the consumer.
+// The map is only locked while the local nodes and keys are read, so the transport and the consumer can access it.
+func (m *authenticatedMap[IdentifierType, K, V]) Diff(remote SyncTransport[IdentifierType], remoteRoot IdentifierType, consumer func(key K, remoteValue V, existsRemotely bool) error) error {
+	if err := m.ensureKeyPaths(); err != nil {
+		return ierrors.Wrap(err, "failed to index key paths")
+	}
+
+	version, localRoot, err := m.latestCommittedRoot()
+	if err != nil {
+		return err
+	}
+
+	// the nodes of the compared version are pruned and the key paths only reflect the latest version,
+	// so every read checks that the compared version is still the latest one.
+	localNode := func(hash []byte) ([]byte, error) {
+		m.mutex.RLock()
+		defer m.mutex.RUnlock()
+
+		if err := m.checkLatestCommittedRoot(version, localRoot); err != nil {
+			return nil, err
+		}
+
+		return m.treeStore.Get(hash)
+	}
+
+	localKey := func(path []byte) ([]byte, error) {
+		m.mutex.RLock()
+		defer m.mutex.RUnlock()
+
+		if err := m.checkLatestCommittedRoot(version, localRoot); err != nil {
+			return nil, err
+		}
+
+		return m.keyPaths.Get(path)
+	}
+
+	return newTreeDiff(m.settings.hashFunction.New(), m.settings.hashValues, localNode, localKey, remote, func(difference *difference) error {
+		key, _, err := m.bytesToKey(difference.keyBytes)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to deserialize key %x", difference.keyBytes)
+		}
+
+		var remoteValue V
+		if difference.remoteLeaf == nil {
+			return consumer(key, remoteValue, false)
+		}
+
+		if remoteValue, _, err = m.bytesToValue(difference.remoteValue); err != nil {
+			return ierrors.Wrapf(err, "failed to deserialize remote value of key %x", difference.keyBytes)
+		}
+
+		return consumer(key, remoteValue, true)
+	}).Run(localRoot[:], remoteRoot[:])
+}
+
+// latestCommittedRoot returns the latest committed version and its root.
+func (m *authenticatedMap[IdentifierType, K, V]) latestCommittedRoot() (version uint64, root IdentifierType, err error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if version, err = m.latestVersion.Get(); err != nil {
+		return 0, root, ierrors.Wrap(err, "failed to get latest version")
+	}
+
+	if root, err = m.versionRoots.Get(version); err != nil {
+		return 0, root, ierrors.Wrapf(err, "failed to get root of version %d", version)
+	}
+
+	return version, root, nil
+}
+
+// checkLatestCommittedRoot checks that the given version with the given root is still the latest committed version.
+func (m *authenticatedMap[IdentifierType, K, V]) checkLatestCommittedRoot(version uint64, root IdentifierType) error {
+	latestVersion, err := m.latestVersion.Get()
+	if err != nil {
+		return ierrors.Wrap(err, "failed to get latest version")
+	}
+
+	if latestVersion == version {
+		if latestRoot, err := m.versionRoots.Get(latestVersion); err != nil {
+			return ierrors.Wrapf(err, "failed to get root of version %d", latestVersion)
+		} else if latestRoot == root {
+			return nil
+		}
+	}
+
+	return ierrors.Wrapf(ErrVersionChanged, "version %d is not the latest committed version anymore", version)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hive.go/serializer/v2/typeutils"
@@ -171,3 +172,248 @@ func (t testValue) Bytes() ([]byte, error) {
 func testValueFromBytes(b []byte) (testValue, int, error) {
 	return b, len(b), nil
 }
+
+func TestMapVersions(t *testing.T) {
+	store := mapdb.NewMapDB()
+	newTestMap := func(store kvstore.KVStore, opts ...Option) *authenticatedMap[[32]byte, testKey, testValue] {
+		return newAuthenticatedMap[[32]byte](store,
+			typeutils.ByteArray32ToBytes,
+			typeutils.ByteArray32FromBytes,
+			testKey.Bytes,
+			testKeyFromBytes,
+			testValue.Bytes,
+			testValueFromBytes,
+			opts...,
+		)
+	}
+
+	newMap := newTestMap(store, WithRetainedVersions(3))
+	require.Equal(t, uint64(0), newMap.Version())
+
+	// version 1
+	require.NoError(t, newMap.Set(testKey{'a'}, testValueFromString("1")))
+	require.NoError(t, newMap.Set(testKey{'b'}, testValueFromString("2")))
+	require.NoError(t, newMap.Commit())
+
+	// version 2
+	require.NoError(t, newMap.Set(testKey{'a'}, testValueFromString("3")))
+	require.NoError(t, lo.Return2(newMap.Delete(testKey{'b'})))
+	require.NoError(t, newMap.Set(testKey{'c'}, testValueFromString("4")))
+	require.NoError(t, newMap.Commit())
+
+	// version 3 (revives the leaf of b from version 1)
+	require.NoError(t, newMap.Set(testKey{'b'}, testValueFromString("2")))
+	require.NoError(t, newMap.Commit())
+	require.Equal(t, uint64(3), newMap.Version())
+
+	expectedStates := map[uint64]map[testKey]testValue{
+		0: {},
+		1: {{'a'}: testValueFromString("1"), {'b'}: testValueFromString("2")},
+		2: {{'a'}: testValueFromString("3"), {'c'}: testValueFromString("4")},
+		3: {{'a'}: testValueFromString("3"), {'b'}: testValueFromString("2"), {'c'}: testValueFromString("4")},
+	}
+
+	for version, expectedState := range expectedStates {
+		root, err := newMap.RootAt(version)
+		require.NoError(t, err)
+
+		for _, key := range []testKey{{'a'}, {'b'}, {'c'}} {
+			value, exists, err := newMap.GetAt(version, key)
+			require.NoError(t, err)
+
+			expectedValue, expectedExists := expectedState[key]
+			require.Equal(t, expectedExists, exists, "version %d, key %s", version, key)
+			require.Equal(t, expectedValue, value, "version %d, key %s", version, key)
+
+			proof, err := newMap.ProofAt(version, key)
+			require.NoError(t, err)
+			if expectedExists {
+				require.True(t, lo.PanicOnErr(VerifyProof(root, key[:], expectedValue, proof)))
+			} else {
+				require.True(t, lo.PanicOnErr(VerifyNonMembershipProof(root, key[:], proof)))
+			}
+		}
+	}
+
+	// version 4 prunes version 0
+	require.NoError(t, lo.Return2(newMap.Delete(testKey{'a'})))
+	require.NoError(t, newMap.Commit())
+
+	_, err := newMap.RootAt(0)
+	require.ErrorIs(t, err, ErrVersionNotRetained)
+	require.ErrorIs(t, newMap.Rollback(0), ErrVersionNotRetained)
+	require.ErrorIs(t, newMap.Rollback(5), ErrVersionNotRetained)
+
+	// roll back uncommitted changes and recent commits after reopening the map
+	newMap = newTestMap(store, WithRetainedVersions(3))
+	require.Equal(t, uint64(4), newMap.Version())
+	require.NoError(t, newMap.Set(testKey{'d'}, testValueFromString("5")))
+	require.NoError(t, newMap.Rollback(2))
+
+	expectedRoot, err := newMap.RootAt(2)
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, newMap.Root())
+	require.Equal(t, uint64(2), newMap.Version())
+	require.Equal(t, len(expectedStates[2]), newMap.Size())
+
+	streamedState := make(map[testKey]testValue)
+	require.NoError(t, newMap.Stream(func(key testKey, value testValue) error {
+		streamedState[key] = value
+
+		return nil
+	}))
+	require.Equal(t, expectedStates[2], streamedState)
+
+	_, err = newMap.RootAt(3)
+	require.ErrorIs(t, err, ErrVersionNotRetained)
+
+	// continue committing until all rolled back and pruned versions are gone
+	require.NoError(t, newMap.Set(testKey{'e'}, testValueFromString("6")))
+	for i := 0; i < 4; i++ {
+		require.NoError(t, newMap.Commit())
+	}
+
+	// no nodes are leaked compared to a map that was built without history
+	referenceMap := newTestMap(mapdb.NewMapDB())
+	require.NoError(t, referenceMap.Set(testKey{'a'}, testValueFromString("3")))
+	require.NoError(t, referenceMap.Set(testKey{'c'}, testValueFromString("4")))
+	require.NoError(t, referenceMap.Set(testKey{'e'}, testValueFromString("6")))
+	require.NoError(t, referenceMap.Commit())
+
+	require.Equal(t, referenceMap.Root(), newMap.Root())
+	require.Equal(t, referenceMap.treeStore.Len(), newMap.treeStore.Len())
+	require.NoError(t, newMap.treeStore.orphanedNodes.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		require.Fail(t, "orphaned node was not pruned", "node %x", key)
+
+		return true
+	}))
+
+	// maps that don't retain versions anymore prune the remaining versions
+	newMap = newTestMap(store)
+	require.NoError(t, newMap.Set(testKey{'f'}, testValueFromString("7")))
+	require.NoError(t, lo.Return2(newMap.Delete(testKey{'a'})))
+	require.NoError(t, newMap.Commit())
+
+	require.NoError(t, lo.Return2(referenceMap.Delete(testKey{'a'})))
+	require.NoError(t, referenceMap.Set(testKey{'f'}, testValueFromString("7")))
+	require.NoError(t, referenceMap.Commit())
+
+	requireNoHistory(t, newMap)
+	require.Equal(t, referenceMap.Root(), newMap.Root())
+	require.Equal(t, referenceMap.treeStore.Len(), newMap.treeStore.Len())
+}
+
+func TestMapWithoutRetainedVersions(t *testing.T) {
+	newMap := newAuthenticatedMap[[32]byte](mapdb.NewMapDB(),
+		typeutils.ByteArray32ToBytes,
+		typeutils.ByteArray32FromBytes,
+		testKey.Bytes,
+		testKeyFromBytes,
+		testValue.Bytes,
+		testValueFromBytes,
+		WithValueHashing(true),
+	)
+
+	require.NoError(t, newMap.Set(testKey{'a'}, testValueFromString("1")))
+	require.NoError(t, newMap.Set(testKey{'b'}, testValueFromString("2")))
+	require.NoError(t, newMap.Commit())
+
+	require.NoError(t, newMap.Set(testKey{'a'}, testValueFromString("3")))
+	require.NoError(t, lo.Return2(newMap.Delete(testKey{'b'})))
+	require.NoError(t, newMap.Commit())
+
+	// neither the journal nor orphaned nodes are recorded and deleted nodes are removed right away
+	requireNoHistory(t, newMap)
+	require.Equal(t, 1, newMap.treeStore.Len())
+	require.ErrorIs(t, newMap.Rollback(1), ErrVersionNotRetained)
+
+	value, exists, err := newMap.Get(testKey{'a'})
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, testValueFromString("3"), value)
+}
+
+// requireNoHistory checks that the given map neither contains journal entries nor orphaned nodes.
+func requireNoHistory(t *testing.T, m *authenticatedMap[[32]byte, testKey, testValue]) {
+	for _, store := range []kvstore.KVStore{m.journal.store, m.treeStore.orphanedNodes} {
+		require.NoError(t, store.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+			require.Fail(t, "unexpected history entry", "key %x", key)
+
+			return true
+		}))
+	}
+}
+
+func TestMapDiscard(t *testing.T) {
+	store := mapdb.NewMapDB()
+	newMap := newAuthenticatedMap[[32]byte](store,
+		typeutils.ByteArray32ToBytes,
+		typeutils.ByteArray32FromBytes,
+		testKey.Bytes,
+		testKeyFromBytes,
+		testValue.Bytes,
+		testValueFromBytes,
+	)
+
+	require.NoError(t, newMap.Set(testKey{'a'}, testValueFromString("1")))
+	require.NoError(t, newMap.Set(testKey{'b'}, testValueFromString("2")))
+	require.NoError(t, newMap.Commit())
+
+	committedRoot := newMap.Root()
+	committedStore := storeContent(t, store)
+
+	// staged changes are visible but do not touch the store
+	require.NoError(t, newMap.Set(testKey{'a'}, testValueFromString("3")))
+	require.NoError(t, newMap.Set(testKey{'c'}, testValueFromString("4")))
+	require.NoError(t, lo.Return2(newMap.Delete(testKey{'b'})))
+	require.Equal(t, 2, newMap.Size())
+	require.NotEqual(t, committedRoot, newMap.Root())
+	require.Equal(t, map[testKey]testValue{{'a'}: testValueFromString("3"), {'c'}: testValueFromString("4")}, streamMap(t, newMap))
+	require.Equal(t, committedStore, storeContent(t, store))
+
+	// discarding restores the committed state
+	require.NoError(t, newMap.Discard())
+	require.Equal(t, committedRoot, newMap.Root())
+	require.Equal(t, 2, newMap.Size())
+	require.Equal(t, map[testKey]testValue{{'a'}: testValueFromString("1"), {'b'}: testValueFromString("2")}, streamMap(t, newMap))
+	require.Equal(t, committedStore, storeContent(t, store))
+
+	// committed changes are persisted
+	require.NoError(t, newMap.Set(testKey{'c'}, testValueFromString("4")))
+	require.NoError(t, lo.Return2(newMap.Delete(testKey{'a'})))
+	require.NoError(t, newMap.Commit())
+
+	restoredMap := newAuthenticatedMap[[32]byte](store,
+		typeutils.ByteArray32ToBytes,
+		typeutils.ByteArray32FromBytes,
+		testKey.Bytes,
+		testKeyFromBytes,
+		testValue.Bytes,
+		testValueFromBytes,
+	)
+	require.Equal(t, newMap.Root(), restoredMap.Root())
+	require.Equal(t, 2, restoredMap.Size())
+	require.Equal(t, map[testKey]testValue{{'b'}: testValueFromString("2"), {'c'}: testValueFromString("4")}, streamMap(t, restoredMap))
+}
+
+func streamMap[K comparable, V any](t *testing.T, m *authenticatedMap[[32]byte, K, V]) map[K]V {
+	content := make(map[K]V)
+	require.NoError(t, m.Stream(func(key K, value V) error {
+		content[key] = value
+
+		return nil
+	}))
+
+	return content
+}
+
+func storeContent(t *testing.T, store kvstore.KVStore) map[string]string {
+	content := make(map[string]string)
+	require.NoError(t, store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		content[string(key)] = string(value)
+
+		return true
+	}))
+
+	return content
+}
@@ -0,0 +1,55 @@
+package ads
+
+import (
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+// WithRetainedVersions sets the number of committed versions (in addition to the latest one) that are retained to
+// be able to query or roll back to them.
+func WithRetainedVersions(retainedVersions uint64) Option {
+	return func(settings *settings) {
+		settings.retainedVersions = retainedVersions
+	}
+}
+
+// WithHashFunction sets the hash function that is used to build the tree (defaults to SHA256).
+func WithHashFunction(hashFunction *HashFunction) Option {
+	return func(settings *settings) {
+		settings.hashFunction = hashFunction
+	}
+}
+
+// WithValueHashing sets whether the values are hashed before they are stored in the leaves of the tree (defaults to
+// false).
+func WithValueHashing(hashValues bool) Option {
+	return func(settings *settings) {
+		settings.hashValues = hashValues
+	}
+}
+
+// settings is a struct that contains the settings of a Map or Set.
+type settings struct {
+	retainedVersions uint64
+	hashFunction     *HashFunction
+	hashValues       bool
+}
+
+// newSettings creates the settings from the given options.
+func newSettings(opts []Option) *settings {
+	return options.Apply(&settings{
+		hashFunction: SHA256,
+	}, opts)
+}
+
+// treeSettingsBytes returns the serialized settings that affect the structure of the tree.
+func (s *settings) treeSettingsBytes() []byte {
+	hashValues := byte(0)
+	if s.hashValues {
+		hashValues = 1
+	}
+
+	return append([]byte{hashValues}, s.hashFunction.Name()...)
+}
+
+// Option is a function that configures the settings of a Map or Set.
+type Option = options.Option[settings]
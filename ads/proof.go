@@ -0,0 +1,180 @@
+package ads
+
+import (
+	"bytes"
+	"hash"
+	"math/bits"
+
+	"github.com/pokt-network/smt"
+
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+var (
+	// ErrInvalidProof is returned if a proof is malformed.
+	ErrInvalidProof = ierrors.New("invalid proof")
+
+	leafPrefix  = []byte{0}
+	innerPrefix = []byte{1}
+)
+
+// Proof is a compact sparse merkle proof for the membership or non-membership of a key in a Map or Set.
+// It can be serialized using serix.
+type Proof[IdentifierType types.IdentifierType] struct {
+	// SideNodes contains the side nodes along the path from the leaf to the root that are not placeholders.
+	SideNodes []IdentifierType `serix:"sideNodes,lenPrefix=uint16"`
+	// BitMask marks the side nodes along the path that are placeholders.
+	BitMask []byte `serix:"bitMask,lenPrefix=uint8"`
+	// NumSideNodes is the number of side nodes along the path including the placeholders.
+	NumSideNodes uint16 `serix:"numSideNodes"`
+	// NonMembershipLeafData contains the data of an unrelated leaf at the position of the key in case of a
+	// non-membership proof. It is empty for membership proofs and if there is no leaf at the position of the key.
+	NonMembershipLeafData []byte `serix:"nonMembershipLeafData,lenPrefix=uint16"`
+}
+
+// newProof creates a compact Proof from the given proof of the sparse merkle tree.
+func newProof[IdentifierType types.IdentifierType](proof *smt.SparseMerkleProof, spec *smt.TrieSpec) (*Proof[IdentifierType], error) {
+	compactProof, err := smt.CompactProof(proof, spec)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to compact proof")
+	}
+
+	sideNodes := make([]IdentifierType, len(compactProof.SideNodes))
+	for i, sideNode := range compactProof.SideNodes {
+		copy(sideNodes[i][:], sideNode)
+	}
+
+	return &Proof[IdentifierType]{
+		SideNodes:             sideNodes,
+		BitMask:               compactProof.BitMask,
+		NumSideNodes:          uint16(compactProof.NumSideNodes),
+		NonMembershipLeafData: compactProof.NonMembershipLeafData,
+	}, nil
+}
+
+// VerifyProof verifies that the given key is mapped to the given value in the Map with the given root.
+// The options need to match the options that the Map was created with.
+func VerifyProof[IdentifierType types.IdentifierType](root IdentifierType, key []byte, value []byte, proof *Proof[IdentifierType], opts ...Option) (bool, error) {
+	if len(proof.NonMembershipLeafData) != 0 {
+		return false, nil
+	}
+
+	settings := newSettings(opts)
+	hasher := settings.hashFunction.New()
+	path := digest(hasher, key)
+
+	valueData := value
+	if settings.hashValues {
+		valueData = digest(hasher, value)
+	}
+
+	return proof.verify(hasher, root, path, digest(hasher, leafPrefix, path, valueData))
+}
+
+// VerifySetProof verifies that the given key is an element of the Set with the given root.
+// The options need to match the options that the Set was created with.
+func VerifySetProof[IdentifierType types.IdentifierType](root IdentifierType, key []byte, proof *Proof[IdentifierType], opts ...Option) (bool, error) {
+	return VerifyProof(root, key, []byte{}, proof, opts...)
+}
+
+// VerifyNonMembershipProof verifies that the given key does not exist in the Map or Set with the given root.
+// The options need to match the options that the Map or Set was created with.
+func VerifyNonMembershipProof[IdentifierType types.IdentifierType](root IdentifierType, key []byte, proof *Proof[IdentifierType], opts ...Option) (bool, error) {
+	hasher := newSettings(opts).hashFunction.New()
+	path := digest(hasher, key)
+
+	// there is no leaf at the position of the key
+	if len(proof.NonMembershipLeafData) == 0 {
+		var placeholder IdentifierType
+
+		return proof.verify(hasher, root, path, placeholder[:])
+	}
+
+	// there is an unrelated leaf at the position of the key
+	if len(proof.NonMembershipLeafData) < len(leafPrefix)+len(path) || !bytes.HasPrefix(proof.NonMembershipLeafData, leafPrefix) {
+		return false, ierrors.Wrap(ErrInvalidProof, "invalid non-membership leaf data")
+	}
+
+	if bytes.Equal(proof.NonMembershipLeafData[len(leafPrefix):len(leafPrefix)+len(path)], path) {
+		return false, nil
+	}
+
+	return proof.verify(hasher, root, path, digest(hasher, proof.NonMembershipLeafData))
+}
+
+// verify recomputes the root from the given leaf hash and the side nodes and compares it to the given root.
+func (p *Proof[IdentifierType]) verify(hasher hash.Hash, root IdentifierType, path []byte, leafHash []byte) (bool, error) {
+	if len(path) != len(root) {
+		return false, ierrors.Wrapf(ErrInvalidProof, "hash size %d does not match root size %d", len(path), len(root))
+	}
+
+	// there is at most one side node per bit of the path
+	sideNodes, err := p.decompactedSideNodes(len(path) * 8)
+	if err != nil {
+		return false, err
+	}
+
+	currentHash := leafHash
+	for i, sideNode := range sideNodes {
+		if pathBit(path, len(sideNodes)-1-i) == 0 {
+			currentHash = digest(hasher, innerPrefix, currentHash, sideNode[:])
+		} else {
+			currentHash = digest(hasher, innerPrefix, sideNode[:], currentHash)
+		}
+	}
+
+	return bytes.Equal(currentHash, root[:]), nil
+}
+
+// decompactedSideNodes returns all side nodes of the proof including the placeholders.
+func (p *Proof[IdentifierType]) decompactedSideNodes(maxSideNodes int) ([]IdentifierType, error) {
+	if int(p.NumSideNodes) > maxSideNodes {
+		return nil, ierrors.Wrapf(ErrInvalidProof, "too many side nodes: %d", p.NumSideNodes)
+	}
+
+	if len(p.BitMask) != (int(p.NumSideNodes)+7)/8 {
+		return nil, ierrors.Wrapf(ErrInvalidProof, "invalid bit mask length: %d", len(p.BitMask))
+	}
+
+	placeholders := 0
+	for _, b := range p.BitMask {
+		placeholders += bits.OnesCount8(b)
+	}
+
+	if len(p.SideNodes) != int(p.NumSideNodes)-placeholders {
+		return nil, ierrors.Wrapf(ErrInvalidProof, "invalid number of side nodes: %d", len(p.SideNodes))
+	}
+
+	sideNodes := make([]IdentifierType, p.NumSideNodes)
+	position := 0
+	for i := range sideNodes {
+		if pathBit(p.BitMask, i) == 1 {
+			continue
+		}
+
+		sideNodes[i] = p.SideNodes[position]
+		position++
+	}
+
+	return sideNodes, nil
+}
+
+// digest returns the hash of the concatenation of the given data.
+func digest(hasher hash.Hash, data ...[]byte) []byte {
+	hasher.Reset()
+	for _, d := range data {
+		_, _ = hasher.Write(d)
+	}
+
+	return hasher.Sum(nil)
+}
+
+// pathBit returns the bit at the given position of the path (starting at the most significant bit).
+func pathBit(path []byte, position int) int {
+	if path[position/8]&(1<<(7-uint(position)%8)) != 0 {
+		return 1
+	}
+
+	return 0
+}
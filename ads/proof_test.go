@@ -0,0 +1,134 @@
+package ads
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+	"github.com/iotaledger/hive.go/serializer/v2/typeutils"
+)
+
+func TestMapProof(t *testing.T) {
+	newMap := newAuthenticatedMap[[32]byte](mapdb.NewMapDB(),
+		typeutils.ByteArray32ToBytes,
+		typeutils.ByteArray32FromBytes,
+		testKey.Bytes,
+		testKeyFromBytes,
+		testValue.Bytes,
+		testValueFromBytes,
+	)
+
+	// proofs of an empty map
+	emptyProof, err := newMap.Proof(testKey([]byte{'a'}))
+	require.NoError(t, err)
+	require.True(t, lo.PanicOnErr(VerifyNonMembershipProof(newMap.Root(), []byte{'a'}, emptyProof)))
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, newMap.Set(testKey([]byte{byte(i)}), testValueFromString("value")))
+	}
+	require.NoError(t, newMap.Set(testKey([]byte{'e'}), testValue{}))
+	require.NoError(t, newMap.Commit())
+
+	root := newMap.Root()
+
+	// membership proofs
+	for i := 0; i < 50; i++ {
+		proof, err := newMap.Proof(testKey([]byte{byte(i)}))
+		require.NoError(t, err)
+
+		require.True(t, lo.PanicOnErr(VerifyProof(root, []byte{byte(i)}, []byte("value"), proof)))
+		require.False(t, lo.PanicOnErr(VerifyProof(root, []byte{byte(i)}, []byte("other value"), proof)))
+		require.False(t, lo.PanicOnErr(VerifyProof(root, []byte{byte(i + 1)}, []byte("value"), proof)))
+		require.False(t, lo.PanicOnErr(VerifyNonMembershipProof(root, []byte{byte(i)}, proof)))
+	}
+
+	emptyValueProof, err := newMap.Proof(testKey([]byte{'e'}))
+	require.NoError(t, err)
+	require.True(t, lo.PanicOnErr(VerifyProof(root, []byte{'e'}, []byte{}, emptyValueProof)))
+
+	// non-membership proofs
+	for i := 50; i < 100; i++ {
+		proof, err := newMap.Proof(testKey([]byte{byte(i)}))
+		require.NoError(t, err)
+
+		require.True(t, lo.PanicOnErr(VerifyNonMembershipProof(root, []byte{byte(i)}, proof)))
+		require.False(t, lo.PanicOnErr(VerifyProof(root, []byte{byte(i)}, []byte("value"), proof)))
+		require.False(t, lo.PanicOnErr(VerifyNonMembershipProof(root, []byte{0}, proof)))
+	}
+
+	// proofs for a different root are rejected
+	proof, err := newMap.Proof(testKey([]byte{0}))
+	require.NoError(t, err)
+	require.False(t, lo.PanicOnErr(VerifyProof([32]byte{1}, []byte{0}, []byte("value"), proof)))
+
+	// malformed proofs are rejected
+	_, err = VerifyProof(root, []byte{0}, []byte("value"), &Proof[[32]byte]{NumSideNodes: 8, BitMask: []byte{0}})
+	require.ErrorIs(t, err, ErrInvalidProof)
+	_, err = VerifyNonMembershipProof(root, []byte{0}, &Proof[[32]byte]{NonMembershipLeafData: []byte{0, 1}})
+	require.ErrorIs(t, err, ErrInvalidProof)
+}
+
+func TestSetProof(t *testing.T) {
+	newSet := newAuthenticatedSet[[32]byte](mapdb.NewMapDB(),
+		typeutils.ByteArray32ToBytes,
+		typeutils.ByteArray32FromBytes,
+		testKey.Bytes,
+		testKeyFromBytes,
+	)
+
+	require.NoError(t, newSet.Add(testKey([]byte{'a'})))
+	require.NoError(t, newSet.Add(testKey([]byte{'b'})))
+	require.NoError(t, newSet.Commit())
+
+	membershipProof, err := newSet.Proof(testKey([]byte{'a'}))
+	require.NoError(t, err)
+	require.True(t, lo.PanicOnErr(VerifySetProof(newSet.Root(), []byte{'a'}, membershipProof)))
+	require.False(t, lo.PanicOnErr(VerifyNonMembershipProof(newSet.Root(), []byte{'a'}, membershipProof)))
+
+	nonMembershipProof, err := newSet.Proof(testKey([]byte{'c'}))
+	require.NoError(t, err)
+	require.True(t, lo.PanicOnErr(VerifyNonMembershipProof(newSet.Root(), []byte{'c'}, nonMembershipProof)))
+	require.False(t, lo.PanicOnErr(VerifySetProof(newSet.Root(), []byte{'c'}, nonMembershipProof)))
+}
+
+func TestProofSerialization(t *testing.T) {
+	newSet := newAuthenticatedSet[[32]byte](mapdb.NewMapDB(),
+		typeutils.ByteArray32ToBytes,
+		typeutils.ByteArray32FromBytes,
+		testKey.Bytes,
+		testKeyFromBytes,
+	)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, newSet.Add(testKey([]byte{byte(i)})))
+	}
+	require.NoError(t, newSet.Commit())
+
+	api := serix.NewAPI()
+	for _, key := range []byte{0, 42} {
+		proof, err := newSet.Proof(testKey([]byte{key}))
+		require.NoError(t, err)
+
+		proofBytes, err := api.Encode(context.Background(), proof)
+		require.NoError(t, err)
+
+		decodedProof := new(Proof[[32]byte])
+		consumedBytes, err := api.Decode(context.Background(), proofBytes, decodedProof)
+		require.NoError(t, err)
+		require.Equal(t, len(proofBytes), consumedBytes)
+
+		reencodedBytes, err := api.Encode(context.Background(), decodedProof)
+		require.NoError(t, err)
+		require.Equal(t, proofBytes, reencodedBytes)
+
+		if key == 0 {
+			require.True(t, lo.PanicOnErr(VerifySetProof(newSet.Root(), []byte{key}, decodedProof)))
+		} else {
+			require.True(t, lo.PanicOnErr(VerifyNonMembershipProof(newSet.Root(), []byte{key}, decodedProof)))
+		}
+	}
+}
@@ -11,6 +11,10 @@ type Set[IdentifierType types.IdentifierType, K any] interface {
 	// Root returns the root of the sparse merkle tree.
 	Root() IdentifierType
 
+	// Proof returns a proof for the membership or non-membership of the given key that can be verified against
+	// the current root using VerifySetProof or VerifyNonMembershipProof.
+	Proof(key K) (proof *Proof[IdentifierType], err error)
+
 	// Add adds the key to the set.
 	Add(key K) error
 
@@ -20,12 +24,50 @@ type Set[IdentifierType types.IdentifierType, K any] interface {
 	// Delete deletes the given key.
 	Delete(key K) (deleted bool, err error)
 
-	// Stream streams all the set elements to the given consumer function.
+	// Stream streams all the set elements to the given consumer function in the order of their serialized keys.
 	Stream(consumerFunc func(key K) error) error
 
-	// Commit persists the changes to the underlying store.
+	// StreamPage streams the elements that match the given options to the given consumer function in the order of
+	// their serialized keys and returns the cursor at which the next page starts (nil if there are no more elements).
+	// The set is not locked while the page is streamed.
+	StreamPage(consumerFunc func(key K) error, opts ...StreamOption) (cursor []byte, err error)
+
+	// Import bulk-loads the elements of the given iterator (e.g. the Stream method of another Set) into the empty
+	// set and commits them as a new version.
+	Import(iterator func(consumerFunc func(key K) error) error) error
+
+	// Commit persists the staged changes to the underlying store and records them as a new version.
 	Commit() error
 
+	// Version returns the latest committed version.
+	Version() uint64
+
+	// RootAt returns the root of the sparse merkle tree at the given retained version.
+	RootAt(version uint64) (root IdentifierType, err error)
+
+	// HasAt returns true if the given key existed at the given retained version.
+	HasAt(version uint64, key K) (exists bool, err error)
+
+	// ProofAt returns a proof for the membership or non-membership of the given key that can be verified against
+	// the root of the given retained version.
+	ProofAt(version uint64, key K) (proof *Proof[IdentifierType], err error)
+
+	// Rollback reverts the set to the given retained version and discards all uncommitted changes.
+	Rollback(version uint64) error
+
+	// Discard discards all uncommitted changes.
+	Discard() error
+
+	// Diff compares the latest committed version with the remote set that is reachable through the given transport
+	// and streams the elements that only exist in one of both sets with the given root to the consumer.
+	// The set is not locked while the transport or the consumer is called, so the consumer can repair the set with
+	// Add and Delete. The changes must not be committed or rolled back before Diff returns, otherwise it fails with
+	// ErrVersionChanged.
+	Diff(remote SyncTransport[IdentifierType], remoteRoot IdentifierType, consumer func(key K, existsRemotely bool) error) error
+
+	// HandleSyncRequest returns the requested nodes and keys of the committed versions to a remote set.
+	HandleSyncRequest(request *SyncRequest[IdentifierType]) (response *SyncResponse, err error)
+
 	// Size returns the number of elements in the set.
 	Size() int
 
@@ -34,12 +76,16 @@ type Set[IdentifierType types.IdentifierType, K any] interface {
 }
 
 // NewSet creates a new sparse merkle tree based set.
+//
+// It panics if the set is restored from storage with a different hash function or value hashing setting
+// (ErrSettingsMismatch) or if the size of the hash function does not match the size of the IdentifierType.
 func NewSet[IdentifierType types.IdentifierType, K any](
 	store kvstore.KVStore,
 	identifierToBytes kvstore.ObjectToBytes[IdentifierType],
 	bytesToIdentifier kvstore.BytesToObject[IdentifierType],
 	keyToBytes kvstore.ObjectToBytes[K],
 	bytesToKey kvstore.BytesToObject[K],
+	opts ...Option,
 ) Set[IdentifierType, K] {
-	return newAuthenticatedSet[IdentifierType](store, identifierToBytes, bytesToIdentifier, keyToBytes, bytesToKey)
+	return newAuthenticatedSet[IdentifierType](store, identifierToBytes, bytesToIdentifier, keyToBytes, bytesToKey, opts...)
 }
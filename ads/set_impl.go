@@ -17,9 +17,10 @@ func newAuthenticatedSet[IdentifierType types.IdentifierType, K any](
 	bytesToIdentifier kvstore.BytesToObject[IdentifierType],
 	keyToBytes kvstore.ObjectToBytes[K],
 	bytesToKey kvstore.BytesToObject[K],
+	opts ...Option,
 ) Set[IdentifierType, K] {
 	return &authenticatedSet[IdentifierType, K]{
-		authenticatedMap: newAuthenticatedMap[IdentifierType](store, identifierToBytes, bytesToIdentifier, keyToBytes, bytesToKey, types.Empty.Bytes, types.EmptyFromBytes),
+		authenticatedMap: newAuthenticatedMap[IdentifierType](store, identifierToBytes, bytesToIdentifier, keyToBytes, bytesToKey, types.Empty.Bytes, types.EmptyFromBytes, opts...),
 	}
 }
 
@@ -34,3 +35,28 @@ func (s *authenticatedSet[IdentifierType, K]) Stream(callback func(key K) error)
 		return callback(key)
 	})
 }
+
+// StreamPage streams the elements that match the given options in the order of their serialized keys and returns the
+// cursor at which the next page starts.
+func (s *authenticatedSet[IdentifierType, K]) StreamPage(callback func(key K) error, opts ...StreamOption) (cursor []byte, err error) {
+	return s.authenticatedMap.StreamPage(func(key K, _ types.Empty) error {
+		return callback(key)
+	}, opts...)
+}
+
+// Import bulk-loads the elements of the given iterator into the empty set and commits them as a new version.
+func (s *authenticatedSet[IdentifierType, K]) Import(iterator func(consumerFunc func(key K) error) error) error {
+	return s.authenticatedMap.Import(func(consumerFunc func(key K, value types.Empty) error) error {
+		return iterator(func(key K) error {
+			return consumerFunc(key, types.Void)
+		})
+	})
+}
+
+// Diff compares the latest committed version of the set with the remote set that is reachable through the given
+// transport and streams the elements that only exist in one of both sets to the consumer.
+func (s *authenticatedSet[IdentifierType, K]) Diff(remote SyncTransport[IdentifierType], remoteRoot IdentifierType, consumer func(key K, existsRemotely bool) error) error {
+	return s.authenticatedMap.Diff(remote, remoteRoot, func(key K, _ types.Empty, existsRemotely bool) error {
+		return consumer(key, existsRemotely)
+	})
+}
@@ -95,3 +95,34 @@ func TestStreamSet(t *testing.T) {
 	require.ErrorIs(t, err, ErrStopIteration)
 	require.Equal(t, 1, len(firstSeen))
 }
+
+func TestSetVersions(t *testing.T) {
+	newSet := newAuthenticatedSet[[32]byte](
+		mapdb.NewMapDB(),
+		typeutils.ByteArray32ToBytes,
+		typeutils.ByteArray32FromBytes,
+		testKey.Bytes,
+		testKeyFromBytes,
+		WithRetainedVersions(1),
+	)
+
+	require.NoError(t, newSet.Add(testKey{'a'}))
+	require.NoError(t, newSet.Commit())
+	require.NoError(t, newSet.Add(testKey{'b'}))
+	require.NoError(t, newSet.Commit())
+
+	require.False(t, lo.PanicOnErr(newSet.HasAt(1, testKey{'b'})))
+	require.True(t, lo.PanicOnErr(newSet.HasAt(2, testKey{'b'})))
+
+	proof, err := newSet.ProofAt(1, testKey{'a'})
+	require.NoError(t, err)
+	require.True(t, lo.PanicOnErr(VerifySetProof(lo.PanicOnErr(newSet.RootAt(1)), []byte{'a'}, proof)))
+
+	require.NoError(t, newSet.Rollback(1))
+	require.Equal(t, 1, newSet.Size())
+	require.False(t, lo.PanicOnErr(newSet.Has(testKey{'b'})))
+	require.Equal(t, lo.PanicOnErr(newSet.RootAt(1)), newSet.Root())
+
+	_, err = newSet.RootAt(0)
+	require.ErrorIs(t, err, ErrVersionNotRetained)
+}
@@ -0,0 +1,224 @@
+package ads
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+// WithStreamStart sets the serialized key at which a paginated stream starts (inclusive), e.g. the cursor that was
+// returned by the previous page.
+func WithStreamStart(start []byte) StreamOption {
+	return func(settings *streamSettings) {
+		settings.start = start
+	}
+}
+
+// WithStreamPrefix restricts a paginated stream to the keys whose serialized form starts with the given prefix.
+func WithStreamPrefix(prefix []byte) StreamOption {
+	return func(settings *streamSettings) {
+		settings.prefix = prefix
+	}
+}
+
+// WithStreamLimit sets the maximum number of elements of a page (0 means unlimited).
+func WithStreamLimit(limit int) StreamOption {
+	return func(settings *streamSettings) {
+		settings.limit = limit
+	}
+}
+
+// streamSettings contains the settings of a paginated stream.
+type streamSettings struct {
+	start  []byte
+	prefix []byte
+	limit  int
+}
+
+// StreamOption is a function that configures a paginated stream.
+type StreamOption = options.Option[streamSettings]
+
+// StreamPage streams the key-value pairs in the order of their serialized keys and returns the cursor at which the
+// next page starts (nil if there are no more pairs).
+//
+// The map is only locked while a value is read, so that writers are not blocked by the stream. Changes that happen
+// while the page is streamed may or may not be reflected.
+func (m *authenticatedMap[IdentifierType, K, V]) StreamPage(consumerFunc func(key K, value V) error, opts ...StreamOption) (cursor []byte, err error) {
+	settings := options.Apply(new(streamSettings), opts)
+	addedKeys, deletedKeys := m.uncommittedKeysSnapshot(settings)
+
+	var innerErr error
+	streamedPairs := 0
+	consumeKey := func(keyBytes []byte) bool {
+		if settings.limit != 0 && streamedPairs == settings.limit {
+			cursor = slices.Clone(keyBytes)
+
+			return false
+		}
+
+		var streamed bool
+		if streamed, innerErr = m.streamPair(keyBytes, consumerFunc); streamed {
+			streamedPairs++
+		}
+
+		return innerErr == nil
+	}
+
+	// merge the committed keys with the keys that were added since the latest commit
+	if err = iterateKeysFrom(m.rawKeysStore.KVStore(), settings.prefix, settings.start, func(keyBytes []byte) bool {
+		for ; len(addedKeys) > 0 && bytes.Compare(addedKeys[0], keyBytes) <= 0; addedKeys = addedKeys[1:] {
+			if bytes.Equal(addedKeys[0], keyBytes) {
+				continue
+			}
+
+			if !consumeKey(addedKeys[0]) {
+				return false
+			}
+		}
+
+		// skip keys that were deleted since the latest commit
+		if _, deleted := deletedKeys[string(keyBytes)]; deleted {
+			return true
+		}
+
+		return consumeKey(keyBytes)
+	}); err != nil {
+		return nil, ierrors.Wrap(err, "failed to iterate over raw keys")
+	}
+
+	for ; innerErr == nil && cursor == nil && len(addedKeys) > 0; addedKeys = addedKeys[1:] {
+		if !consumeKey(addedKeys[0]) {
+			break
+		}
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return cursor, nil
+}
+
+// uncommittedKeysSnapshot returns the sorted keys that were added and the keys that were deleted since the latest
+// commit and that are covered by the given stream settings.
+func (m *authenticatedMap[IdentifierType, K, V]) uncommittedKeysSnapshot(settings *streamSettings) (addedKeys [][]byte, deletedKeys map[string]struct{}) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	addedKeys = make([][]byte, 0)
+	deletedKeys = make(map[string]struct{})
+	for keyBytes, added := range m.uncommittedKeys {
+		if !bytes.HasPrefix([]byte(keyBytes), settings.prefix) || bytes.Compare([]byte(keyBytes), settings.start) < 0 {
+			continue
+		}
+
+		if added {
+			addedKeys = append(addedKeys, []byte(keyBytes))
+		} else {
+			deletedKeys[keyBytes] = struct{}{}
+		}
+	}
+	slices.SortFunc(addedKeys, bytes.Compare)
+
+	return addedKeys, deletedKeys
+}
+
+// streamPair reads the value of the given key and passes the pair to the consumer if the key still exists.
+func (m *authenticatedMap[IdentifierType, K, V]) streamPair(keyBytes []byte, consumerFunc func(key K, value V) error) (streamed bool, err error) {
+	key, _, err := m.bytesToKey(keyBytes)
+	if err != nil {
+		return false, ierrors.Wrapf(err, "failed to deserialize key %s", keyBytes)
+	}
+
+	value, exists, err := m.lockedGet(key)
+	if err != nil {
+		return false, ierrors.Wrapf(err, "failed to get value for key %s", keyBytes)
+	} else if !exists {
+		return false, nil
+	}
+
+	if err = consumerFunc(key, value); err != nil {
+		return false, ierrors.Wrapf(err, "failed to execute callback for key %s", keyBytes)
+	}
+
+	return true, nil
+}
+
+// lockedGet returns the value for the given key while holding the read lock of the map.
+func (m *authenticatedMap[IdentifierType, K, V]) lockedGet(key K) (value V, exists bool, err error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	// the tree caches the nodes that it resolves while reading, so reads under the read lock need to be serialized.
+	m.treeReadMutex.Lock()
+	defer m.treeReadMutex.Unlock()
+
+	return m.get(m.tree, key)
+}
+
+// keySeeker is a store that can start an iteration at a key (see kvstore.KVStore.IterateKeysFrom).
+type keySeeker interface {
+	IterateKeysFrom(prefix kvstore.KeyPrefix, start kvstore.Key, consumerFunc kvstore.IteratorKeyConsumerFunc) error
+}
+
+// iterateKeysFrom iterates over the keys of the store that start with the given prefix in ascending order, starting
+// at the given key (inclusive).
+//
+// Stores that can't seek to the start key can only iterate over prefixes, so the range of keys after the start is
+// split into the prefixes that share a decreasing number of bytes with the start key, which avoids scanning the keys
+// before the start.
+func iterateKeysFrom(store kvstore.KVStore, prefix []byte, start []byte, consumerFunc kvstore.IteratorKeyConsumerFunc) error {
+	if seeker, canSeek := store.(keySeeker); canSeek {
+		return seeker.IterateKeysFrom(prefix, start, consumerFunc)
+	}
+
+	if !bytes.HasPrefix(start, prefix) {
+		// all keys with the prefix are smaller than the start
+		if bytes.Compare(start, prefix) > 0 {
+			return nil
+		}
+
+		return store.IterateKeys(prefix, consumerFunc)
+	}
+
+	aborted := false
+	iterate := func(iterationPrefix []byte) error {
+		return store.IterateKeys(iterationPrefix, func(key []byte) bool {
+			aborted = !consumerFunc(key)
+
+			return !aborted
+		})
+	}
+
+	// the start key and the keys that extend it
+	if err := iterate(start); err != nil || aborted {
+		return err
+	}
+
+	for i := len(start) - 1; i >= len(prefix); i-- {
+		// skip the bytes that are not used by any key after the start
+		var largestKey []byte
+		if err := store.IterateKeys(start[:i], func(key []byte) bool {
+			largestKey = slices.Clone(key)
+
+			return false
+		}, kvstore.IterDirectionBackward); err != nil {
+			return err
+		}
+
+		if len(largestKey) <= i || largestKey[i] <= start[i] {
+			continue
+		}
+
+		for b := int(start[i]) + 1; b <= int(largestKey[i]); b++ {
+			if err := iterate(append(slices.Clone(start[:i]), byte(b))); err != nil || aborted {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package ads
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/typeutils"
+)
+
+func TestMapStreamPage(t *testing.T) {
+	stringToBytes := func(s string) ([]byte, error) { return []byte(s), nil }
+	stringFromBytes := func(b []byte) (string, int, error) { return string(b), len(b), nil }
+
+	newMap := newAuthenticatedMap[[32]byte](mapdb.NewMapDB(),
+		typeutils.ByteArray32ToBytes,
+		typeutils.ByteArray32FromBytes,
+		stringToBytes,
+		stringFromBytes,
+		stringToBytes,
+		stringFromBytes,
+	)
+
+	for _, key := range []string{"c", "abc", "a", "ba", "b", "ab"} {
+		require.NoError(t, newMap.Set(key, "value "+key))
+	}
+	require.NoError(t, newMap.Commit())
+
+	// uncommitted changes are part of the stream
+	require.NoError(t, newMap.Set("aa", "value aa"))
+	require.True(t, lo.PanicOnErr(newMap.Delete("b")))
+
+	streamPage := func(opts ...StreamOption) (keys []string, cursor []byte) {
+		keys = make([]string, 0)
+		cursor, err := newMap.StreamPage(func(key string, value string) error {
+			require.Equal(t, "value "+key, value)
+			keys = append(keys, key)
+
+			return nil
+		}, opts...)
+		require.NoError(t, err)
+
+		return keys, cursor
+	}
+
+	keys, cursor := streamPage()
+	require.Equal(t, []string{"a", "aa", "ab", "abc", "ba", "c"}, keys)
+	require.Nil(t, cursor)
+
+	// the pages can be resumed with the returned cursor
+	keys, cursor = streamPage(WithStreamLimit(2))
+	require.Equal(t, []string{"a", "aa"}, keys)
+	require.Equal(t, []byte("ab"), cursor)
+
+	keys, cursor = streamPage(WithStreamLimit(2), WithStreamStart(cursor))
+	require.Equal(t, []string{"ab", "abc"}, keys)
+	require.Equal(t, []byte("ba"), cursor)
+
+	keys, cursor = streamPage(WithStreamLimit(2), WithStreamStart(cursor))
+	require.Equal(t, []string{"ba", "c"}, keys)
+	require.Nil(t, cursor)
+
+	// the stream can be restricted to a prefix
+	keys, cursor = streamPage(WithStreamPrefix([]byte("a")))
+	require.Equal(t, []string{"a", "aa", "ab", "abc"}, keys)
+	require.Nil(t, cursor)
+
+	keys, _ = streamPage(WithStreamPrefix([]byte("a")), WithStreamStart([]byte("abb")))
+	require.Equal(t, []string{"abc"}, keys)
+
+	keys, _ = streamPage(WithStreamPrefix([]byte("b")), WithStreamStart([]byte("a")))
+	require.Equal(t, []string{"ba"}, keys)
+
+	keys, _ = streamPage(WithStreamPrefix([]byte("a")), WithStreamStart([]byte("b")))
+	require.Empty(t, keys)
+
+	// the map is not locked while the consumer is called
+	_, err := newMap.StreamPage(func(key string, _ string) error {
+		return newMap.Set(key, "value "+key)
+	})
+	require.NoError(t, err)
+}
+
+func TestIterateKeysFrom(t *testing.T) {
+	t.Run("prefixes", func(t *testing.T) {
+		testIterateKeysFrom(t, mapdb.NewMapDB())
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		store := &seekingStore{KVStore: mapdb.NewMapDB()}
+		testIterateKeysFrom(t, store)
+		require.Equal(t, 500, store.seeks)
+	})
+}
+
+func testIterateKeysFrom(t *testing.T, store kvstore.KVStore) {
+
+	keys := make([][]byte, 0)
+	for i := 0; i < 200; i++ {
+		key := make([]byte, 1+rand.Intn(3))
+		for j := range key {
+			key[j] = byte('a' + rand.Intn(4))
+		}
+
+		if !slices.ContainsFunc(keys, func(existingKey []byte) bool { return bytes.Equal(existingKey, key) }) {
+			keys = append(keys, key)
+			require.NoError(t, store.Set(key, []byte{}))
+		}
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	randomKey := func(maxLength int) []byte {
+		key := make([]byte, rand.Intn(maxLength+1))
+		for j := range key {
+			key[j] = byte('a' + rand.Intn(5))
+		}
+
+		return key
+	}
+
+	for i := 0; i < 500; i++ {
+		prefix, start := randomKey(2), randomKey(4)
+
+		expectedKeys := make([][]byte, 0)
+		for _, key := range keys {
+			if bytes.HasPrefix(key, prefix) && bytes.Compare(key, start) >= 0 {
+				expectedKeys = append(expectedKeys, key)
+			}
+		}
+
+		iteratedKeys := make([][]byte, 0)
+		require.NoError(t, iterateKeysFrom(store, prefix, start, func(key []byte) bool {
+			iteratedKeys = append(iteratedKeys, key)
+
+			return true
+		}))
+		require.Equal(t, expectedKeys, iteratedKeys, "prefix %s, start %s", prefix, start)
+	}
+}
+
+// seekingStore is a store that can start an iteration at a key.
+type seekingStore struct {
+	kvstore.KVStore
+
+	seeks int
+}
+
+func (s *seekingStore) IterateKeysFrom(prefix kvstore.KeyPrefix, start kvstore.Key, consumerFunc kvstore.IteratorKeyConsumerFunc) error {
+	s.seeks++
+
+	return s.KVStore.IterateKeys(prefix, func(key kvstore.Key) bool {
+		if bytes.Compare(key, start) < 0 {
+			return true
+		}
+
+		return consumerFunc(key)
+	})
+}
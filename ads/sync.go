@@ -0,0 +1,474 @@
+package ads
+
+import (
+	"bytes"
+	"hash"
+
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// maxSyncRequestItems is the maximum number of nodes, keys or values that are requested at once during a diff.
+	maxSyncRequestItems = 1024
+)
+
+var (
+	// ErrInvalidSyncResponse is returned if a remote map sends a response that does not match the request.
+	ErrInvalidSyncResponse = ierrors.New("invalid sync response")
+
+	// ErrVersionChanged is returned if a new version is committed or rolled back while a map is compared.
+	ErrVersionChanged = ierrors.New("committed version changed")
+)
+
+// SyncRequest is a request for the nodes, keys and values of a (remote) Map or Set.
+type SyncRequest[IdentifierType types.IdentifierType] struct {
+	// NodeHashes contains the hashes of the requested nodes.
+	NodeHashes []IdentifierType
+	// KeyPaths contains the paths of the leaves whose keys are requested.
+	KeyPaths []IdentifierType
+	// LeafHashes contains the hashes of the leaves whose values are requested (if the values are hashed).
+	LeafHashes []IdentifierType
+}
+
+// SyncResponse is the response to a SyncRequest.
+type SyncResponse struct {
+	// Nodes contains the encoded nodes in the order of the requested hashes.
+	Nodes [][]byte
+	// Keys contains the keys in the order of the requested paths.
+	Keys [][]byte
+	// Values contains the values in the order of the requested leaf hashes.
+	Values [][]byte
+}
+
+// SyncServer answers the SyncRequests of other maps.
+type SyncServer[IdentifierType types.IdentifierType] interface {
+	// HandleSyncRequest returns the requested nodes, keys and values of the committed versions.
+	HandleSyncRequest(request *SyncRequest[IdentifierType]) (response *SyncResponse, err error)
+}
+
+// SyncTransport sends SyncRequests to a (remote) SyncServer.
+type SyncTransport[IdentifierType types.IdentifierType] interface {
+	// Request sends the given request and returns the response.
+	Request(request *SyncRequest[IdentifierType]) (response *SyncResponse, err error)
+}
+
+// NewLocalSyncTransport returns a SyncTransport that passes the requests to the given in-process SyncServer.
+func NewLocalSyncTransport[IdentifierType types.IdentifierType](server SyncServer[IdentifierType]) SyncTransport[IdentifierType] {
+	return &localSyncTransport[IdentifierType]{
+		server: server,
+	}
+}
+
+// localSyncTransport is a SyncTransport that passes the requests to an in-process SyncServer.
+type localSyncTransport[IdentifierType types.IdentifierType] struct {
+	server SyncServer[IdentifierType]
+}
+
+// Request sends the given request and returns the response.
+func (l *localSyncTransport[IdentifierType]) Request(request *SyncRequest[IdentifierType]) (*SyncResponse, error) {
+	return l.server.HandleSyncRequest(request)
+}
+
+// difference describes a leaf that differs between the local and the remote tree.
+type difference struct {
+	// keyBytes is the key of the leaf.
+	keyBytes []byte
+	// remoteLeaf is the leaf of the remote tree (nil if the key does not exist remotely).
+	remoteLeaf *treeNode
+	// remoteValue is the value of the remote leaf.
+	remoteValue []byte
+}
+
+// treeDiff walks two trees top-down and reports the leaves that differ.
+type treeDiff[IdentifierType types.IdentifierType] struct {
+	hasher     hash.Hash
+	hashValues bool
+	localNode  func(hash []byte) ([]byte, error)
+	localKey   func(path []byte) ([]byte, error)
+	remote     SyncTransport[IdentifierType]
+	consumer   func(difference *difference) error
+}
+
+// newTreeDiff creates a treeDiff that compares a local tree with a remote tree.
+func newTreeDiff[IdentifierType types.IdentifierType](hasher hash.Hash, hashValues bool, localNode func(hash []byte) ([]byte, error), localKey func(path []byte) ([]byte, error), remote SyncTransport[IdentifierType], consumer func(difference *difference) error) *treeDiff[IdentifierType] {
+	return &treeDiff[IdentifierType]{
+		hasher:     hasher,
+		hashValues: hashValues,
+		localNode:  localNode,
+		localKey:   localKey,
+		remote:     remote,
+		consumer:   consumer,
+	}
+}
+
+// diffPair is a pair of subtrees at the same position of the local and the remote tree.
+type diffPair struct {
+	local  *treeNode
+	remote *treeNode
+	depth  int
+}
+
+// Run compares the trees with the given roots.
+func (d *treeDiff[IdentifierType]) Run(localRoot []byte, remoteRoot []byte) error {
+	pairs := []*diffPair{{local: newUnresolvedNode(localRoot), remote: newUnresolvedNode(remoteRoot)}}
+	for len(pairs) > 0 {
+		// skip the subtrees whose hashes match
+		differentPairs := make([]*diffPair, 0, len(pairs))
+		for _, pair := range pairs {
+			if !bytes.Equal(pair.local.hash, pair.remote.hash) {
+				differentPairs = append(differentPairs, pair)
+			}
+		}
+
+		if err := d.resolve(differentPairs); err != nil {
+			return err
+		}
+
+		differences := make([]*difference, 0)
+		nextPairs := make([]*diffPair, 0, 2*len(differentPairs))
+		for _, pair := range differentPairs {
+			local, remote := pair.local, pair.remote
+
+			switch {
+			case local.kind == leafNode && remote.kind == leafNode && bytes.Equal(local.path, remote.path):
+				localDifference, err := d.localDifference(local, remote)
+				if err != nil {
+					return err
+				}
+
+				differences = append(differences, localDifference)
+			case local.kind == leafNode && (remote.kind == leafNode || remote.kind == emptyNode):
+				localDifference, err := d.localDifference(local, nil)
+				if err != nil {
+					return err
+				}
+
+				differences = append(differences, localDifference)
+
+				if remote.kind == leafNode {
+					differences = append(differences, &difference{remoteLeaf: remote})
+				}
+			case remote.kind == leafNode && local.kind == emptyNode:
+				differences = append(differences, &difference{remoteLeaf: remote})
+			default:
+				localLeft, localRight, err := local.children(d.hasher, pair.depth)
+				if err != nil {
+					return ierrors.Wrap(err, "failed to expand local node")
+				}
+
+				remoteLeft, remoteRight, err := remote.children(d.hasher, pair.depth)
+				if err != nil {
+					return ierrors.Wrap(err, "failed to expand remote node")
+				}
+
+				nextPairs = append(nextPairs,
+					&diffPair{local: localLeft, remote: remoteLeft, depth: pair.depth + 1},
+					&diffPair{local: localRight, remote: remoteRight, depth: pair.depth + 1},
+				)
+			}
+		}
+
+		if err := d.report(differences); err != nil {
+			return err
+		}
+
+		pairs = nextPairs
+	}
+
+	return nil
+}
+
+// resolve loads the unresolved nodes of the given pairs from the local store and the remote map.
+func (d *treeDiff[IdentifierType]) resolve(pairs []*diffPair) error {
+	unresolvedRemoteNodes := make([]*treeNode, 0)
+	for _, pair := range pairs {
+		if !pair.local.resolved {
+			nodeBytes, err := d.localNode(pair.local.hash)
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to load local node %x", pair.local.hash)
+			}
+
+			if err = pair.local.parse(d.hasher, nodeBytes); err != nil {
+				return ierrors.Wrapf(err, "failed to parse local node %x", pair.local.hash)
+			}
+		}
+
+		if !pair.remote.resolved {
+			unresolvedRemoteNodes = append(unresolvedRemoteNodes, pair.remote)
+		}
+	}
+
+	for len(unresolvedRemoteNodes) > 0 {
+		batch := unresolvedRemoteNodes[:min(len(unresolvedRemoteNodes), maxSyncRequestItems)]
+		unresolvedRemoteNodes = unresolvedRemoteNodes[len(batch):]
+
+		request := &SyncRequest[IdentifierType]{NodeHashes: make([]IdentifierType, len(batch))}
+		for i, node := range batch {
+			copy(request.NodeHashes[i][:], node.hash)
+		}
+
+		response, err := d.remote.Request(request)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to request remote nodes")
+		}
+
+		if len(response.Nodes) != len(batch) {
+			return ierrors.Wrapf(ErrInvalidSyncResponse, "expected %d nodes, got %d", len(batch), len(response.Nodes))
+		}
+
+		for i, node := range batch {
+			if err = node.parse(d.hasher, response.Nodes[i]); err != nil {
+				return ierrors.Join(ErrInvalidSyncResponse, ierrors.Wrapf(err, "failed to parse remote node %x", node.hash))
+			}
+		}
+	}
+
+	return nil
+}
+
+// localDifference returns the difference of a leaf that exists locally.
+func (d *treeDiff[IdentifierType]) localDifference(localLeaf *treeNode, remoteLeaf *treeNode) (*difference, error) {
+	keyBytes, err := d.localKey(localLeaf.path)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to get local key of path %x", localLeaf.path)
+	}
+
+	return &difference{keyBytes: keyBytes, remoteLeaf: remoteLeaf}, nil
+}
+
+// report completes the given differences with the remote keys and values and passes them to the consumer.
+func (d *treeDiff[IdentifierType]) report(differences []*difference) error {
+	remoteOnlyDifferences := make([]*difference, 0)
+	remoteDifferences := make([]*difference, 0)
+	for _, difference := range differences {
+		if difference.keyBytes == nil {
+			remoteOnlyDifferences = append(remoteOnlyDifferences, difference)
+		}
+
+		if difference.remoteLeaf != nil {
+			remoteDifferences = append(remoteDifferences, difference)
+		}
+	}
+
+	if err := d.requestKeys(remoteOnlyDifferences); err != nil {
+		return err
+	}
+
+	if err := d.requestValues(remoteDifferences); err != nil {
+		return err
+	}
+
+	for _, difference := range differences {
+		if err := d.consumer(difference); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// requestKeys requests the keys of the given differences whose leaves only exist remotely.
+func (d *treeDiff[IdentifierType]) requestKeys(differences []*difference) error {
+	for len(differences) > 0 {
+		batch := differences[:min(len(differences), maxSyncRequestItems)]
+		differences = differences[len(batch):]
+
+		request := &SyncRequest[IdentifierType]{KeyPaths: make([]IdentifierType, len(batch))}
+		for i, difference := range batch {
+			copy(request.KeyPaths[i][:], difference.remoteLeaf.path)
+		}
+
+		response, err := d.remote.Request(request)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to request remote keys")
+		}
+
+		if len(response.Keys) != len(batch) {
+			return ierrors.Wrapf(ErrInvalidSyncResponse, "expected %d keys, got %d", len(batch), len(response.Keys))
+		}
+
+		for i, difference := range batch {
+			if !bytes.Equal(digest(d.hasher, response.Keys[i]), difference.remoteLeaf.path) {
+				return ierrors.Wrapf(ErrInvalidSyncResponse, "key does not match path %x", difference.remoteLeaf.path)
+			}
+
+			difference.keyBytes = response.Keys[i]
+		}
+	}
+
+	return nil
+}
+
+// requestValues requests the values of the remote leaves of the given differences if the leaves only contain the
+// hashes of the values.
+func (d *treeDiff[IdentifierType]) requestValues(differences []*difference) error {
+	if !d.hashValues {
+		for _, difference := range differences {
+			difference.remoteValue = difference.remoteLeaf.valueData
+		}
+
+		return nil
+	}
+
+	for len(differences) > 0 {
+		batch := differences[:min(len(differences), maxSyncRequestItems)]
+		differences = differences[len(batch):]
+
+		request := &SyncRequest[IdentifierType]{LeafHashes: make([]IdentifierType, len(batch))}
+		for i, difference := range batch {
+			copy(request.LeafHashes[i][:], difference.remoteLeaf.hash)
+		}
+
+		response, err := d.remote.Request(request)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to request remote values")
+		}
+
+		if len(response.Values) != len(batch) {
+			return ierrors.Wrapf(ErrInvalidSyncResponse, "expected %d values, got %d", len(batch), len(response.Values))
+		}
+
+		for i, difference := range batch {
+			if !bytes.Equal(digest(d.hasher, response.Values[i]), difference.remoteLeaf.valueData) {
+				return ierrors.Wrapf(ErrInvalidSyncResponse, "value does not match leaf %x", difference.remoteLeaf.hash)
+			}
+
+			difference.remoteValue = response.Values[i]
+		}
+	}
+
+	return nil
+}
+
+// treeNodeKind is the kind of a node of the tree.
+type treeNodeKind uint8
+
+const (
+	emptyNode treeNodeKind = iota
+	leafNode
+	innerNode
+	extensionNode
+)
+
+// treeNode is a decoded node of the tree.
+type treeNode struct {
+	hash     []byte
+	resolved bool
+	kind     treeNodeKind
+
+	// path is the path of a leaf or the path that contains the bits of an extension.
+	path []byte
+	// valueData is the value of a leaf.
+	valueData []byte
+	// left and right are the hashes of the children of an inner node.
+	left  []byte
+	right []byte
+	// start and end are the bounds of the bits of the path that are covered by an extension.
+	start int
+	end   int
+	// child is the hash of the child of an extension.
+	child []byte
+}
+
+// newUnresolvedNode creates a node with the given hash that still needs to be loaded.
+func newUnresolvedNode(hash []byte) *treeNode {
+	if bytes.Equal(hash, make([]byte, len(hash))) {
+		return &treeNode{hash: hash, resolved: true, kind: emptyNode}
+	}
+
+	return &treeNode{hash: hash}
+}
+
+// parse decodes the given node and verifies that it matches the hash of the treeNode.
+func (t *treeNode) parse(hasher hash.Hash, nodeBytes []byte) error {
+	hashSize := hasher.Size()
+
+	switch {
+	case bytes.HasPrefix(nodeBytes, leafPrefix) && len(nodeBytes) >= len(leafPrefix)+hashSize:
+		t.kind = leafNode
+		t.path = nodeBytes[len(leafPrefix) : len(leafPrefix)+hashSize]
+		t.valueData = nodeBytes[len(leafPrefix)+hashSize:]
+	case bytes.HasPrefix(nodeBytes, innerPrefix) && len(nodeBytes) == len(innerPrefix)+2*hashSize:
+		t.kind = innerNode
+		t.left = nodeBytes[len(innerPrefix) : len(innerPrefix)+hashSize]
+		t.right = nodeBytes[len(innerPrefix)+hashSize:]
+	case bytes.HasPrefix(nodeBytes, extensionPrefix) && len(nodeBytes) == len(extensionPrefix)+2+2*hashSize:
+		t.kind = extensionNode
+		t.start = int(nodeBytes[len(extensionPrefix)])
+		t.end = int(nodeBytes[len(extensionPrefix)+1])
+		t.path = nodeBytes[len(extensionPrefix)+2 : len(extensionPrefix)+2+hashSize]
+		t.child = nodeBytes[len(extensionPrefix)+2+hashSize:]
+
+		if t.start >= t.end {
+			return ierrors.Errorf("invalid extension bounds [%d, %d)", t.start, t.end)
+		}
+	default:
+		return ierrors.New("invalid node encoding")
+	}
+
+	if nodeHash := t.computeHash(hasher, nodeBytes); !bytes.Equal(nodeHash, t.hash) {
+		return ierrors.Errorf("node hash %x does not match requested hash %x", nodeHash, t.hash)
+	}
+
+	t.resolved = true
+
+	return nil
+}
+
+// computeHash returns the hash of the given encoded node.
+func (t *treeNode) computeHash(hasher hash.Hash, nodeBytes []byte) []byte {
+	if t.kind != extensionNode {
+		return digest(hasher, nodeBytes)
+	}
+
+	// the hash of an extension is the hash of the chain of inner nodes that it represents
+	nodeHash := t.child
+	placeholder := make([]byte, hasher.Size())
+	for i := t.end - 1; i >= t.start; i-- {
+		if pathBit(t.path, i) == 0 {
+			nodeHash = digest(hasher, innerPrefix, nodeHash, placeholder)
+		} else {
+			nodeHash = digest(hasher, innerPrefix, placeholder, nodeHash)
+		}
+	}
+
+	return nodeHash
+}
+
+// children returns the subtrees below the node, if the node is located at the given depth.
+func (t *treeNode) children(hasher hash.Hash, depth int) (left *treeNode, right *treeNode, err error) {
+	empty := &treeNode{hash: make([]byte, hasher.Size()), resolved: true, kind: emptyNode}
+
+	switch t.kind {
+	case emptyNode:
+		return empty, empty, nil
+	case leafNode:
+		// a leaf is pushed down until it meets the leaves of the other tree
+		if pathBit(t.path, depth) == 0 {
+			return t, empty, nil
+		}
+
+		return empty, t, nil
+	case innerNode:
+		return newUnresolvedNode(t.left), newUnresolvedNode(t.right), nil
+	case extensionNode:
+		if t.start != depth {
+			return nil, nil, ierrors.Errorf("extension starts at depth %d instead of %d", t.start, depth)
+		}
+
+		child := newUnresolvedNode(t.child)
+		if t.end > depth+1 {
+			child = &treeNode{resolved: true, kind: extensionNode, path: t.path, start: depth + 1, end: t.end, child: t.child}
+			child.hash = child.computeHash(hasher, nil)
+		}
+
+		if pathBit(t.path, depth) == 0 {
+			return child, empty, nil
+		}
+
+		return empty, child, nil
+	default:
+		return nil, nil, ierrors.Errorf("unknown node kind %d", t.kind)
+	}
+}
@@ -0,0 +1,233 @@
+package ads
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/typeutils"
+)
+
+type remoteEntry struct {
+	value  testValue
+	exists bool
+}
+
+func TestMapDiff(t *testing.T) {
+	newTestMap := func() *authenticatedMap[[32]byte, uint64, testValue] {
+		return newAuthenticatedMap[[32]byte](mapdb.NewMapDB(),
+			typeutils.ByteArray32ToBytes,
+			typeutils.ByteArray32FromBytes,
+			typeutils.Uint64ToBytes,
+			typeutils.Uint64FromBytes,
+			testValue.Bytes,
+			testValueFromBytes,
+		)
+	}
+
+	localMap, remoteMap := newTestMap(), newTestMap()
+	for i := uint64(0); i < 2000; i++ {
+		require.NoError(t, localMap.Set(i, testValueFromString(fmt.Sprintf("value-%d", i))))
+		require.NoError(t, remoteMap.Set(i, testValueFromString(fmt.Sprintf("value-%d", i))))
+	}
+
+	expectedDifferences := map[uint64]remoteEntry{
+		5:    {value: testValueFromString("changed"), exists: true},
+		1234: {value: testValueFromString("changed"), exists: true},
+		10:   {},
+		20:   {},
+		2000: {value: testValueFromString("remote only"), exists: true},
+		2001: {value: testValue{}, exists: true},
+		3000: {},
+	}
+	require.NoError(t, localMap.Set(3000, testValueFromString("local only")))
+	for key, entry := range expectedDifferences {
+		if entry.exists {
+			require.NoError(t, remoteMap.Set(key, entry.value))
+		} else {
+			require.NoError(t, lo.Return2(remoteMap.Delete(key)))
+		}
+	}
+	require.NoError(t, localMap.Commit())
+	require.NoError(t, remoteMap.Commit())
+
+	remote := &countingSyncTransport{SyncTransport: NewLocalSyncTransport[[32]byte](remoteMap)}
+	differences := make(map[uint64]remoteEntry)
+	require.NoError(t, localMap.Diff(remote, remoteMap.Root(), func(key uint64, remoteValue testValue, existsRemotely bool) error {
+		require.NotContains(t, differences, key)
+		differences[key] = remoteEntry{value: remoteValue, exists: existsRemotely}
+
+		return nil
+	}))
+	require.Equal(t, expectedDifferences, differences)
+
+	// only the divergent part of the tree is requested
+	require.Less(t, remote.requestedNodes, 200)
+
+	// repairing the differences results in the remote root
+	for key, entry := range differences {
+		if entry.exists {
+			require.NoError(t, localMap.Set(key, entry.value))
+		} else {
+			require.NoError(t, lo.Return2(localMap.Delete(key)))
+		}
+	}
+	require.NoError(t, localMap.Commit())
+	require.Equal(t, remoteMap.Root(), localMap.Root())
+
+	require.NoError(t, localMap.Diff(remote, remoteMap.Root(), func(key uint64, _ testValue, _ bool) error {
+		return ierrors.Errorf("unexpected difference for key %d", key)
+	}))
+
+	// a bulk-loaded map has no differences to a map that was built by single inserts
+	importedMap := newTestMap()
+	require.NoError(t, importedMap.Import(remoteMap.Stream))
+	require.NoError(t, importedMap.Diff(NewLocalSyncTransport[[32]byte](remoteMap), remoteMap.Root(), func(key uint64, _ testValue, _ bool) error {
+		return ierrors.Errorf("unexpected difference for key %d", key)
+	}))
+
+	// compare against an empty remote map
+	emptyMap := newTestMap()
+	require.NoError(t, emptyMap.Commit())
+
+	differences = make(map[uint64]remoteEntry)
+	require.NoError(t, emptyMap.Diff(NewLocalSyncTransport[[32]byte](remoteMap), remoteMap.Root(), func(key uint64, remoteValue testValue, existsRemotely bool) error {
+		differences[key] = remoteEntry{value: remoteValue, exists: existsRemotely}
+
+		// the consumer can repair the map while it is compared
+		return emptyMap.Set(key, remoteValue)
+	}))
+	require.Equal(t, remoteMap.Size(), len(differences))
+	require.NoError(t, emptyMap.Commit())
+	require.Equal(t, remoteMap.Root(), emptyMap.Root())
+
+	// the compared version must not change during the comparison
+	partialMap := newTestMap()
+	for i := uint64(0); i < 2000; i += 2 {
+		require.NoError(t, partialMap.Set(i, testValueFromString(fmt.Sprintf("value-%d", i))))
+	}
+	require.NoError(t, partialMap.Commit())
+	require.ErrorIs(t, partialMap.Diff(NewLocalSyncTransport[[32]byte](remoteMap), remoteMap.Root(), func(uint64, testValue, bool) error {
+		return partialMap.Commit()
+	}), ErrVersionChanged)
+
+	// responses that do not match the requested nodes are rejected
+	tamperedTransport := &tamperingSyncTransport{SyncTransport: NewLocalSyncTransport[[32]byte](remoteMap)}
+	require.ErrorIs(t, newTestMap().Diff(tamperedTransport, remoteMap.Root(), func(uint64, testValue, bool) error {
+		return nil
+	}), ErrInvalidSyncResponse)
+}
+
+func TestSetDiff(t *testing.T) {
+	newTestSet := func() Set[[32]byte, testKey] {
+		return newAuthenticatedSet[[32]byte](mapdb.NewMapDB(),
+			typeutils.ByteArray32ToBytes,
+			typeutils.ByteArray32FromBytes,
+			testKey.Bytes,
+			testKeyFromBytes,
+		)
+	}
+
+	localSet, remoteSet := newTestSet(), newTestSet()
+	require.NoError(t, localSet.Add(testKey{'a'}))
+	require.NoError(t, localSet.Add(testKey{'b'}))
+	require.NoError(t, remoteSet.Add(testKey{'b'}))
+	require.NoError(t, remoteSet.Add(testKey{'c'}))
+	require.NoError(t, localSet.Commit())
+	require.NoError(t, remoteSet.Commit())
+
+	differences := make(map[testKey]bool)
+	require.NoError(t, localSet.Diff(NewLocalSyncTransport[[32]byte](remoteSet), remoteSet.Root(), func(key testKey, existsRemotely bool) error {
+		differences[key] = existsRemotely
+
+		return nil
+	}))
+	require.Equal(t, map[testKey]bool{{'a'}: false, {'c'}: true}, differences)
+}
+
+// countingSyncTransport counts the requested nodes.
+type countingSyncTransport struct {
+	SyncTransport[[32]byte]
+
+	requestedNodes int
+}
+
+func (c *countingSyncTransport) Request(request *SyncRequest[[32]byte]) (*SyncResponse, error) {
+	c.requestedNodes += len(request.NodeHashes)
+
+	return c.SyncTransport.Request(request)
+}
+
+// tamperingSyncTransport modifies the returned nodes.
+type tamperingSyncTransport struct {
+	SyncTransport[[32]byte]
+}
+
+func (c *tamperingSyncTransport) Request(request *SyncRequest[[32]byte]) (*SyncResponse, error) {
+	response, err := c.SyncTransport.Request(request)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, node := range response.Nodes {
+		tamperedNode := append([]byte{}, node...)
+		tamperedNode[len(tamperedNode)-1]++
+		response.Nodes[i] = tamperedNode
+	}
+
+	return response, nil
+}
+
+func TestMapKeyPathsIndex(t *testing.T) {
+	store := mapdb.NewMapDB()
+	newTestMap := func() *authenticatedMap[[32]byte, testKey, testValue] {
+		return newAuthenticatedMap[[32]byte](store,
+			typeutils.ByteArray32ToBytes,
+			typeutils.ByteArray32FromBytes,
+			testKey.Bytes,
+			testKeyFromBytes,
+			testValue.Bytes,
+			testValueFromBytes,
+		)
+	}
+
+	oldMap := newTestMap()
+	require.NoError(t, oldMap.Set(testKey{'a'}, testValueFromString("1")))
+	require.NoError(t, oldMap.Commit())
+
+	// the key paths are only indexed once the map is synced
+	require.False(t, oldMap.keyPathsIndexed.Load())
+	require.NoError(t, oldMap.keyPaths.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		require.Fail(t, "unexpected key path", "%x", key)
+
+		return true
+	}))
+
+	keyPathRequest := func(keys ...byte) *SyncRequest[[32]byte] {
+		request := &SyncRequest[[32]byte]{}
+		for _, key := range keys {
+			request.KeyPaths = append(request.KeyPaths, [32]byte(oldMap.keyPath([]byte{key})))
+		}
+
+		return request
+	}
+
+	response, err := oldMap.HandleSyncRequest(keyPathRequest('a'))
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{'a'}}, response.Keys)
+
+	// the index is maintained by later changes and restored maps
+	require.NoError(t, oldMap.Set(testKey{'b'}, testValueFromString("2")))
+	require.NoError(t, oldMap.Commit())
+
+	restoredMap := newTestMap()
+	require.True(t, restoredMap.keyPathsIndexed.Load())
+	response, err = restoredMap.HandleSyncRequest(keyPathRequest('a', 'b'))
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{'a'}, {'b'}}, response.Keys)
+}
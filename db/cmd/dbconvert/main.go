@@ -0,0 +1,45 @@
+// Package main implements a command to convert a database to another database engine.
+// Use with "dbconvert -src [path] -dst [path] -dst-engine [engine]".
+// The command needs to be built with the "rocksdb" build tag to support the rocksdb engine.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/iotaledger/hive.go/db"
+)
+
+func main() {
+	srcPath := flag.String("src", "", "the path of the source database")
+	srcEngine := flag.String("src-engine", "", "the engine of the source database (default: read from the database info file)")
+	dstPath := flag.String("dst", "", "the path of the target database, the folder must not exist or be empty")
+	dstEngine := flag.String("dst-engine", "", "the engine of the target database")
+	flag.Parse()
+
+	if *srcPath == "" || *dstPath == "" || *dstEngine == "" {
+		printUsage("the source path, the target path and the target engine must be specified")
+	}
+
+	startTime := time.Now()
+
+	if err := db.Convert(*srcPath, db.EngineFromString(*srcEngine), *dstPath, db.EngineFromString(*dstEngine), func(copiedEntries uint64) {
+		fmt.Printf("copied %d entries (%v)\n", copiedEntries, time.Since(startTime).Truncate(time.Millisecond))
+	}); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error:\t%s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("converted database %s to %s (%s) in %v\n", *srcPath, *dstPath, *dstEngine, time.Since(startTime).Truncate(time.Millisecond))
+}
+
+// printUsage prints the usage of the command in case of an error.
+func printUsage(errorMsg string) {
+	_, _ = fmt.Fprintf(os.Stderr, "Error:\t%s\n\n", errorMsg)
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of dbconvert:\n")
+	flag.PrintDefaults()
+
+	os.Exit(2)
+}
@@ -0,0 +1,225 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/runtime/ioutils"
+)
+
+var (
+	// ErrUnsupportedEnginePair is returned if a database can't be converted from the source to the target engine.
+	ErrUnsupportedEnginePair = ierrors.New("unsupported engine pair")
+)
+
+var (
+	// convertibleEngines are the database engines that persist a KVStore which can be converted.
+	convertibleEngines = []Engine{EngineRocksDB}
+	// knownEngines are all database engines that can be found in a "database info file".
+	// The source database is checked against them, so that an unsupported engine pair is reported as such.
+	knownEngines = []Engine{EngineDebug, EngineMapDB, EngineRocksDB, EngineSQLite, EnginePostgreSQL}
+)
+
+const (
+	// convertBatchSize is the amount of entries that are written to the target database in a single batch.
+	convertBatchSize = 100_000
+)
+
+// ConvertProgressFunc is called after every batch written during a conversion with the amount of copied entries so far.
+type ConvertProgressFunc func(copiedEntries uint64)
+
+// Convert copies all data of the database in srcPath to a new database with the given engine in dstPath.
+// The engine of the source database is read from its "database info file" if srcEngine is EngineAuto.
+// Only engines that persist a KVStore in the database folder can be converted, all other engine pairs fail
+// with ErrUnsupportedEnginePair. Only the live entries are copied, so the target database is compacted.
+// The source database is opened in read-only mode and the target folder must not exist or be empty.
+// The data is written to a temporary folder next to the target folder, which is only renamed to the target folder
+// after all data was copied and the amount of entries in both databases was verified.
+// The temporary folder is removed if the conversion fails.
+func Convert(srcPath string, srcEngine Engine, dstPath string, dstEngine Engine, progress ConvertProgressFunc) (err error) {
+	if err := checkEnginePair(srcEngine, dstEngine); err != nil {
+		return err
+	}
+
+	dstExists, err := ioutils.DirExistsAndIsNotEmpty(dstPath)
+	if err != nil {
+		return err
+	}
+	if dstExists {
+		return ierrors.Errorf("target database folder is not empty (%s)", dstPath)
+	}
+
+	srcEngine, srcLock, err := checkEngineAndLock(srcPath, false, srcEngine, append([]Engine{EngineAuto}, knownEngines...), LockShared)
+	if err != nil {
+		return ierrors.Wrap(err, "unable to check source database")
+	}
+
+	// the engine of the source database is only known after its "database info file" was read
+	if err := checkEnginePair(srcEngine, dstEngine); err != nil {
+		return ierrors.Join(err, srcLock.Close())
+	}
+
+	srcStore, err := openKVStore(srcPath, srcEngine, srcLock, true)
+	if err != nil {
+		return ierrors.Wrap(err, "unable to open source database")
+	}
+	defer func() {
+		err = ierrors.Join(err, srcStore.Close())
+	}()
+
+	dstParentPath := filepath.Dir(dstPath)
+	if err := ioutils.CreateDirectory(dstParentPath, 0o700); err != nil {
+		return ierrors.Wrapf(err, "could not create dir '%s'", dstParentPath)
+	}
+
+	tmpPath, err := os.MkdirTemp(dstParentPath, filepath.Base(dstPath)+".tmp")
+	if err != nil {
+		return ierrors.Wrapf(err, "could not create temporary database dir in '%s'", dstParentPath)
+	}
+
+	if err := convertToFolder(srcStore, tmpPath, dstEngine, progress); err != nil {
+		return ierrors.Join(err, os.RemoveAll(tmpPath))
+	}
+
+	// an empty target folder is replaced by the temporary folder
+	if err := os.Remove(dstPath); err != nil && !os.IsNotExist(err) {
+		return ierrors.Join(ierrors.Wrapf(err, "unable to remove empty target database dir '%s'", dstPath), os.RemoveAll(tmpPath))
+	}
+
+	if err := os.Rename(tmpPath, dstPath); err != nil {
+		return ierrors.Join(ierrors.Wrapf(err, "unable to move database to '%s'", dstPath), os.RemoveAll(tmpPath))
+	}
+
+	return nil
+}
+
+// checkEnginePair checks whether a database can be converted from the source to the target engine.
+// A source engine of EngineAuto is accepted, since it is only known after the source database was checked.
+func checkEnginePair(srcEngine Engine, dstEngine Engine) error {
+	_, srcErr := EngineAllowed(srcEngine, append([]Engine{EngineAuto}, convertibleEngines...))
+	_, dstErr := EngineAllowed(dstEngine, convertibleEngines)
+	if srcErr != nil || dstErr != nil {
+		return ierrors.Wrapf(ErrUnsupportedEnginePair, "%s to %s, only databases of the engines %s can be converted",
+			srcEngine, dstEngine, GetSupportedEnginesString(convertibleEngines))
+	}
+
+	return nil
+}
+
+// convertToFolder copies all entries of the source KVStore to a new database with the given engine in the given folder
+// and verifies the result. The "database info file" of the new database is only written after the conversion was verified.
+func convertToFolder(srcStore kvstore.KVStore, dstPath string, dstEngine Engine, progress ConvertProgressFunc) (err error) {
+	dstLock, err := LockDirectory(dstPath, LockExclusive)
+	if err != nil {
+		return ierrors.Wrap(err, "unable to lock target database")
+	}
+
+	dstStore, err := openKVStore(dstPath, dstEngine, dstLock, false)
+	if err != nil {
+		return ierrors.Wrap(err, "unable to open target database")
+	}
+	defer func() {
+		err = ierrors.Join(err, dstStore.Close())
+	}()
+
+	if err := kvstore.CopyBatched(srcStore, newProgressStore(dstStore, progress), convertBatchSize); err != nil {
+		return ierrors.Wrap(err, "unable to copy database entries")
+	}
+
+	srcEntries, err := countEntries(srcStore)
+	if err != nil {
+		return ierrors.Wrap(err, "unable to count source database entries")
+	}
+
+	dstEntries, err := countEntries(dstStore)
+	if err != nil {
+		return ierrors.Wrap(err, "unable to count target database entries")
+	}
+
+	if srcEntries != dstEntries {
+		return ierrors.Errorf("entry count mismatch after conversion: source %d, target %d", srcEntries, dstEntries)
+	}
+
+	return storeDatabaseInfoToFile(databaseInfoFilePath(dstPath), dstEngine)
+}
+
+// countEntries returns the amount of entries in the given KVStore.
+func countEntries(store kvstore.KVStore) (uint64, error) {
+	var count uint64
+	if err := store.IterateKeys(kvstore.EmptyPrefix, func(_ kvstore.Key) bool {
+		count++
+
+		return true
+	}); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// progressStore wraps a KVStore to report the amount of entries committed by batched mutations.
+type progressStore struct {
+	kvstore.KVStore
+
+	progress      ConvertProgressFunc
+	copiedEntries atomic.Uint64
+}
+
+func newProgressStore(store kvstore.KVStore, progress ConvertProgressFunc) *progressStore {
+	return &progressStore{
+		KVStore:  store,
+		progress: progress,
+	}
+}
+
+func (p *progressStore) Batched() (kvstore.BatchedMutations, error) {
+	batchedMutations, err := p.KVStore.Batched()
+	if err != nil {
+		return nil, err
+	}
+
+	return &progressBatchedMutations{
+		BatchedMutations: batchedMutations,
+		store:            p,
+	}, nil
+}
+
+// progressBatchedMutations counts the entries set in a batch and reports them on commit.
+type progressBatchedMutations struct {
+	kvstore.BatchedMutations
+
+	store   *progressStore
+	entries uint64
+}
+
+func (b *progressBatchedMutations) Set(key kvstore.Key, value kvstore.Value) error {
+	if err := b.BatchedMutations.Set(key, value); err != nil {
+		return err
+	}
+	b.entries++
+
+	return nil
+}
+
+func (b *progressBatchedMutations) Cancel() {
+	b.BatchedMutations.Cancel()
+	b.entries = 0
+}
+
+func (b *progressBatchedMutations) Commit() error {
+	if err := b.BatchedMutations.Commit(); err != nil {
+		return err
+	}
+
+	copiedEntries := b.store.copiedEntries.Add(b.entries)
+	b.entries = 0
+
+	if b.store.progress != nil {
+		b.store.progress(copiedEntries)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+//go:build rocksdb
+
+package db_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/db"
+	"github.com/iotaledger/hive.go/kvstore/rocksdb"
+)
+
+func TestConvert(t *testing.T) {
+	srcPath := filepath.Join(t.TempDir(), "src")
+	dstParentPath := t.TempDir()
+	dstPath := filepath.Join(dstParentPath, "dst")
+	allowedEngines := []db.Engine{db.EngineAuto, db.EngineRocksDB}
+
+	engine, lock, err := db.CheckEngineAndLock(srcPath, true, db.EngineRocksDB, allowedEngines)
+	require.NoError(t, err)
+	require.Equal(t, db.EngineRocksDB, engine)
+
+	instance, err := rocksdb.CreateDB(srcPath)
+	require.NoError(t, err)
+
+	srcStore := rocksdb.New(instance)
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, srcStore.Set([]byte(fmt.Sprintf("key%04d", i)), []byte(fmt.Sprintf("value%04d", i))))
+	}
+	require.NoError(t, srcStore.DeletePrefix([]byte("key09")))
+	require.NoError(t, srcStore.Flush())
+
+	// the source database is still in use
+	require.ErrorIs(t, db.Convert(srcPath, db.EngineAuto, dstPath, db.EngineRocksDB, nil), db.ErrDatabaseLocked)
+	require.NoDirExists(t, dstPath)
+
+	require.NoError(t, srcStore.Close())
+	require.NoError(t, lock.Close())
+
+	var copiedEntries uint64
+	require.NoError(t, db.Convert(srcPath, db.EngineRocksDB, dstPath, db.EngineRocksDB, func(entries uint64) {
+		copiedEntries = entries
+	}))
+	require.EqualValues(t, 900, copiedEntries)
+
+	// the temporary folder was renamed to the target folder
+	dirEntries, err := os.ReadDir(dstParentPath)
+	require.NoError(t, err)
+	require.Len(t, dirEntries, 1)
+
+	engine, lock, err = db.CheckEngineAndLock(dstPath, false, db.EngineAuto, allowedEngines)
+	require.NoError(t, err)
+	require.Equal(t, db.EngineRocksDB, engine)
+	require.NoError(t, lock.Close())
+
+	instance, err = rocksdb.OpenDBReadOnly(dstPath)
+	require.NoError(t, err)
+
+	dstStore := rocksdb.New(instance)
+	value, err := dstStore.Get([]byte("key0042"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value0042"), value)
+	require.NoError(t, dstStore.Close())
+
+	// the target database folder must be empty
+	require.Error(t, db.Convert(srcPath, db.EngineAuto, dstPath, db.EngineRocksDB, nil))
+
+	// an empty target folder is replaced
+	emptyDstPath := filepath.Join(dstParentPath, "empty")
+	require.NoError(t, os.Mkdir(emptyDstPath, 0o700))
+	require.NoError(t, db.Convert(srcPath, db.EngineAuto, emptyDstPath, db.EngineRocksDB, nil))
+	require.FileExists(t, filepath.Join(emptyDstPath, "dbinfo"))
+}
+
+func TestConvertUnsupportedEnginePair(t *testing.T) {
+	srcPath := t.TempDir()
+	dstParentPath := t.TempDir()
+	dstPath := filepath.Join(dstParentPath, "dst")
+
+	// only engines that persist a KVStore can be converted
+	_, lock, err := db.CheckEngineAndLock(srcPath, true, db.EngineSQLite, []db.Engine{db.EngineSQLite})
+	require.NoError(t, err)
+	require.NoError(t, lock.Close())
+
+	require.ErrorIs(t, db.Convert(srcPath, db.EngineAuto, dstPath, db.EngineRocksDB, nil), db.ErrUnsupportedEnginePair)
+	require.ErrorIs(t, db.Convert(srcPath, db.EngineSQLite, dstPath, db.EngineRocksDB, nil), db.ErrUnsupportedEnginePair)
+	require.ErrorIs(t, db.Convert(srcPath, db.EngineRocksDB, dstPath, db.EngineMapDB, nil), db.ErrUnsupportedEnginePair)
+
+	// the source database is not mistaken for a database of another engine
+	require.ErrorIs(t, db.Convert(srcPath, db.EngineRocksDB, dstPath, db.EngineRocksDB, nil), db.ErrEngineMismatch)
+
+	dirEntries, err := os.ReadDir(dstParentPath)
+	require.NoError(t, err)
+	require.Empty(t, dirEntries)
+
+	// the source database is not locked after a failed conversion
+	lock, err = db.LockDirectory(srcPath, db.LockExclusive)
+	require.NoError(t, err)
+	require.NoError(t, lock.Close())
+}
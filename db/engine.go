@@ -72,21 +72,42 @@ func EngineFromStringAllowed(dbEngineStr string, allowedEngines []Engine) (Engin
 // This function stores a so called "database info file" in the database folder or
 // checks if an existing "database info file" contains the correct engine.
 // Otherwise the files in the database folder are not compatible.
+// The database folder is locked exclusively while the "database info file" is accessed, so the check fails
+// with ErrDatabaseLocked if another process holds the lock, see CheckEngineAndLock.
 func CheckEngine(dbPath string, createDatabaseIfNotExists bool, dbEngine Engine, allowedEngines []Engine) (Engine, error) {
+	targetEngine, lock, err := CheckEngineAndLock(dbPath, createDatabaseIfNotExists, dbEngine, allowedEngines)
+	if err != nil {
+		return targetEngine, err
+	}
+
+	return targetEngine, lock.Close()
+}
+
+// CheckEngineAndLock checks if the correct database engine is used like CheckEngine.
+// Before the "database info file" is accessed, an exclusive advisory lock is taken on the database folder
+// to prevent other processes from using it at the same time. The returned lock must be closed after the database was closed.
+// The lock is nil if the database engine does not use the file system.
+func CheckEngineAndLock(dbPath string, createDatabaseIfNotExists bool, dbEngine Engine, allowedEngines []Engine) (Engine, *Lock, error) {
+	return checkEngineAndLock(dbPath, createDatabaseIfNotExists, dbEngine, allowedEngines, LockExclusive)
+}
+
+// checkEngineAndLock locks the database folder with the given mode and checks if the correct database engine is used.
+// The "database info file" is only created if the database folder is locked exclusively.
+func checkEngineAndLock(dbPath string, createDatabaseIfNotExists bool, dbEngine Engine, allowedEngines []Engine, lockMode LockMode) (Engine, *Lock, error) {
 	// check if the given target engine is allowed
 	_, err := EngineAllowed(dbEngine, allowedEngines)
 	if err != nil {
-		return EngineUnknown, err
+		return EngineUnknown, nil, err
 	}
 
 	switch dbEngine {
 	case EngineUnknown:
-		return dbEngine, ierrors.New("the database engine must not be EngineUnknown")
+		return dbEngine, nil, ierrors.New("the database engine must not be EngineUnknown")
 
 		// TODO: add an interface with a flag that indicates if the database needs the file system or not.
 	case EngineMapDB, EnginePostgreSQL:
 		// no need to create or access a "database info file" in case of mapdb (in-memory) or postgres (external database)
-		return dbEngine, nil
+		return dbEngine, nil, nil
 	}
 
 	dbEngineSpecified := dbEngine != EngineAuto
@@ -94,25 +115,44 @@ func CheckEngine(dbPath string, createDatabaseIfNotExists bool, dbEngine Engine,
 	// check if the database exists and if it should be created
 	dbExists, err := ioutils.DirExistsAndIsNotEmpty(dbPath)
 	if err != nil {
-		return EngineUnknown, err
+		return EngineUnknown, nil, err
 	}
 
 	if !dbExists {
 		if !createDatabaseIfNotExists {
-			return EngineUnknown, ierrors.Errorf("database not found (%s)", dbPath)
+			return EngineUnknown, nil, ierrors.Errorf("database not found (%s)", dbPath)
 		}
 
 		if createDatabaseIfNotExists && !dbEngineSpecified {
-			return EngineUnknown, ierrors.New("the database engine must be specified if the database should be newly created")
+			return EngineUnknown, nil, ierrors.New("the database engine must be specified if the database should be newly created")
+		}
+
+		if err := ioutils.CreateDirectory(dbPath, 0700); err != nil {
+			return EngineUnknown, nil, ierrors.Wrapf(err, "could not create database dir '%s'", dbPath)
 		}
 	}
 
-	var targetEngine Engine
+	lock, err := LockDirectory(dbPath, lockMode)
+	if err != nil {
+		return EngineUnknown, nil, err
+	}
 
-	// check if the database info file exists and if it should be created
-	dbInfoFilePath := filepath.Join(dbPath, "dbinfo")
-	_, err = os.Stat(dbInfoFilePath)
+	targetEngine, err := checkDatabaseInfo(dbPath, dbEngine, allowedEngines, lockMode == LockExclusive)
 	if err != nil {
+		return targetEngine, nil, ierrors.Join(err, lock.Close())
+	}
+
+	return targetEngine, lock, nil
+}
+
+// checkDatabaseInfo checks the engine in the "database info file" of the locked database folder.
+// The file is created if it does not exist yet, the engine is specified and the file is allowed to be written.
+func checkDatabaseInfo(dbPath string, dbEngine Engine, allowedEngines []Engine, writeAllowed bool) (Engine, error) {
+	dbEngineSpecified := dbEngine != EngineAuto
+
+	// check if the database info file exists and if it should be created
+	dbInfoFilePath := databaseInfoFilePath(dbPath)
+	if _, err := os.Stat(dbInfoFilePath); err != nil {
 		if !os.IsNotExist(err) {
 			return EngineUnknown, ierrors.Wrapf(err, "unable to check database info file (%s)", dbInfoFilePath)
 		}
@@ -122,26 +162,31 @@ func CheckEngine(dbPath string, createDatabaseIfNotExists bool, dbEngine Engine,
 		}
 
 		// if the dbInfo file does not exist and the dbEngine is given, create the dbInfo file.
-		if err := storeDatabaseInfoToFile(dbInfoFilePath, dbEngine); err != nil {
-			return EngineUnknown, err
+		if writeAllowed {
+			if err := storeDatabaseInfoToFile(dbInfoFilePath, dbEngine); err != nil {
+				return EngineUnknown, err
+			}
 		}
 
-		targetEngine = dbEngine
-	} else {
-		dbEngineFromInfoFile, err := LoadEngineFromFile(dbInfoFilePath, allowedEngines)
-		if err != nil {
-			return EngineUnknown, err
-		}
+		return dbEngine, nil
+	}
 
-		// if the dbInfo file exists and the dbEngine is given, compare the engines.
-		if dbEngineSpecified && dbEngineFromInfoFile != dbEngine {
-			return dbEngineFromInfoFile, ErrEngineMismatch
-		}
+	dbEngineFromInfoFile, err := LoadEngineFromFile(dbInfoFilePath, allowedEngines)
+	if err != nil {
+		return EngineUnknown, err
+	}
 
-		targetEngine = dbEngineFromInfoFile
+	// if the dbInfo file exists and the dbEngine is given, compare the engines.
+	if dbEngineSpecified && dbEngineFromInfoFile != dbEngine {
+		return dbEngineFromInfoFile, ErrEngineMismatch
 	}
 
-	return targetEngine, nil
+	return dbEngineFromInfoFile, nil
+}
+
+// databaseInfoFilePath returns the path of the "database info file" in the database folder.
+func databaseInfoFilePath(dbPath string) string {
+	return filepath.Join(dbPath, "dbinfo")
 }
 
 // LoadEngineFromFile returns the engine from the "database info file".
@@ -4,10 +4,23 @@ go 1.22.0
 
 require (
 	github.com/iotaledger/hive.go/ierrors v0.0.0-20240315104458-b689cbcfddbd
+	github.com/iotaledger/hive.go/kvstore v0.0.0-20240315104458-b689cbcfddbd
 	github.com/iotaledger/hive.go/runtime v0.0.0-20240315104458-b689cbcfddbd
+	github.com/stretchr/testify v1.9.0
 )
 
 require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/iotaledger/grocksdb v1.7.5-0.20230220105546-5162e18885c7 // indirect
+	github.com/iotaledger/hive.go/constraints v0.0.0-20240315104458-b689cbcfddbd // indirect
+	github.com/iotaledger/hive.go/ds v0.0.0-20240315104458-b689cbcfddbd // indirect
+	github.com/iotaledger/hive.go/lo v0.0.0-20240315104458-b689cbcfddbd // indirect
+	github.com/iotaledger/hive.go/serializer/v2 v2.0.0-rc.1.0.20240223135320-81de52dfbf66 // indirect
+	github.com/iotaledger/hive.go/stringify v0.0.0-20240315104458-b689cbcfddbd // indirect
+	github.com/kr/text v0.2.0 // indirect
 	github.com/pelletier/go-toml/v2 v2.2.0 // indirect
+	github.com/petermattis/goid v0.0.0-20231207134359-e60b3f734c67 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/sasha-s/go-deadlock v0.3.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
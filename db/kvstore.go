@@ -0,0 +1,41 @@
+package db
+
+import (
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+// openKVStore opens the KVStore of the given engine in the locked database folder.
+// The lock is handed over to the KVStore and released after the KVStore was closed,
+// it is also released if the KVStore can't be opened.
+// Read-only KVStores can be opened with a shared lock, all others need an exclusive lock.
+func openKVStore(dbPath string, dbEngine Engine, lock *Lock, readOnly bool) (kvstore.KVStore, error) {
+	if lock == nil {
+		return nil, ierrors.Errorf("database folder (%s) must be locked before it is opened", dbPath)
+	}
+
+	if !readOnly && lock.mode != LockExclusive {
+		return nil, ierrors.Join(ierrors.Errorf("database folder (%s) must be locked exclusively to be written", dbPath), lock.Close())
+	}
+
+	store, err := newKVStore(dbPath, dbEngine, readOnly)
+	if err != nil {
+		return nil, ierrors.Join(err, lock.Close())
+	}
+
+	return &lockedKVStore{
+		KVStore: store,
+		lock:    lock,
+	}, nil
+}
+
+// lockedKVStore is a KVStore that releases the lock on its database folder when it is closed.
+type lockedKVStore struct {
+	kvstore.KVStore
+
+	lock *Lock
+}
+
+func (s *lockedKVStore) Close() error {
+	return ierrors.Join(s.KVStore.Close(), s.lock.Close())
+}
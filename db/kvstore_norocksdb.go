@@ -0,0 +1,13 @@
+//go:build !rocksdb
+
+package db
+
+import (
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+// newKVStore opens the KVStore of the given engine in the database folder.
+func newKVStore(_ string, dbEngine Engine, _ bool) (kvstore.KVStore, error) {
+	return nil, ierrors.Errorf("database engine %s is not supported for conversion, build with the rocksdb tag", dbEngine)
+}
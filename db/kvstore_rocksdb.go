@@ -0,0 +1,32 @@
+//go:build rocksdb
+
+package db
+
+import (
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/rocksdb"
+)
+
+// newKVStore opens the KVStore of the given engine in the database folder.
+func newKVStore(dbPath string, dbEngine Engine, readOnly bool) (kvstore.KVStore, error) {
+	//nolint:exhaustive // only engines that persist a KVStore are supported
+	switch dbEngine {
+	case EngineRocksDB:
+		var instance *rocksdb.RocksDB
+		var err error
+		if readOnly {
+			instance, err = rocksdb.OpenDBReadOnly(dbPath)
+		} else {
+			instance, err = rocksdb.CreateDB(dbPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return rocksdb.New(instance), nil
+
+	default:
+		return nil, ierrors.Errorf("database engine %s is not supported for conversion", dbEngine)
+	}
+}
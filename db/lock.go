@@ -0,0 +1,152 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// lockFileName is the name of the lock file in the database folder.
+	lockFileName = "dblock"
+)
+
+var (
+	// ErrDatabaseLocked is returned if the database folder is already locked by another process.
+	ErrDatabaseLocked = ierrors.New("database is locked by another process")
+)
+
+// LockMode defines how a database folder is locked.
+type LockMode byte
+
+const (
+	// LockExclusive is used by processes that write to the database.
+	// Only a single process can hold an exclusive lock and no shared locks can be held at the same time.
+	LockExclusive LockMode = iota
+	// LockShared is used by tools that open the database in read-only mode.
+	// Several processes can hold a shared lock at the same time.
+	LockShared
+)
+
+// Lock is an advisory lock on a database folder that is held until Close is called
+// or the process exits.
+type Lock struct {
+	file *os.File
+	mode LockMode
+}
+
+// LockDirectory takes an advisory lock on the given database folder.
+// It returns an error wrapping ErrDatabaseLocked, which names the PID of the process
+// holding the lock, if the lock can't be acquired immediately.
+func LockDirectory(dbPath string, mode LockMode) (*Lock, error) {
+	lockFilePath := filepath.Join(dbPath, lockFileName)
+
+	file, err := os.OpenFile(lockFilePath, os.O_RDWR|os.O_CREATE, 0o660)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to open database lock file (%s)", lockFilePath)
+	}
+
+	locked, err := lockFile(file, mode == LockExclusive)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, ierrors.Wrapf(err, "unable to lock database lock file (%s)", lockFilePath)
+	}
+
+	if !locked {
+		pid := readLockOwner(file, mode)
+		_ = file.Close()
+
+		if pid == 0 {
+			return nil, ierrors.Wrapf(ErrDatabaseLocked, "database folder (%s) is in use", dbPath)
+		}
+
+		return nil, ierrors.Wrapf(ErrDatabaseLocked, "database folder (%s) is in use by process with PID %d", dbPath, pid)
+	}
+
+	// only the exclusive owner stores its PID, shared owners would overwrite each other.
+	if mode == LockExclusive {
+		if err := writeLockOwner(file); err != nil {
+			_ = unlockFile(file)
+			_ = file.Close()
+
+			return nil, ierrors.Wrapf(err, "unable to write database lock file (%s)", lockFilePath)
+		}
+	}
+
+	return &Lock{file: file, mode: mode}, nil
+}
+
+// Close releases the lock. It is safe to call Close on a nil Lock.
+func (l *Lock) Close() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+
+	defer func() {
+		l.file = nil
+	}()
+
+	// the PID of the exclusive owner is cleared, so it is not reported after the lock was released.
+	var clearErr error
+	if l.mode == LockExclusive {
+		if err := l.file.Truncate(0); err != nil {
+			clearErr = ierrors.Wrap(err, "unable to clear database lock file")
+		}
+	}
+
+	if err := unlockFile(l.file); err != nil {
+		_ = l.file.Close()
+
+		return ierrors.Join(clearErr, ierrors.Wrap(err, "unable to unlock database lock file"))
+	}
+
+	return ierrors.Join(clearErr, l.file.Close())
+}
+
+// readLockOwner returns the PID of the process holding an exclusive lock on the lock file or 0 if it is unknown.
+// The failed lock mode tells whether the lock file is held exclusively, only shared owners don't block a shared lock.
+func readLockOwner(file *os.File, failedMode LockMode) int {
+	if failedMode == LockExclusive {
+		// the lock file might only be held by shared owners, which don't store their PID.
+		locked, err := lockFile(file, false)
+		if err != nil {
+			return 0
+		}
+		if locked {
+			_ = unlockFile(file)
+
+			return 0
+		}
+	}
+
+	content := make([]byte, 32)
+
+	n, err := file.ReadAt(content, 0)
+	if n == 0 && err != nil {
+		return 0
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content[:n])))
+	if err != nil {
+		return 0
+	}
+
+	return pid
+}
+
+// writeLockOwner stores the PID of the current process in the lock file.
+func writeLockOwner(file *os.File) error {
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err := file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0); err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
@@ -0,0 +1,124 @@
+package db_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/db"
+)
+
+func TestLockDirectory(t *testing.T) {
+	dbPath := t.TempDir()
+
+	lock, err := db.LockDirectory(dbPath, db.LockExclusive)
+	require.NoError(t, err)
+
+	_, err = db.LockDirectory(dbPath, db.LockExclusive)
+	require.ErrorIs(t, err, db.ErrDatabaseLocked)
+	require.ErrorContains(t, err, fmt.Sprintf("PID %d", os.Getpid()))
+
+	_, err = db.LockDirectory(dbPath, db.LockShared)
+	require.ErrorIs(t, err, db.ErrDatabaseLocked)
+
+	require.NoError(t, lock.Close())
+	require.NoError(t, lock.Close())
+
+	sharedLock1, err := db.LockDirectory(dbPath, db.LockShared)
+	require.NoError(t, err)
+
+	sharedLock2, err := db.LockDirectory(dbPath, db.LockShared)
+	require.NoError(t, err)
+
+	_, err = db.LockDirectory(dbPath, db.LockExclusive)
+	require.ErrorIs(t, err, db.ErrDatabaseLocked)
+
+	require.NoError(t, sharedLock1.Close())
+	require.NoError(t, sharedLock2.Close())
+
+	lock, err = db.LockDirectory(dbPath, db.LockExclusive)
+	require.NoError(t, err)
+	require.NoError(t, lock.Close())
+}
+
+func TestLockOwner(t *testing.T) {
+	dbPath := t.TempDir()
+
+	sharedLock, err := db.LockDirectory(dbPath, db.LockShared)
+	require.NoError(t, err)
+
+	// shared owners don't store their PID
+	_, err = db.LockDirectory(dbPath, db.LockExclusive)
+	require.ErrorIs(t, err, db.ErrDatabaseLocked)
+	require.NotContains(t, err.Error(), "PID")
+	require.NoError(t, sharedLock.Close())
+
+	lock, err := db.LockDirectory(dbPath, db.LockExclusive)
+	require.NoError(t, err)
+	require.NoError(t, lock.Close())
+
+	// the PID of the former exclusive owner is not reported for shared owners
+	sharedLock, err = db.LockDirectory(dbPath, db.LockShared)
+	require.NoError(t, err)
+
+	_, err = db.LockDirectory(dbPath, db.LockExclusive)
+	require.ErrorIs(t, err, db.ErrDatabaseLocked)
+	require.NotContains(t, err.Error(), "PID")
+	require.NoError(t, sharedLock.Close())
+}
+
+func TestCheckEngine(t *testing.T) {
+	dbPath := t.TempDir()
+	allowedEngines := []db.Engine{db.EngineAuto, db.EngineRocksDB, db.EngineMapDB}
+
+	engine, lock, err := db.CheckEngineAndLock(dbPath, true, db.EngineRocksDB, allowedEngines)
+	require.NoError(t, err)
+	require.Equal(t, db.EngineRocksDB, engine)
+
+	// the lock is held until it is closed
+	_, _, err = db.CheckEngineAndLock(dbPath, false, db.EngineAuto, allowedEngines)
+	require.ErrorIs(t, err, db.ErrDatabaseLocked)
+	require.ErrorContains(t, err, fmt.Sprintf("PID %d", os.Getpid()))
+
+	_, err = db.LockDirectory(dbPath, db.LockShared)
+	require.ErrorIs(t, err, db.ErrDatabaseLocked)
+
+	require.NoError(t, lock.Close())
+
+	engine, lock, err = db.CheckEngineAndLock(dbPath, false, db.EngineAuto, allowedEngines)
+	require.NoError(t, err)
+	require.Equal(t, db.EngineRocksDB, engine)
+	require.NoError(t, lock.Close())
+
+	// the database info file is not touched if the database folder is locked
+	newDBPath := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, os.Mkdir(newDBPath, 0o700))
+	lock, err = db.LockDirectory(newDBPath, db.LockExclusive)
+	require.NoError(t, err)
+
+	_, _, err = db.CheckEngineAndLock(newDBPath, true, db.EngineRocksDB, allowedEngines)
+	require.ErrorIs(t, err, db.ErrDatabaseLocked)
+	require.NoFileExists(t, filepath.Join(newDBPath, "dbinfo"))
+	require.NoError(t, lock.Close())
+
+	// in-memory databases are not locked
+	engine, lock, err = db.CheckEngineAndLock(dbPath, false, db.EngineMapDB, allowedEngines)
+	require.NoError(t, err)
+	require.Equal(t, db.EngineMapDB, engine)
+	require.Nil(t, lock)
+	require.NoError(t, lock.Close())
+
+	// CheckEngine only holds the lock during the check
+	engine, err = db.CheckEngine(dbPath, false, db.EngineAuto, allowedEngines)
+	require.NoError(t, err)
+	require.Equal(t, db.EngineRocksDB, engine)
+
+	lock, err = db.LockDirectory(dbPath, db.LockExclusive)
+	require.NoError(t, err)
+	_, err = db.CheckEngine(dbPath, false, db.EngineAuto, allowedEngines)
+	require.ErrorIs(t, err, db.ErrDatabaseLocked)
+	require.NoError(t, lock.Close())
+}
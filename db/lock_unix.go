@@ -0,0 +1,34 @@
+//go:build unix
+
+package db
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// lockFile tries to lock the given file without blocking.
+// It returns false if the file is already locked by another process.
+func lockFile(file *os.File, exclusive bool) (bool, error) {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+
+	if err := syscall.Flock(int(file.Fd()), how|syscall.LOCK_NB); err != nil {
+		if ierrors.Is(err, syscall.EWOULDBLOCK) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// unlockFile releases the lock on the given file.
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,56 @@
+//go:build windows
+
+package db
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	lockfileFailImmediately = 0x00000001
+	lockfileExclusiveLock   = 0x00000002
+
+	errorLockViolation syscall.Errno = 33
+)
+
+var (
+	modkernel32      = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = modkernel32.NewProc("LockFileEx")
+	procUnlockFileEx = modkernel32.NewProc("UnlockFileEx")
+)
+
+// lockFile tries to lock the given file without blocking.
+// It returns false if the file is already locked by another process.
+func lockFile(file *os.File, exclusive bool) (bool, error) {
+	flags := uint32(lockfileFailImmediately)
+	if exclusive {
+		flags |= lockfileExclusiveLock
+	}
+
+	var overlapped syscall.Overlapped
+	r1, _, err := procLockFileEx.Call(file.Fd(), uintptr(flags), 0, 1, 0, uintptr(unsafe.Pointer(&overlapped)))
+	if r1 == 0 {
+		if ierrors.Is(err, errorLockViolation) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// unlockFile releases the lock on the given file.
+func unlockFile(file *os.File) error {
+	var overlapped syscall.Overlapped
+	r1, _, err := procUnlockFileEx.Call(file.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(&overlapped)))
+	if r1 == 0 {
+		return err
+	}
+
+	return nil
+}
@@ -1,43 +1,37 @@
 package kvstore
 
-import (
-	"sync/atomic"
-)
-
 // BatchCollector is used to collect objects that should be written.
 type BatchCollector struct {
 	batchedMuts          BatchedMutations
-	scheduledCount       *atomic.Int32
 	batchSize            int
 	writtenValues        []BatchWriteObject
+	results              []*BatchWriteResult
 	writtenValuesCounter int
 	committed            bool
 }
 
 // newBatchCollector creates a new BatchCollector that is used to collect objects that should be written.
-func newBatchCollector(batchedMuts BatchedMutations, scheduledCount *atomic.Int32, batchSize int) *BatchCollector {
+func newBatchCollector(batchedMuts BatchedMutations, batchSize int) *BatchCollector {
 	return &BatchCollector{
 		batchedMuts:          batchedMuts,
-		scheduledCount:       scheduledCount,
 		batchSize:            batchSize,
 		writtenValues:        make([]BatchWriteObject, batchSize),
+		results:              make([]*BatchWriteResult, batchSize),
 		writtenValuesCounter: 0,
 		committed:            false,
 	}
 }
 
-// Add adds an object to the batch.
+// Add adds an object and the result of its BatchWrite (nil if nobody asked for it) to the batch.
 // It returns true in case the batch size is reached.
-func (br *BatchCollector) Add(objectToPersist BatchWriteObject) (batchSizeReached bool) {
+func (br *BatchCollector) Add(objectToPersist BatchWriteObject, result *BatchWriteResult) (batchSizeReached bool) {
 	if br.committed {
 		panic("mutations were already committed")
 	}
 
-	objectToPersist.ResetBatchWriteScheduled()
-	br.scheduledCount.Add(-1)
-
 	objectToPersist.BatchWrite(br.batchedMuts)
 	br.writtenValues[br.writtenValuesCounter] = objectToPersist
+	br.results[br.writtenValuesCounter] = result
 	br.writtenValuesCounter++
 
 	return br.writtenValuesCounter >= br.batchSize
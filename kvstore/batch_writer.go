@@ -5,10 +5,18 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/iotaledger/hive.go/ierrors"
 	"github.com/iotaledger/hive.go/runtime/syncutils"
 	"github.com/iotaledger/hive.go/runtime/timeutil"
 )
 
+var (
+	// ErrQueueFull is returned if an object can not be enqueued without blocking because the batch queue is full.
+	ErrQueueFull = ierrors.New("batch queue is full")
+	// ErrBatchedWriterStopped is returned if an object is enqueued after the BatchedWriter was stopped.
+	ErrBatchedWriterStopped = ierrors.New("batched writer was stopped")
+)
+
 // BatchWriteObject is an object that can be persisted to the KVStore in batches using the BatchedWriter.
 type BatchWriteObject interface {
 	// BatchWrite mashalls the object and adds it to the BatchedMutations.
@@ -26,6 +34,9 @@ var defaultOptions = []Option{
 	WithQueueSize(10000),
 	WithBatchSize(10000),
 	WithBatchTimeout(500 * time.Millisecond),
+	WithCommitErrorHandler(func(err error) {
+		panic(err)
+	}),
 }
 
 // Options define options for the BatchedWriter.
@@ -36,6 +47,8 @@ type Options struct {
 	batchSize int
 	// the timeout for collecting elements for the batch.
 	batchTimeout time.Duration
+	// the handler for the errors of batches that contain objects without a result.
+	commitErrorHandler func(err error)
 }
 
 // applies the given Option.
@@ -66,9 +79,129 @@ func WithBatchTimeout(batchTimeout time.Duration) Option {
 	}
 }
 
+// WithCommitErrorHandler defines the handler that is called if a batch fails to be committed that contains objects
+// that were enqueued without a result (see Enqueue). The default handler panics.
+func WithCommitErrorHandler(handler func(err error)) Option {
+	return func(opts *Options) {
+		opts.commitErrorHandler = handler
+	}
+}
+
 // Option is a function setting a BatchedWriter option.
 type Option func(opts *Options)
 
+// BatchWriteResult is the result of the BatchWrite of an enqueued object.
+type BatchWriteResult struct {
+	done chan struct{}
+	err  error
+	once sync.Once
+}
+
+// newBatchWriteResult creates a new pending BatchWriteResult.
+func newBatchWriteResult() *BatchWriteResult {
+	return &BatchWriteResult{
+		done: make(chan struct{}),
+	}
+}
+
+// Done returns a channel that is closed when the object was persisted or failed to be persisted.
+func (r *BatchWriteResult) Done() <-chan struct{} {
+	return r.done
+}
+
+// Err returns the error of the BatchWrite (nil if the object was persisted or the result is still pending).
+func (r *BatchWriteResult) Err() error {
+	select {
+	case <-r.done:
+		return r.err
+	default:
+		return nil
+	}
+}
+
+// Wait waits until the object was persisted and returns the error of the BatchWrite.
+func (r *BatchWriteResult) Wait() error {
+	<-r.done
+
+	return r.err
+}
+
+// OnDone registers a callback that is called with the error of the BatchWrite when the result is available.
+func (r *BatchWriteResult) OnDone(callback func(err error)) {
+	go func() {
+		callback(r.Wait())
+	}()
+}
+
+// resolvedWith resolves the result with the given error and returns it (nil if the result is nil).
+func (r *BatchWriteResult) resolvedWith(err error) *BatchWriteResult {
+	if r != nil {
+		r.resolve(err)
+	}
+
+	return r
+}
+
+// resolve sets the error of the BatchWrite and marks the result as done.
+func (r *BatchWriteResult) resolve(err error) {
+	r.once.Do(func() {
+		r.err = err
+		close(r.done)
+	})
+}
+
+// BatchedWriterStats contains statistics about a BatchedWriter.
+type BatchedWriterStats struct {
+	// QueueLength is the number of objects that are waiting in the batch queue.
+	QueueLength int
+	// CommittedBatches is the number of batches that were committed.
+	CommittedBatches uint64
+	// CommittedObjects is the number of objects that were committed.
+	CommittedObjects uint64
+	// FailedBatches is the number of batches that failed to be committed.
+	FailedBatches uint64
+	// FailedObjects is the number of objects that failed to be committed.
+	FailedObjects uint64
+	// LastBatchSize is the number of objects of the last batch.
+	LastBatchSize int
+	// MaxBatchSize is the largest number of objects of a batch.
+	MaxBatchSize int
+	// LastCommitLatency is the time it took to commit the last batch.
+	LastCommitLatency time.Duration
+	// MaxCommitLatency is the longest time it took to commit a batch.
+	MaxCommitLatency time.Duration
+	// TotalCommitLatency is the time it took to commit all batches.
+	TotalCommitLatency time.Duration
+}
+
+// AverageBatchSize returns the average number of objects per batch.
+func (s BatchedWriterStats) AverageBatchSize() float64 {
+	if batches := s.CommittedBatches + s.FailedBatches; batches != 0 {
+		return float64(s.CommittedObjects+s.FailedObjects) / float64(batches)
+	}
+
+	return 0
+}
+
+// AverageCommitLatency returns the average time it took to commit a batch.
+func (s BatchedWriterStats) AverageCommitLatency() time.Duration {
+	if batches := s.CommittedBatches + s.FailedBatches; batches != 0 {
+		return s.TotalCommitLatency / time.Duration(batches)
+	}
+
+	return 0
+}
+
+// batchQueueEntry is an element of the batch queue.
+type batchQueueEntry struct {
+	// object is the object that is written (nil if the entry only waits for the earlier objects).
+	object BatchWriteObject
+	// result is the result of the BatchWrite (nil if nobody asked for it).
+	result *BatchWriteResult
+	// failedBatches is the number of failed batches when an entry without an object was enqueued.
+	failedBatches uint64
+}
+
 // BatchedWriter persists BatchWriteObjects in batches to a KVStore.
 type BatchedWriter struct {
 	store          KVStore
@@ -77,9 +210,20 @@ type BatchedWriter struct {
 	autoStartOnce  sync.Once
 	running        atomic.Bool
 	scheduledCount atomic.Int32
-	batchQueue     chan BatchWriteObject
+	batchQueue     chan batchQueueEntry
 	flushChan      chan struct{}
 	opts           *Options
+
+	// waiters contains the entries without an object that were taken from the queue since the last commit.
+	// It is only accessed by the batch writer.
+	waiters []batchQueueEntry
+	// failedBatches is the number of batches that failed to be committed.
+	failedBatches atomic.Uint64
+
+	statsMutex syncutils.RWMutex
+	stats      BatchedWriterStats
+	// lastCommitErr is the error of the last batch that failed to be committed.
+	lastCommitErr error
 }
 
 // NewBatchedWriter creates a new BatchedWriter instance.
@@ -92,7 +236,7 @@ func NewBatchedWriter(store KVStore, opts ...Option) *BatchedWriter {
 		store:          store,
 		writeWg:        sync.WaitGroup{},
 		startStopMutex: syncutils.Mutex{},
-		batchQueue:     make(chan BatchWriteObject, options.queueSize),
+		batchQueue:     make(chan batchQueueEntry, options.queueSize),
 		flushChan:      make(chan struct{}, 1), // must be buffered with size 1 since no receiver is actively waiting.
 		opts:           options,
 	}
@@ -119,43 +263,235 @@ func (bw *BatchedWriter) StopBatchWriter() {
 	if bw.running.Load() {
 		bw.running.Store(false)
 
+		// do not wait for the batch timeout
+		bw.triggerFlush()
+
 		bw.writeWg.Wait()
 	}
 	bw.startStopMutex.Unlock()
 }
 
 // Enqueue adds a BatchWriteObject to the write queue.
+// Errors of the BatchWrite are passed to the commit error handler (see WithCommitErrorHandler), use
+// EnqueueWithResult to handle them instead.
 // It also starts the batch writer if not done yet.
 func (bw *BatchedWriter) Enqueue(object BatchWriteObject) {
+	bw.enqueue(object, false, true)
+}
+
+// EnqueueWithResult adds a BatchWriteObject to the write queue and returns the result of its BatchWrite.
+// If the object is already queued, the returned result is done once the queued BatchWrite was committed.
+// It also starts the batch writer if not done yet.
+func (bw *BatchedWriter) EnqueueWithResult(object BatchWriteObject) *BatchWriteResult {
+	result, _ := bw.enqueue(object, true, true)
+
+	return result
+}
+
+// TryEnqueue adds a BatchWriteObject to the write queue without blocking and returns the result of its BatchWrite.
+// It returns ErrQueueFull if the queue is full.
+// It also starts the batch writer if not done yet.
+func (bw *BatchedWriter) TryEnqueue(object BatchWriteObject) (*BatchWriteResult, error) {
+	return bw.enqueue(object, true, false)
+}
+
+// FlushAndWaitAll flushes the queued objects and waits until all objects that were enqueued before were committed.
+// It returns the error of the last batch that failed to be committed in the meantime, use EnqueueWithResult to wait
+// for a specific object. If the batch writer was stopped, it returns the error of the last batch that failed to be
+// committed at all.
+func (bw *BatchedWriter) FlushAndWaitAll() error {
+	result := newBatchWriteResult()
+	if err := bw.enqueueWaiter(result, true); err != nil {
+		// wait until the stopped batch writer committed all objects
+		bw.startStopMutex.Lock()
+		defer bw.startStopMutex.Unlock()
+
+		return bw.lastCommitError()
+	}
+
+	bw.Flush()
+
+	return result.Wait()
+}
+
+// Stats returns the statistics of the BatchedWriter.
+func (bw *BatchedWriter) Stats() BatchedWriterStats {
+	bw.statsMutex.RLock()
+	defer bw.statsMutex.RUnlock()
+
+	stats := bw.stats
+	stats.QueueLength = len(bw.batchQueue)
+
+	return stats
+}
+
+// enqueue adds a BatchWriteObject to the write queue and returns the result of its BatchWrite if withResult is true.
+func (bw *BatchedWriter) enqueue(object BatchWriteObject, withResult bool, blocking bool) (*BatchWriteResult, error) {
 	bw.autoStartOnce.Do(func() {
 		if !bw.running.Load() {
 			bw.startBatchWriter()
 		}
 	})
 
+	var result *BatchWriteResult
+	if withResult {
+		result = newBatchWriteResult()
+	}
+
 	// abort if the BatchWriter has been stopped
 	if !bw.running.Load() {
-		return
+		return result.resolvedWith(ErrBatchedWriterStopped), ErrBatchedWriterStopped
 	}
 
-	// abort if the very same object has been queued already
+	// wait behind the very same object if it has been queued already
 	if object.BatchWriteScheduled() {
-		return
+		if !withResult {
+			return nil, nil
+		}
+
+		if err := bw.enqueueWaiter(result, blocking); err != nil {
+			return result.resolvedWith(err), err
+		}
+
+		return result, nil
 	}
 
-	// queue object
+	if err := bw.enqueueEntry(batchQueueEntry{object: object, result: result}, blocking); err != nil {
+		object.ResetBatchWriteScheduled()
+
+		return result.resolvedWith(err), err
+	}
+
+	return result, nil
+}
+
+// enqueueWaiter adds an entry without an object to the write queue whose result is done once the objects that were
+// enqueued before were committed.
+func (bw *BatchedWriter) enqueueWaiter(result *BatchWriteResult, blocking bool) error {
+	if !bw.running.Load() {
+		return ErrBatchedWriterStopped
+	}
+
+	// count the failed batches before the entry is queued, so that the batches of the earlier objects are covered
+	return bw.enqueueEntry(batchQueueEntry{result: result, failedBatches: bw.failedBatches.Load()}, blocking)
+}
+
+// enqueueEntry adds the given entry to the write queue.
+func (bw *BatchedWriter) enqueueEntry(entry batchQueueEntry, blocking bool) error {
 	bw.scheduledCount.Add(1)
-	bw.batchQueue <- object
+
+	if blocking {
+		bw.batchQueue <- entry
+
+		return nil
+	}
+
+	select {
+	case bw.batchQueue <- entry:
+		return nil
+	default:
+		bw.scheduledCount.Add(-1)
+
+		return ErrQueueFull
+	}
+}
+
+// addToBatch adds the given entry that was taken from the queue to the batch of the given collector.
+func (bw *BatchedWriter) addToBatch(batchCollector *BatchCollector, entry batchQueueEntry) (batchSizeReached bool) {
+	bw.scheduledCount.Add(-1)
+
+	if entry.object == nil {
+		bw.waiters = append(bw.waiters, entry)
+
+		return false
+	}
+
+	entry.object.ResetBatchWriteScheduled()
+
+	return batchCollector.Add(entry.object, entry.result)
+}
+
+// commitBatch commits the batch of the given collector, resolves the results of its objects and the waiting entries
+// and updates the stats.
+func (bw *BatchedWriter) commitBatch(batchCollector *BatchCollector) {
+	start := time.Now()
+	err := batchCollector.Commit()
+	latency := time.Since(start)
+
+	batchSize := batchCollector.writtenValuesCounter
+	if batchSize != 0 {
+		bw.updateStats(batchSize, latency, err)
+
+		if err != nil {
+			bw.failedBatches.Add(1)
+		}
+	}
+
+	unobservedObjects := false
+	for i := range batchSize {
+		if result := batchCollector.results[i]; result != nil {
+			result.resolve(err)
+		} else {
+			unobservedObjects = true
+		}
+	}
+
+	for _, waiter := range bw.waiters {
+		// the objects that were enqueued before the waiter can also be part of earlier batches
+		if err == nil && bw.failedBatches.Load() != waiter.failedBatches {
+			waiter.result.resolve(bw.lastCommitError())
+		} else {
+			waiter.result.resolve(err)
+		}
+	}
+	bw.waiters = bw.waiters[:0]
+
+	if err != nil && unobservedObjects {
+		bw.opts.commitErrorHandler(ierrors.Wrap(err, "failed to commit batch"))
+	}
+}
+
+// updateStats adds the given batch to the stats.
+func (bw *BatchedWriter) updateStats(batchSize int, latency time.Duration, err error) {
+	bw.statsMutex.Lock()
+	defer bw.statsMutex.Unlock()
+
+	if err != nil {
+		bw.lastCommitErr = err
+		bw.stats.FailedBatches++
+		bw.stats.FailedObjects += uint64(batchSize)
+	} else {
+		bw.stats.CommittedBatches++
+		bw.stats.CommittedObjects += uint64(batchSize)
+	}
+	bw.stats.LastBatchSize = batchSize
+	bw.stats.MaxBatchSize = max(bw.stats.MaxBatchSize, batchSize)
+	bw.stats.LastCommitLatency = latency
+	bw.stats.MaxCommitLatency = max(bw.stats.MaxCommitLatency, latency)
+	bw.stats.TotalCommitLatency += latency
+}
+
+// lastCommitError returns the error of the last batch that failed to be committed.
+func (bw *BatchedWriter) lastCommitError() error {
+	bw.statsMutex.RLock()
+	defer bw.statsMutex.RUnlock()
+
+	return bw.lastCommitErr
 }
 
 // Flush sends a signal to flush all the queued elements.
 func (bw *BatchedWriter) Flush() {
 	if bw.running.Load() {
-		select {
-		case bw.flushChan <- struct{}{}:
-		default:
-			// another flush request is already queued => no need to block
-		}
+		bw.triggerFlush()
+	}
+}
+
+// triggerFlush sends a signal to flush all the queued elements without blocking.
+func (bw *BatchedWriter) triggerFlush() {
+	select {
+	case bw.flushChan <- struct{}{}:
+	default:
+		// another flush request is already queued => no need to block
 	}
 }
 
@@ -168,7 +504,7 @@ func (bw *BatchedWriter) runBatchWriter() {
 		if err != nil {
 			panic(err)
 		}
-		batchCollector := newBatchCollector(batchedMutation, &bw.scheduledCount, bw.opts.batchSize)
+		batchCollector := newBatchCollector(batchedMutation, bw.opts.batchSize)
 		shouldFlush := false
 
 		collectValues := func() {
@@ -178,12 +514,10 @@ func (bw *BatchedWriter) runBatchWriter() {
 			for {
 				select {
 				// an element was added to the queue
-				case objectToPersist := <-bw.batchQueue:
-					if batchCollector.Add(objectToPersist) {
+				case entry := <-bw.batchQueue:
+					if bw.addToBatch(batchCollector, entry) {
 						// batch size was reached => apply the mutations
-						if err := batchCollector.Commit(); err != nil {
-							panic(err)
-						}
+						bw.commitBatch(batchCollector)
 
 						return
 					}
@@ -197,9 +531,7 @@ func (bw *BatchedWriter) runBatchWriter() {
 				// batch timeout was reached
 				case <-batchWriterTimeoutTimer.C:
 					// apply the collected mutations
-					if err := batchCollector.Commit(); err != nil {
-						panic(err)
-					}
+					bw.commitBatch(batchCollector)
 
 					return
 				}
@@ -215,27 +547,23 @@ func (bw *BatchedWriter) runBatchWriter() {
 			for {
 				select {
 				// pick the next element from the queue
-				case objectToPersist := <-bw.batchQueue:
-					if batchCollector.Add(objectToPersist) {
+				case entry := <-bw.batchQueue:
+					if bw.addToBatch(batchCollector, entry) {
 						// batch size was reached => apply the mutations
-						if err := batchCollector.Commit(); err != nil {
-							panic(err)
-						}
+						bw.commitBatch(batchCollector)
 
 						// create a new collector to batch the remaining elements
 						batchedMutation, err := bw.store.Batched()
 						if err != nil {
 							panic(err)
 						}
-						batchCollector = newBatchCollector(batchedMutation, &bw.scheduledCount, bw.opts.batchSize)
+						batchCollector = newBatchCollector(batchedMutation, bw.opts.batchSize)
 					}
 
 				// no elements left
 				default:
 					// apply the collected mutations
-					if err := batchCollector.Commit(); err != nil {
-						panic(err)
-					}
+					bw.commitBatch(batchCollector)
 
 					break FlushValues
 				}
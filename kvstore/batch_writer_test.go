@@ -0,0 +1,254 @@
+package kvstore_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+func TestBatchedWriterResults(t *testing.T) {
+	store := mapdb.NewMapDB()
+	defer store.Close()
+
+	batchedWriter := kvstore.NewBatchedWriter(store, kvstore.WithBatchTimeout(time.Hour))
+
+	object := newTestBatchObject("key", "value")
+	result := batchedWriter.EnqueueWithResult(object)
+	require.Nil(t, result.Err())
+
+	// enqueuing the same object again returns a result that waits for the queued object
+	queuedResult := batchedWriter.EnqueueWithResult(object)
+	require.NotSame(t, result, queuedResult)
+
+	require.NoError(t, batchedWriter.FlushAndWaitAll())
+	require.NoError(t, result.Wait())
+	require.NoError(t, queuedResult.Wait())
+	require.True(t, object.written.Load())
+	value, err := store.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	// nothing is queued
+	require.NoError(t, batchedWriter.FlushAndWaitAll())
+
+	callbackErr := make(chan error, 1)
+	otherObject := newTestBatchObject("other key", "other value")
+	batchedWriter.EnqueueWithResult(otherObject).OnDone(func(err error) {
+		callbackErr <- err
+	})
+	batchedWriter.Flush()
+	require.NoError(t, <-callbackErr)
+
+	stats := batchedWriter.Stats()
+	require.Equal(t, uint64(2), stats.CommittedBatches)
+	require.Equal(t, uint64(2), stats.CommittedObjects)
+	require.Equal(t, uint64(0), stats.FailedBatches)
+	require.Equal(t, 1, stats.LastBatchSize)
+	require.Equal(t, 1, stats.MaxBatchSize)
+	require.Equal(t, 1.0, stats.AverageBatchSize())
+	require.Equal(t, 0, stats.QueueLength)
+
+	batchedWriter.StopBatchWriter()
+
+	result, err = batchedWriter.TryEnqueue(newTestBatchObject("key", "value"))
+	require.ErrorIs(t, err, kvstore.ErrBatchedWriterStopped)
+	require.ErrorIs(t, result.Wait(), kvstore.ErrBatchedWriterStopped)
+}
+
+func TestBatchedWriterQueueFull(t *testing.T) {
+	store := mapdb.NewMapDB()
+	defer store.Close()
+
+	batchedWriter := kvstore.NewBatchedWriter(store, kvstore.WithQueueSize(1), kvstore.WithBatchTimeout(time.Hour))
+
+	// block the batch writer while it writes the first object
+	blockingObject := newTestBatchObject("blocking", "value")
+	blockingObject.unblock = make(chan struct{})
+	blockingObject.blocked = make(chan struct{})
+	blockingResult, err := batchedWriter.TryEnqueue(blockingObject)
+	require.NoError(t, err)
+	<-blockingObject.blocked
+
+	queuedObject := newTestBatchObject("queued", "value")
+	queuedResult, err := batchedWriter.TryEnqueue(queuedObject)
+	require.NoError(t, err)
+	require.Equal(t, 1, batchedWriter.Stats().QueueLength)
+
+	rejectedObject := newTestBatchObject("rejected", "value")
+	rejectedResult, err := batchedWriter.TryEnqueue(rejectedObject)
+	require.ErrorIs(t, err, kvstore.ErrQueueFull)
+	require.ErrorIs(t, rejectedResult.Wait(), kvstore.ErrQueueFull)
+	require.False(t, rejectedObject.scheduled.Load())
+
+	close(blockingObject.unblock)
+	require.NoError(t, batchedWriter.FlushAndWaitAll())
+	require.NoError(t, blockingResult.Wait())
+	require.NoError(t, queuedResult.Wait())
+
+	has, err := store.Has([]byte("rejected"))
+	require.NoError(t, err)
+	require.False(t, has)
+
+	batchedWriter.StopBatchWriter()
+}
+
+func TestBatchedWriterCommitError(t *testing.T) {
+	store := &failingStore{KVStore: mapdb.NewMapDB()}
+	defer store.Close()
+
+	handledErrors := make(chan error, 1)
+	batchedWriter := kvstore.NewBatchedWriter(store, kvstore.WithBatchTimeout(time.Hour), kvstore.WithCommitErrorHandler(func(err error) {
+		handledErrors <- err
+	}))
+	defer batchedWriter.StopBatchWriter()
+
+	object := newTestBatchObject("key", "value")
+	result := batchedWriter.EnqueueWithResult(object)
+
+	require.ErrorIs(t, batchedWriter.FlushAndWaitAll(), errCommitFailed)
+	require.ErrorIs(t, result.Err(), errCommitFailed)
+	require.False(t, object.written.Load())
+
+	// the handler is not called if the errors are observed by the results
+	require.Empty(t, handledErrors)
+
+	stats := batchedWriter.Stats()
+	require.Equal(t, uint64(1), stats.FailedBatches)
+	require.Equal(t, uint64(1), stats.FailedObjects)
+	require.Equal(t, uint64(0), stats.CommittedBatches)
+
+	// errors of objects without a result are passed to the handler
+	batchedWriter.Enqueue(newTestBatchObject("other key", "value"))
+	batchedWriter.Flush()
+	require.ErrorIs(t, <-handledErrors, errCommitFailed)
+
+	// the stopped batch writer returns the error of the last failed batch
+	batchedWriter.StopBatchWriter()
+	require.ErrorIs(t, batchedWriter.FlushAndWaitAll(), errCommitFailed)
+}
+
+func TestBatchedWriterValueObjects(t *testing.T) {
+	store := mapdb.NewMapDB()
+	defer store.Close()
+
+	batchedWriter := kvstore.NewBatchedWriter(store, kvstore.WithBatchTimeout(time.Hour))
+	defer batchedWriter.StopBatchWriter()
+
+	// objects that are not comparable and objects that are equal can be enqueued
+	results := []*kvstore.BatchWriteResult{
+		batchedWriter.EnqueueWithResult(newTestValueObject("key", "value")),
+		batchedWriter.EnqueueWithResult(newTestValueObject("key", "value")),
+		batchedWriter.EnqueueWithResult(newTestValueObject("other key", "value")),
+	}
+	batchedWriter.Flush()
+
+	for _, result := range results {
+		require.NoError(t, result.Wait())
+	}
+	require.Equal(t, uint64(3), batchedWriter.Stats().CommittedObjects)
+}
+
+var errCommitFailed = ierrors.New("commit failed")
+
+// failingStore is a KVStore whose batched mutations fail to commit.
+type failingStore struct {
+	kvstore.KVStore
+}
+
+func (f *failingStore) Batched() (kvstore.BatchedMutations, error) {
+	batchedMutations, err := f.KVStore.Batched()
+	if err != nil {
+		return nil, err
+	}
+
+	return &failingBatchedMutations{BatchedMutations: batchedMutations}, nil
+}
+
+type failingBatchedMutations struct {
+	kvstore.BatchedMutations
+}
+
+func (f *failingBatchedMutations) Commit() error {
+	f.BatchedMutations.Cancel()
+
+	return errCommitFailed
+}
+
+// testBatchObject is a BatchWriteObject that sets a single key.
+type testBatchObject struct {
+	key       []byte
+	value     []byte
+	scheduled atomic.Bool
+	written   atomic.Bool
+
+	// blocked is closed and unblock is awaited during the BatchWrite if they are set.
+	blocked chan struct{}
+	unblock chan struct{}
+}
+
+func newTestBatchObject(key string, value string) *testBatchObject {
+	return &testBatchObject{
+		key:   []byte(key),
+		value: []byte(value),
+	}
+}
+
+func (t *testBatchObject) BatchWrite(batchedMuts kvstore.BatchedMutations) {
+	if t.blocked != nil {
+		close(t.blocked)
+		<-t.unblock
+	}
+
+	if err := batchedMuts.Set(t.key, t.value); err != nil {
+		panic(err)
+	}
+}
+
+func (t *testBatchObject) BatchWriteDone() {
+	t.written.Store(true)
+}
+
+func (t *testBatchObject) BatchWriteScheduled() bool {
+	return t.scheduled.Swap(true)
+}
+
+func (t *testBatchObject) ResetBatchWriteScheduled() {
+	t.scheduled.Store(false)
+}
+
+// testValueObject is a BatchWriteObject that is not comparable, because it contains slices.
+type testValueObject struct {
+	key       []byte
+	value     []byte
+	scheduled *atomic.Bool
+}
+
+func newTestValueObject(key string, value string) testValueObject {
+	return testValueObject{
+		key:       []byte(key),
+		value:     []byte(value),
+		scheduled: &atomic.Bool{},
+	}
+}
+
+func (t testValueObject) BatchWrite(batchedMuts kvstore.BatchedMutations) {
+	if err := batchedMuts.Set(t.key, t.value); err != nil {
+		panic(err)
+	}
+}
+
+func (t testValueObject) BatchWriteDone() {}
+
+func (t testValueObject) BatchWriteScheduled() bool {
+	return t.scheduled.Swap(true)
+}
+
+func (t testValueObject) ResetBatchWriteScheduled() {
+	t.scheduled.Store(false)
+}
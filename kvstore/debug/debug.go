@@ -126,6 +126,15 @@ func (s *debugStore) IterateKeys(prefix kvstore.KeyPrefix, consumerFunc kvstore.
 	return s.underlying.IterateKeys(prefix, consumerFunc, iterDirection...)
 }
 
+// IterateKeysFrom iterates over all keys with the provided prefix in ascending order, starting at the given key (inclusive).
+func (s *debugStore) IterateKeysFrom(prefix kvstore.KeyPrefix, start kvstore.Key, consumerFunc kvstore.IteratorKeyConsumerFunc) error {
+	if s.accessCallback != nil && s.accessCallbackCommandsFilter.HasBits(IterateKeysCommand) {
+		s.accessCallback(IterateKeysCommand, prefix, start)
+	}
+
+	return s.underlying.IterateKeysFrom(prefix, start, consumerFunc)
+}
+
 func (s *debugStore) Clear() error {
 	if s.accessCallback != nil && s.accessCallbackCommandsFilter.HasBits(ClearCommand) {
 		s.accessCallback(ClearCommand)
@@ -142,6 +151,14 @@ func (s *debugStore) Get(key kvstore.Key) (value kvstore.Value, err error) {
 	return s.underlying.Get(key)
 }
 
+func (s *debugStore) GetMany(keys []kvstore.Key) (values []kvstore.Value, exists []bool, err error) {
+	if s.accessCallback != nil && s.accessCallbackCommandsFilter.HasBits(GetCommand) {
+		s.accessCallback(GetCommand, keys...)
+	}
+
+	return s.underlying.GetMany(keys)
+}
+
 func (s *debugStore) Set(key kvstore.Key, value kvstore.Value) error {
 	if s.accessCallback != nil && s.accessCallbackCommandsFilter.HasBits(SetCommand) {
 		s.accessCallback(SetCommand, key, value)
@@ -174,6 +191,18 @@ func (s *debugStore) DeletePrefix(prefix kvstore.KeyPrefix) error {
 	return s.underlying.DeletePrefix(prefix)
 }
 
+func (s *debugStore) CompactRange(prefix kvstore.KeyPrefix) error {
+	return s.underlying.CompactRange(prefix)
+}
+
+func (s *debugStore) EstimateSize(prefix kvstore.KeyPrefix) (uint64, error) {
+	return s.underlying.EstimateSize(prefix)
+}
+
+func (s *debugStore) EstimateCount(prefix kvstore.KeyPrefix) (uint64, error) {
+	return s.underlying.EstimateCount(prefix)
+}
+
 func (s *debugStore) Flush() error {
 	return s.underlying.Flush()
 }
@@ -51,6 +51,11 @@ func (s *flushKVStore) IterateKeys(prefix kvstore.KeyPrefix, consumerFunc kvstor
 	return s.store.IterateKeys(prefix, consumerFunc, iterDirection...)
 }
 
+// IterateKeysFrom iterates over all keys with the provided prefix in ascending order, starting at the given key (inclusive).
+func (s *flushKVStore) IterateKeysFrom(prefix kvstore.KeyPrefix, start kvstore.Key, consumerFunc kvstore.IteratorKeyConsumerFunc) error {
+	return s.store.IterateKeysFrom(prefix, start, consumerFunc)
+}
+
 // Clear clears the realm.
 func (s *flushKVStore) Clear() error {
 	if err := s.store.Clear(); err != nil {
@@ -65,6 +70,11 @@ func (s *flushKVStore) Get(key kvstore.Key) (kvstore.Value, error) {
 	return s.store.Get(key)
 }
 
+// GetMany gets the values for the given keys.
+func (s *flushKVStore) GetMany(keys []kvstore.Key) ([]kvstore.Value, []bool, error) {
+	return s.store.GetMany(keys)
+}
+
 // Set sets the given key and value.
 func (s *flushKVStore) Set(key kvstore.Key, value kvstore.Value) error {
 	if err := s.store.Set(key, value); err != nil {
@@ -97,6 +107,21 @@ func (s *flushKVStore) DeletePrefix(prefix kvstore.KeyPrefix) error {
 	return s.store.Flush()
 }
 
+// CompactRange compacts the underlying storage for all the entries matching the given key prefix.
+func (s *flushKVStore) CompactRange(prefix kvstore.KeyPrefix) error {
+	return s.store.CompactRange(prefix)
+}
+
+// EstimateSize returns the approximate number of bytes used by all the entries matching the given key prefix.
+func (s *flushKVStore) EstimateSize(prefix kvstore.KeyPrefix) (uint64, error) {
+	return s.store.EstimateSize(prefix)
+}
+
+// EstimateCount returns the approximate number of entries matching the given key prefix.
+func (s *flushKVStore) EstimateCount(prefix kvstore.KeyPrefix) (uint64, error) {
+	return s.store.EstimateCount(prefix)
+}
+
 // Flush persists all outstanding write operations to disc.
 func (s *flushKVStore) Flush() error {
 	return s.store.Flush()
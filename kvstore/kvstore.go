@@ -76,12 +76,21 @@ type KVStore interface {
 	// Optionally the direction for the iteration can be passed (default: IterDirectionForward).
 	IterateKeys(prefix KeyPrefix, consumerFunc IteratorKeyConsumerFunc, direction ...IterDirection) error
 
+	// IterateKeysFrom iterates over all keys with the provided prefix in ascending order, starting at the given key (inclusive).
+	// The keys before the start are skipped without iterating over them, e.g. to continue at the cursor of a paginated iteration.
+	IterateKeysFrom(prefix KeyPrefix, start Key, consumerFunc IteratorKeyConsumerFunc) error
+
 	// Clear clears the realm.
 	Clear() error
 
 	// Get gets the given key or nil if it doesn't exist or an error if an error occurred.
 	Get(key Key) (value Value, err error)
 
+	// GetMany gets the values for the given keys.
+	// The returned slices have the same length and order as the given keys.
+	// For each key that doesn't exist, the value is nil and the corresponding exists flag is false.
+	GetMany(keys []Key) (values []Value, exists []bool, err error)
+
 	// Set sets the given key and value.
 	Set(key Key, value Value) error
 
@@ -94,6 +103,21 @@ type KVStore interface {
 	// DeletePrefix deletes all the entries matching the given key prefix.
 	DeletePrefix(prefix KeyPrefix) error
 
+	// CompactRange compacts the underlying storage for all the entries matching the given key prefix.
+	// This reclaims the space of deleted entries. You can pass kvstore.EmptyPrefix to compact the whole realm.
+	CompactRange(prefix KeyPrefix) error
+
+	// EstimateSize returns the approximate number of bytes used by all the entries matching the given key prefix.
+	// You can pass kvstore.EmptyPrefix to estimate the size of the whole realm.
+	// The exact size can be determined with kvstore.Size, which iterates over all the entries and therefore
+	// works the same for every KVStore.
+	EstimateSize(prefix KeyPrefix) (uint64, error)
+
+	// EstimateCount returns the approximate number of entries matching the given key prefix.
+	// You can pass kvstore.EmptyPrefix to estimate the number of entries of the whole realm.
+	// The exact number can be determined with kvstore.Count.
+	EstimateCount(prefix KeyPrefix) (uint64, error)
+
 	// Flush persists all outstanding write operations to disc.
 	Flush() error
 
@@ -197,3 +221,35 @@ func CopyBatched(source KVStore, target KVStore, batchSize ...int) error {
 
 	return target.Flush()
 }
+
+// Count returns the exact number of entries matching the given key prefix by iterating over all keys.
+// Unlike KVStore.EstimateCount it is not part of the interface, since it only relies on KVStore.IterateKeys and
+// its cost grows with the number of entries, no matter which store is used.
+func Count(store KVStore, prefix KeyPrefix) (uint64, error) {
+	var count uint64
+	if err := store.IterateKeys(prefix, func(_ Key) bool {
+		count++
+
+		return true
+	}); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Size returns the exact number of bytes of all keys and values matching the given key prefix by iterating over all entries.
+// The realm of the store is not taken into account.
+// Unlike KVStore.EstimateSize it is not part of the interface, since it only relies on KVStore.Iterate.
+func Size(store KVStore, prefix KeyPrefix) (uint64, error) {
+	var size uint64
+	if err := store.Iterate(prefix, func(key Key, value Value) bool {
+		size += uint64(len(key) + len(value))
+
+		return true
+	}); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
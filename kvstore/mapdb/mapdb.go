@@ -72,6 +72,17 @@ func (s *mapDB) IterateKeys(prefix kvstore.KeyPrefix, consumerFunc kvstore.Itera
 	return nil
 }
 
+// IterateKeysFrom iterates over all keys with the provided prefix in ascending order, starting at the given key (inclusive).
+func (s *mapDB) IterateKeysFrom(prefix kvstore.KeyPrefix, start kvstore.Key, consumerFunc kvstore.IteratorKeyConsumerFunc) error {
+	if s.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	s.m.iterateKeysFrom(s.realm, prefix, start, consumerFunc)
+
+	return nil
+}
+
 func (s *mapDB) Clear() error {
 	if s.closed.Load() {
 		return kvstore.ErrStoreClosed
@@ -101,6 +112,19 @@ func (s *mapDB) Get(key kvstore.Key) (kvstore.Value, error) {
 	return value, nil
 }
 
+func (s *mapDB) GetMany(keys []kvstore.Key) ([]kvstore.Value, []bool, error) {
+	if s.closed.Load() {
+		return nil, nil, kvstore.ErrStoreClosed
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	values, exists := s.m.getMany(s.realm, keys)
+
+	return values, exists, nil
+}
+
 func (s *mapDB) Set(key kvstore.Key, value kvstore.Value) error {
 	if s.closed.Load() {
 		return kvstore.ErrStoreClosed
@@ -161,6 +185,38 @@ func (s *mapDB) DeletePrefix(prefix kvstore.KeyPrefix) error {
 	return nil
 }
 
+// CompactRange is a no-op for the mapDB, because deleted entries do not occupy any space.
+func (s *mapDB) CompactRange(_ kvstore.KeyPrefix) error {
+	if s.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	return nil
+}
+
+// EstimateSize returns the exact number of bytes of all keys and values matching the given key prefix.
+// The realm is not taken into account.
+func (s *mapDB) EstimateSize(prefix kvstore.KeyPrefix) (uint64, error) {
+	if s.closed.Load() {
+		return 0, kvstore.ErrStoreClosed
+	}
+
+	_, size := s.m.countAndSize(s.realm, prefix)
+
+	return size, nil
+}
+
+// EstimateCount returns the exact number of entries matching the given key prefix.
+func (s *mapDB) EstimateCount(prefix kvstore.KeyPrefix) (uint64, error) {
+	if s.closed.Load() {
+		return 0, kvstore.ErrStoreClosed
+	}
+
+	count, _ := s.m.countAndSize(s.realm, prefix)
+
+	return count, nil
+}
+
 func (s *mapDB) Flush() error {
 	if s.closed.Load() {
 		return kvstore.ErrStoreClosed
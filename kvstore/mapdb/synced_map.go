@@ -33,6 +33,25 @@ func (s *syncedKVMap) get(key []byte) ([]byte, bool) {
 	return byteutils.ConcatBytes(value), true
 }
 
+func (s *syncedKVMap) getMany(realm []byte, keys [][]byte) ([][]byte, []bool) {
+	values := make([][]byte, len(keys))
+	exists := make([]bool, len(keys))
+
+	s.RLock()
+	defer s.RUnlock()
+	for i, key := range keys {
+		value, ok := s.m[byteutils.ConcatBytesToString(realm, key)]
+		if !ok {
+			continue
+		}
+		// always copy the value
+		values[i] = byteutils.ConcatBytes(value)
+		exists[i] = true
+	}
+
+	return values, exists
+}
+
 func (s *syncedKVMap) set(key, value []byte) {
 	s.Lock()
 	defer s.Unlock()
@@ -57,6 +76,20 @@ func (s *syncedKVMap) deletePrefix(keyPrefix []byte) {
 	}
 }
 
+func (s *syncedKVMap) countAndSize(realm []byte, keyPrefix []byte) (count uint64, size uint64) {
+	s.RLock()
+	defer s.RUnlock()
+	prefix := byteutils.ConcatBytesToString(realm, keyPrefix)
+	for key, value := range s.m {
+		if strings.HasPrefix(key, prefix) {
+			count++
+			size += uint64(len(key) - len(realm) + len(value))
+		}
+	}
+
+	return count, size
+}
+
 func (s *syncedKVMap) iterate(realm []byte, keyPrefix []byte, consume func(key, value []byte) bool, iterDirection ...kvstore.IterDirection) {
 	// take a snapshot of the current elements
 	s.RLock()
@@ -106,3 +139,24 @@ func (s *syncedKVMap) iterateKeys(realm []byte, keyPrefix []byte, consume func(k
 		}
 	}
 }
+
+func (s *syncedKVMap) iterateKeysFrom(realm []byte, keyPrefix []byte, start []byte, consume func(key []byte) bool) {
+	// take a snapshot of the current elements starting at the start key
+	s.RLock()
+	prefix := byteutils.ConcatBytesToString(realm, keyPrefix)
+	startKey := byteutils.ConcatBytesToString(realm, start)
+	keysSlice := make([]string, 0)
+	for key := range s.m {
+		if strings.HasPrefix(key, prefix) && key >= startKey {
+			keysSlice = append(keysSlice, key)
+		}
+	}
+	s.RUnlock()
+
+	// iterate through found elements
+	for _, key := range utils.SortSlice(keysSlice) {
+		if !consume([]byte(key)[len(realm):]) {
+			break
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package kvstore
+
+import (
+	"encoding/binary"
+	"slices"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	queuePrefixSequence byte = iota
+	queuePrefixElements
+	queuePrefixInFlight
+)
+
+// queueIDInterval is the interval of the Sequence of the IDs, which is 1 so that the IDs have no gaps after a restart.
+const queueIDInterval = 1
+
+// QueueElement is an element that was taken from a Queue and needs to be acknowledged or requeued.
+type QueueElement[T any] struct {
+	// ID is the position of the element in the queue.
+	ID uint64
+	// Value is the value of the element.
+	Value T
+}
+
+// Queue is a persistent FIFO queue backed by a KVStore.
+//
+// Popped elements are kept in flight until they are acknowledged or requeued. Elements that are still in flight when
+// the queue is restored from the store (e.g. after a crash) are requeued at the tail of the queue. The IDs of the
+// elements are taken from a Sequence, which persists an ID before it is used, so that IDs are never reused if the
+// process crashes. The head of the queue is the stored element with the lowest ID.
+type Queue[T any] struct {
+	store    KVStore
+	elements *TypedStore[uint64, T]
+	inFlight *TypedStore[uint64, T]
+	ids      *Sequence
+	size     int
+	mutex    sync.Mutex
+
+	valueToBytes ObjectToBytes[T]
+}
+
+// NewQueue creates a new Queue that stores its elements in the given store.
+func NewQueue[T any](store KVStore, valueToBytes ObjectToBytes[T], bytesToValue BytesToObject[T]) (*Queue[T], error) {
+	elementsStore, err := store.WithExtendedRealm([]byte{queuePrefixElements})
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to create elements store")
+	}
+
+	inFlightStore, err := store.WithExtendedRealm([]byte{queuePrefixInFlight})
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to create in-flight store")
+	}
+
+	q := &Queue[T]{
+		store:        store,
+		elements:     NewTypedStore(elementsStore, queueIDToBytes, queueIDFromBytes, valueToBytes, bytesToValue),
+		inFlight:     NewTypedStore(inFlightStore, queueIDToBytes, queueIDFromBytes, valueToBytes, bytesToValue),
+		valueToBytes: valueToBytes,
+	}
+
+	if q.ids, err = NewSequence(store, []byte{queuePrefixSequence}, queueIDInterval); err != nil {
+		return nil, ierrors.Wrap(err, "failed to create ID sequence")
+	}
+
+	if err = q.elements.IterateKeys(EmptyPrefix, func(uint64) bool {
+		q.size++
+
+		return true
+	}); err != nil {
+		return nil, ierrors.Wrap(err, "failed to count elements")
+	}
+
+	if err = q.requeueInFlight(); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// Push appends the given value to the tail of the queue and returns its ID.
+func (q *Queue[T]) Push(value T) (id uint64, err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	valueBytes, err := q.valueToBytes(value)
+	if err != nil {
+		return 0, ierrors.Wrap(err, "failed to encode value")
+	}
+
+	return q.push(valueBytes, nil)
+}
+
+// Peek returns the element at the head of the queue without removing it.
+func (q *Queue[T]) Peek() (element *QueueElement[T], exists bool, err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if err = q.elements.Iterate(EmptyPrefix, func(id uint64, value T) bool {
+		element = &QueueElement[T]{ID: id, Value: value}
+
+		return false
+	}); err != nil {
+		return nil, false, ierrors.Wrap(err, "failed to get head element")
+	}
+
+	return element, element != nil, nil
+}
+
+// Pop removes the element at the head of the queue and keeps it in flight until it is acknowledged or requeued.
+func (q *Queue[T]) Pop() (element *QueueElement[T], exists bool, err error) {
+	elements, err := q.PopMany(1)
+	if err != nil || len(elements) == 0 {
+		return nil, false, err
+	}
+
+	return elements[0], true, nil
+}
+
+// PopMany removes up to the given number of elements from the head of the queue and keeps them in flight until they
+// are acknowledged or requeued.
+func (q *Queue[T]) PopMany(maxCount int) (elements []*QueueElement[T], err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	batch, err := q.store.Batched()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to create batch")
+	}
+
+	elements = make([]*QueueElement[T], 0)
+	var innerErr error
+	if err = q.elements.KVStore().Iterate(EmptyPrefix, func(key Key, value Value) bool {
+		if len(elements) >= maxCount {
+			return false
+		}
+		valueBytes := slices.Clone(value)
+
+		id, _, err := queueIDFromBytes(key)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		decodedValue, _, err := q.elements.bytesToValue(valueBytes)
+		if err != nil {
+			innerErr = ierrors.Wrapf(err, "failed to decode element %d", id)
+
+			return false
+		}
+
+		if err = batch.Delete(queueKey(queuePrefixElements, id)); err != nil {
+			innerErr = ierrors.Wrapf(err, "failed to delete element %d", id)
+
+			return false
+		}
+
+		if err = batch.Set(queueKey(queuePrefixInFlight, id), valueBytes); err != nil {
+			innerErr = ierrors.Wrapf(err, "failed to mark element %d as in flight", id)
+
+			return false
+		}
+
+		elements = append(elements, &QueueElement[T]{ID: id, Value: decodedValue})
+
+		return true
+	}); err != nil {
+		batch.Cancel()
+
+		return nil, ierrors.Wrap(err, "failed to iterate over elements")
+	} else if innerErr != nil {
+		batch.Cancel()
+
+		return nil, innerErr
+	}
+
+	if len(elements) == 0 {
+		batch.Cancel()
+
+		return elements, nil
+	}
+
+	if err = batch.Commit(); err != nil {
+		return nil, ierrors.Wrap(err, "failed to commit batch")
+	}
+	q.size -= len(elements)
+
+	return elements, nil
+}
+
+// Ack acknowledges that the in-flight element with the given ID was processed and removes it from the queue.
+func (q *Queue[T]) Ack(id uint64) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if err := q.checkInFlight(id); err != nil {
+		return err
+	}
+
+	if err := q.inFlight.Delete(id); err != nil {
+		return ierrors.Wrapf(err, "failed to delete element %d", id)
+	}
+
+	return nil
+}
+
+// Requeue appends the in-flight element with the given ID to the tail of the queue again and returns its new ID.
+func (q *Queue[T]) Requeue(id uint64) (newID uint64, err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if err = q.checkInFlight(id); err != nil {
+		return 0, err
+	}
+
+	valueBytes, err := q.inFlight.KVStore().Get(queueIDBytes(id))
+	if err != nil {
+		return 0, ierrors.Wrapf(err, "failed to get element %d", id)
+	}
+
+	return q.push(valueBytes, queueKey(queuePrefixInFlight, id))
+}
+
+// Size returns the number of elements in the queue that are not in flight.
+func (q *Queue[T]) Size() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.size
+}
+
+// InFlight returns the IDs of the elements that were popped but not acknowledged or requeued yet.
+func (q *Queue[T]) InFlight() (ids []uint64, err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	ids = make([]uint64, 0)
+	if err = q.inFlight.IterateKeys(EmptyPrefix, func(id uint64) bool {
+		ids = append(ids, id)
+
+		return true
+	}); err != nil {
+		return nil, ierrors.Wrap(err, "failed to iterate over in-flight elements")
+	}
+
+	return ids, nil
+}
+
+// push appends the given encoded value to the tail of the queue and deletes the given key in the same batch.
+func (q *Queue[T]) push(valueBytes []byte, deletedKey []byte) (id uint64, err error) {
+	if id, err = q.ids.Next(); err != nil {
+		return 0, ierrors.Wrap(err, "failed to get next ID")
+	}
+
+	batch, err := q.store.Batched()
+	if err != nil {
+		return 0, ierrors.Wrap(err, "failed to create batch")
+	}
+
+	if deletedKey != nil {
+		if err = batch.Delete(deletedKey); err != nil {
+			batch.Cancel()
+
+			return 0, ierrors.Wrap(err, "failed to delete key")
+		}
+	}
+
+	if err = batch.Set(queueKey(queuePrefixElements, id), valueBytes); err != nil {
+		batch.Cancel()
+
+		return 0, ierrors.Wrapf(err, "failed to set element %d", id)
+	}
+
+	if err = batch.Commit(); err != nil {
+		return 0, ierrors.Wrap(err, "failed to commit batch")
+	}
+	q.size++
+
+	return id, nil
+}
+
+// requeueInFlight requeues the elements that were still in flight when the queue was restored.
+func (q *Queue[T]) requeueInFlight() error {
+	inFlightElements := make(map[uint64][]byte)
+	ids := make([]uint64, 0)
+	var innerErr error
+	if err := q.inFlight.KVStore().Iterate(EmptyPrefix, func(key Key, value Value) bool {
+		id, _, err := queueIDFromBytes(key)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		inFlightElements[id] = slices.Clone(value)
+		ids = append(ids, id)
+
+		return true
+	}); err != nil {
+		return ierrors.Wrap(err, "failed to iterate over in-flight elements")
+	} else if innerErr != nil {
+		return ierrors.Wrap(innerErr, "failed to decode in-flight element")
+	}
+
+	for _, id := range ids {
+		if _, err := q.push(inFlightElements[id], queueKey(queuePrefixInFlight, id)); err != nil {
+			return ierrors.Wrapf(err, "failed to requeue element %d", id)
+		}
+	}
+
+	return nil
+}
+
+// checkInFlight returns an error if the element with the given ID is not in flight.
+func (q *Queue[T]) checkInFlight(id uint64) error {
+	has, err := q.inFlight.Has(id)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to check if element %d is in flight", id)
+	} else if !has {
+		return ierrors.Wrapf(ErrKeyNotFound, "element %d is not in flight", id)
+	}
+
+	return nil
+}
+
+// queueKey returns the key of the element with the given ID in the realm with the given prefix.
+func queueKey(prefix byte, id uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte{prefix}, id)
+}
+
+// queueIDBytes returns the big endian encoding of the given ID, which keeps the elements sorted by their IDs.
+func queueIDBytes(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, id)
+}
+
+func queueIDToBytes(id uint64) ([]byte, error) {
+	return queueIDBytes(id), nil
+}
+
+func queueIDFromBytes(b []byte) (uint64, int, error) {
+	if len(b) < 8 {
+		return 0, 0, ierrors.Errorf("not enough bytes to decode queue ID: %d", len(b))
+	}
+
+	return binary.BigEndian.Uint64(b), 8, nil
+}
@@ -0,0 +1,122 @@
+package kvstore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+func TestQueue(t *testing.T) {
+	store := mapdb.NewMapDB()
+	defer store.Close()
+
+	queue, err := kvstore.NewQueue[int](store, intToBytes, bytesToInt)
+	require.NoError(t, err)
+
+	_, exists, err := queue.Pop()
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	for i := 0; i < 5; i++ {
+		id, err := queue.Push(i)
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), id)
+	}
+	require.Equal(t, 5, queue.Size())
+
+	element, exists, err := queue.Peek()
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, &kvstore.QueueElement[int]{ID: 0, Value: 0}, element)
+	require.Equal(t, 5, queue.Size())
+
+	element, exists, err = queue.Pop()
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, &kvstore.QueueElement[int]{ID: 0, Value: 0}, element)
+	require.Equal(t, 4, queue.Size())
+
+	elements, err := queue.PopMany(2)
+	require.NoError(t, err)
+	require.Equal(t, []*kvstore.QueueElement[int]{{ID: 1, Value: 1}, {ID: 2, Value: 2}}, elements)
+	require.Equal(t, []uint64{0, 1, 2}, inFlight(t, queue))
+
+	// acknowledged elements are removed and requeued elements are appended to the tail
+	require.NoError(t, queue.Ack(0))
+	require.True(t, ierrors.Is(queue.Ack(0), kvstore.ErrKeyNotFound))
+
+	newID, err := queue.Requeue(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), newID)
+	require.Equal(t, []uint64{2}, inFlight(t, queue))
+
+	_, err = queue.Requeue(1)
+	require.True(t, ierrors.Is(err, kvstore.ErrKeyNotFound))
+
+	// the queue is restored from the store and the elements that are still in flight are requeued
+	restoredQueue, err := kvstore.NewQueue[int](store, intToBytes, bytesToInt)
+	require.NoError(t, err)
+	require.Equal(t, 4, restoredQueue.Size())
+	require.Empty(t, inFlight(t, restoredQueue))
+
+	elements, err = restoredQueue.PopMany(10)
+	require.NoError(t, err)
+	require.Equal(t, []*kvstore.QueueElement[int]{{ID: 3, Value: 3}, {ID: 4, Value: 4}, {ID: 5, Value: 1}, {ID: 6, Value: 2}}, elements)
+	require.Equal(t, 0, restoredQueue.Size())
+
+	for _, element := range elements {
+		require.NoError(t, restoredQueue.Ack(element.ID))
+	}
+
+	// only the ID sequence remains in the store
+	keys := make([][]byte, 0)
+	require.NoError(t, store.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		keys = append(keys, key)
+
+		return true
+	}))
+	require.Equal(t, [][]byte{{0}}, keys)
+
+	_, exists, err = restoredQueue.Peek()
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func inFlight(t *testing.T, queue *kvstore.Queue[int]) []uint64 {
+	ids, err := queue.InFlight()
+	require.NoError(t, err)
+
+	return ids
+}
+
+func TestQueueIDOrdering(t *testing.T) {
+	store := mapdb.NewMapDB()
+	defer store.Close()
+
+	queue, err := kvstore.NewQueue[int](store, intToBytes, bytesToInt)
+	require.NoError(t, err)
+
+	// the IDs are stored in big endian so that the in-flight elements are requeued in order
+	for i := 0; i < 300; i++ {
+		_, err = queue.Push(i)
+		require.NoError(t, err)
+	}
+
+	elements, err := queue.PopMany(300)
+	require.NoError(t, err)
+	require.Len(t, elements, 300)
+
+	restoredQueue, err := kvstore.NewQueue[int](store, intToBytes, bytesToInt)
+	require.NoError(t, err)
+
+	elements, err = restoredQueue.PopMany(300)
+	require.NoError(t, err)
+	for i, element := range elements {
+		require.Equal(t, i, element.Value)
+		require.Equal(t, uint64(300+i), element.ID)
+	}
+}
@@ -3,6 +3,7 @@
 package rocksdb
 
 import (
+	"bytes"
 	"sync"
 	"sync/atomic"
 
@@ -14,6 +15,9 @@ import (
 	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
 )
 
+// memtableReadTier lets iterators only read the data held in the memtables (rocksdb's kMemtableTier).
+const memtableReadTier = grocksdb.ReadTier(3)
+
 type rocksDBStore struct {
 	instance *RocksDB
 	dbPrefix []byte
@@ -53,6 +57,52 @@ func (s *rocksDBStore) buildKeyPrefix(prefix kvstore.KeyPrefix) kvstore.KeyPrefi
 	return byteutils.ConcatBytes(s.dbPrefix, prefix)
 }
 
+// builds the range of keys covered by the realm and the given prefix.
+// The limit of the range is nil if there is no upper bound for the prefix.
+func (s *rocksDBStore) buildKeyRange(prefix kvstore.KeyPrefix) grocksdb.Range {
+	keyPrefix := s.buildKeyPrefix(prefix)
+
+	return grocksdb.Range{
+		Start: keyPrefix,
+		Limit: utils.KeyPrefixUpperBound(keyPrefix),
+	}
+}
+
+// boundKeyRange returns the given range with a concrete limit.
+// If the range has no upper bound, the limit is set right after the last existing key.
+// It returns false if there are no keys in an unbounded range.
+func (s *rocksDBStore) boundKeyRange(keyRange grocksdb.Range) (grocksdb.Range, bool) {
+	if keyRange.Limit != nil {
+		return keyRange, true
+	}
+
+	lastKey, exists := s.lastKey()
+	if !exists || !bytes.HasPrefix(lastKey, keyRange.Start) {
+		return keyRange, false
+	}
+
+	return grocksdb.Range{
+		Start: keyRange.Start,
+		Limit: append(lastKey, 0),
+	}, true
+}
+
+// lastKey returns the last key in the database (ignoring the realm).
+func (s *rocksDBStore) lastKey() ([]byte, bool) {
+	it := s.instance.db.NewIterator(s.instance.ro)
+	defer it.Close()
+
+	it.SeekToLast()
+	if !it.Valid() {
+		return nil, false
+	}
+
+	key := it.Key()
+	defer key.Free()
+
+	return utils.CopyBytes(key.Data(), key.Size()), true
+}
+
 // getIterFuncs returns the function pointers for the iteration based on the given settings.
 func (s *rocksDBStore) getIterFuncs(it *grocksdb.Iterator, keyPrefix []byte, iterDirection ...kvstore.IterDirection) (start func(), valid func() bool, move func(), err error) {
 
@@ -153,6 +203,36 @@ func (s *rocksDBStore) IterateKeys(prefix kvstore.KeyPrefix, consumerFunc kvstor
 	return nil
 }
 
+// IterateKeysFrom iterates over all keys with the provided prefix in ascending order, starting at the given key (inclusive).
+func (s *rocksDBStore) IterateKeysFrom(prefix kvstore.KeyPrefix, start kvstore.Key, consumerFunc kvstore.IteratorKeyConsumerFunc) error {
+	if s.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	it := s.instance.db.NewIterator(s.instance.ro)
+	defer it.Close()
+
+	keyPrefix := s.buildKeyPrefix(prefix)
+
+	// keys that are smaller than the prefix are not part of the iteration
+	seekKey := keyPrefix
+	if bytes.Compare(start, prefix) > 0 {
+		seekKey = byteutils.ConcatBytes(s.dbPrefix, start)
+	}
+
+	for it.Seek(seekKey); it.ValidForPrefix(keyPrefix); it.Next() {
+		key := it.Key()
+		k := utils.CopyBytes(key.Data(), key.Size())[len(s.dbPrefix):]
+		key.Free()
+
+		if !consumerFunc(k) {
+			break
+		}
+	}
+
+	return nil
+}
+
 func (s *rocksDBStore) Clear() error {
 	if s.closed.Load() {
 		return kvstore.ErrStoreClosed
@@ -176,6 +256,40 @@ func (s *rocksDBStore) Get(key kvstore.Key) (kvstore.Value, error) {
 	return v, nil
 }
 
+func (s *rocksDBStore) GetMany(keys []kvstore.Key) ([]kvstore.Value, []bool, error) {
+	if s.closed.Load() {
+		return nil, nil, kvstore.ErrStoreClosed
+	}
+
+	values := make([]kvstore.Value, len(keys))
+	exists := make([]bool, len(keys))
+	if len(keys) == 0 {
+		return values, exists, nil
+	}
+
+	dbKeys := make([][]byte, len(keys))
+	for i, key := range keys {
+		dbKeys[i] = byteutils.ConcatBytes(s.dbPrefix, key)
+	}
+
+	slices, err := s.instance.db.MultiGet(s.instance.ro, dbKeys...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer slices.Destroy()
+
+	for i, slice := range slices {
+		if !slice.Exists() {
+			continue
+		}
+
+		values[i] = utils.CopyBytes(slice.Data(), slice.Size())
+		exists[i] = true
+	}
+
+	return values, exists, nil
+}
+
 func (s *rocksDBStore) Set(key kvstore.Key, value kvstore.Value) error {
 	if s.closed.Load() {
 		return kvstore.ErrStoreClosed
@@ -210,21 +324,137 @@ func (s *rocksDBStore) DeletePrefix(prefix kvstore.KeyPrefix) error {
 		return kvstore.ErrStoreClosed
 	}
 
-	keyPrefix := s.buildKeyPrefix(prefix)
+	keyRange := s.buildKeyRange(prefix)
+
+	deleteRange, exists := s.boundKeyRange(keyRange)
+	if !exists {
+		return nil
+	}
 
 	writeBatch := grocksdb.NewWriteBatch()
 	defer writeBatch.Destroy()
 
-	it := s.instance.db.NewIterator(s.instance.ro)
+	writeBatch.DeleteRange(deleteRange.Start, deleteRange.Limit)
+
+	if err := s.instance.db.Write(s.instance.wo, writeBatch); err != nil {
+		return err
+	}
+
+	if s.instance.compactAfterPrune {
+		s.instance.CompactRange(keyRange)
+	}
+
+	return nil
+}
+
+func (s *rocksDBStore) CompactRange(prefix kvstore.KeyPrefix) error {
+	if s.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	s.instance.CompactRange(s.buildKeyRange(prefix))
+
+	return nil
+}
+
+// EstimateSize returns the approximate number of bytes on disk used by all the entries matching the given key prefix.
+// Entries that were not flushed to disk yet are not taken into account.
+func (s *rocksDBStore) EstimateSize(prefix kvstore.KeyPrefix) (uint64, error) {
+	if s.closed.Load() {
+		return 0, kvstore.ErrStoreClosed
+	}
+
+	keyRange, exists := s.boundKeyRange(s.buildKeyRange(prefix))
+	if !exists {
+		return 0, nil
+	}
+
+	sizes, err := s.instance.db.GetApproximateSizes([]grocksdb.Range{keyRange})
+	if err != nil {
+		return 0, ierrors.Wrap(err, "failed to get approximate sizes")
+	}
+
+	return sizes[0], nil
+}
+
+// EstimateCount returns the approximate number of entries matching the given key prefix.
+// The entries on disk are estimated from the "rocksdb.estimate-num-keys" property of the whole database without the
+// entries of the memtables, weighted by the share of disk space used by the entries matching the prefix.
+// The entries in the memtables are counted, since the memtables are bounded in size and held in memory.
+func (s *rocksDBStore) EstimateCount(prefix kvstore.KeyPrefix) (uint64, error) {
+	if s.closed.Load() {
+		return 0, kvstore.ErrStoreClosed
+	}
+
+	totalCount, success := s.instance.GetIntProperty("rocksdb.estimate-num-keys")
+	if !success {
+		return 0, ierrors.New("failed to get property rocksdb.estimate-num-keys")
+	}
+
+	keyPrefix := s.buildKeyPrefix(prefix)
+	if len(keyPrefix) == 0 {
+		return totalCount, nil
+	}
+
+	memtableCount, err := s.countMemtableKeys(keyPrefix)
+	if err != nil {
+		return 0, err
+	}
+
+	totalSize, success := s.instance.GetIntProperty("rocksdb.live-sst-files-size")
+	if !success {
+		return 0, ierrors.New("failed to get property rocksdb.live-sst-files-size")
+	}
+
+	if totalSize == 0 {
+		return memtableCount, nil
+	}
+
+	var totalMemtableCount uint64
+	for _, property := range []string{"rocksdb.num-entries-active-mem-table", "rocksdb.num-entries-imm-mem-tables"} {
+		entries, success := s.instance.GetIntProperty(property)
+		if !success {
+			return 0, ierrors.Errorf("failed to get property %s", property)
+		}
+		totalMemtableCount += entries
+	}
+
+	if totalMemtableCount >= totalCount {
+		return memtableCount, nil
+	}
+	diskCount := totalCount - totalMemtableCount
+
+	size, err := s.EstimateSize(prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	if size >= totalSize {
+		return diskCount + memtableCount, nil
+	}
+
+	return uint64(float64(diskCount)*(float64(size)/float64(totalSize))) + memtableCount, nil
+}
+
+// countMemtableKeys counts the keys with the given prefix that are held in the memtables and not flushed to disk yet.
+func (s *rocksDBStore) countMemtableKeys(keyPrefix []byte) (uint64, error) {
+	ro := grocksdb.NewDefaultReadOptions()
+	defer ro.Destroy()
+	ro.SetReadTier(memtableReadTier)
+
+	it := s.instance.db.NewIterator(ro)
 	defer it.Close()
 
+	var count uint64
 	for it.Seek(keyPrefix); it.ValidForPrefix(keyPrefix); it.Next() {
-		key := it.Key()
-		writeBatch.Delete(key.Data())
-		key.Free()
+		count++
+	}
+
+	if err := it.Err(); err != nil {
+		return 0, ierrors.Wrap(err, "failed to iterate the memtables")
 	}
 
-	return s.instance.db.Write(s.instance.wo, writeBatch)
+	return count, nil
 }
 
 func (s *rocksDBStore) Flush() error {
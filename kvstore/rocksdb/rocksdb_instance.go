@@ -14,6 +14,8 @@ type RocksDB struct {
 	ro *grocksdb.ReadOptions
 	wo *grocksdb.WriteOptions
 	fo *grocksdb.FlushOptions
+
+	compactAfterPrune bool
 }
 
 // CreateDB creates a new RocksDB instance.
@@ -64,10 +66,11 @@ func CreateDB(directory string, options ...Option) (*RocksDB, error) {
 	}
 
 	return &RocksDB{
-		db: db,
-		ro: ro,
-		wo: wo,
-		fo: fo,
+		db:                db,
+		ro:                ro,
+		wo:                wo,
+		fo:                fo,
+		compactAfterPrune: dbOpts.compactAfterPrune,
 	}, nil
 }
 
@@ -129,6 +132,12 @@ func (r *RocksDB) Close() error {
 	return nil
 }
 
+// CompactRange runs a manual compaction on the given range of keys.
+// A nil start or limit means the range is unbounded at that side.
+func (r *RocksDB) CompactRange(keyRange grocksdb.Range) {
+	r.db.CompactRange(keyRange)
+}
+
 // GetProperty returns the value of a database property.
 func (r *RocksDB) GetProperty(name string) string {
 	return r.db.GetProperty(name)
@@ -2,13 +2,14 @@ package rocksdb
 
 // Options holds the options used to instantiate the underlying grocksdb.DB.
 type Options struct {
-	compression    bool
-	fillCache      bool
-	sync           bool
-	disableWAL     bool
-	parallelism    int
-	blockCacheSize uint64
-	custom         []string
+	compression       bool
+	fillCache         bool
+	sync              bool
+	disableWAL        bool
+	parallelism       int
+	blockCacheSize    uint64
+	compactAfterPrune bool
+	custom            []string
 }
 
 // Option is one of the Options.
@@ -56,6 +57,13 @@ func BlockCacheSize(size uint64) Option {
 	}
 }
 
+// CompactAfterPrune triggers a manual compaction of the affected key range after DeletePrefix and Clear.
+func CompactAfterPrune(compact bool) Option {
+	return func(args *Options) {
+		args.compactAfterPrune = compact
+	}
+}
+
 // Custom passes the given string to GetOptionsFromString.
 func Custom(options []string) Option {
 	return func(args *Options) {
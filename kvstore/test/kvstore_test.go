@@ -117,6 +117,41 @@ func TestSetAndGetEmptyValue(t *testing.T) {
 	}
 }
 
+func TestGetMany(t *testing.T) {
+
+	prefix := []byte("testPrefix")
+	for _, dbImplementation := range dbImplementations {
+		store, err := testStore(t, dbImplementation, prefix)
+		require.NoError(t, err)
+
+		keys := make([]kvstore.Key, 0, len(testEntries)+1)
+		for _, entry := range testEntries {
+			err := store.Set(entry.Key, entry.Value)
+			require.NoError(t, err, "used db: %s", dbImplementation)
+
+			keys = append(keys, entry.Key)
+		}
+		keys = append(keys, []byte("unknownKey"))
+
+		values, exists, err := store.GetMany(keys)
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.Len(t, values, len(keys), "used db: %s", dbImplementation)
+		require.Len(t, exists, len(keys), "used db: %s", dbImplementation)
+
+		for i, entry := range testEntries {
+			require.True(t, exists[i], "used db: %s", dbImplementation)
+			require.Equal(t, entry.Value, values[i], "used db: %s", dbImplementation)
+		}
+		require.False(t, exists[len(testEntries)], "used db: %s", dbImplementation)
+		require.Nil(t, values[len(testEntries)], "used db: %s", dbImplementation)
+
+		values, exists, err = store.GetMany(nil)
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.Empty(t, values, "used db: %s", dbImplementation)
+		require.Empty(t, exists, "used db: %s", dbImplementation)
+	}
+}
+
 func TestDelete(t *testing.T) {
 
 	prefix := []byte("testPrefix")
@@ -554,6 +589,46 @@ func TestIteratePrefixKeyOnly(t *testing.T) {
 	}
 }
 
+func TestIterateKeysFrom(t *testing.T) {
+
+	prefix := []byte("testPrefix")
+	for _, dbImplementation := range dbImplementations {
+		store, err := testStore(t, dbImplementation, prefix)
+		require.NoError(t, err)
+
+		for i := 0; i < 100; i++ {
+			str := fmt.Sprintf("%02d", i)
+			require.NoError(t, store.Set([]byte("testKey"+str), []byte(str)), "used db: %s", dbImplementation)
+			require.NoError(t, store.Set([]byte("someOtherKey"+str), []byte(str)), "used db: %s", dbImplementation)
+		}
+
+		collectKeys := func(prefix []byte, start []byte, limit int) []string {
+			keys := make([]string, 0)
+			require.NoError(t, store.IterateKeysFrom(prefix, start, func(key kvstore.Key) bool {
+				keys = append(keys, string(key))
+
+				return len(keys) < limit
+			}), "used db: %s", dbImplementation)
+
+			return keys
+		}
+
+		// the start is inclusive and the iteration stops at the end of the prefix
+		require.Equal(t, []string{"testKey42", "testKey43"}, collectKeys([]byte("testKey"), []byte("testKey42"), 2), "used db: %s", dbImplementation)
+		require.Equal(t, []string{"testKey98", "testKey99"}, collectKeys([]byte("testKey"), []byte("testKey975"), 10), "used db: %s", dbImplementation)
+
+		// a start before the prefix iterates over all keys with the prefix
+		require.Equal(t, []string{"testKey00", "testKey01"}, collectKeys([]byte("testKey"), []byte("someOtherKey50"), 2), "used db: %s", dbImplementation)
+		require.Len(t, collectKeys([]byte("testKey"), nil, 1000), 100, "used db: %s", dbImplementation)
+
+		// a start after the prefix doesn't iterate over any key
+		require.Empty(t, collectKeys([]byte("someOtherKey"), []byte("testKey00"), 1000), "used db: %s", dbImplementation)
+
+		// the whole realm
+		require.Equal(t, []string{"someOtherKey99", "testKey00"}, collectKeys(kvstore.EmptyPrefix, []byte("someOtherKey99"), 2), "used db: %s", dbImplementation)
+	}
+}
+
 func TestDeletePrefix(t *testing.T) {
 
 	prefix := []byte("testPrefix")
@@ -630,6 +705,121 @@ func TestDeletePrefixIsEmpty(t *testing.T) {
 	}
 }
 
+func TestDeletePrefixWithoutUpperBound(t *testing.T) {
+
+	for _, dbImplementation := range dbImplementations {
+		// the realm has no upper bound, so the deletion has to cover all keys up to the last one
+		store, err := testStore(t, dbImplementation, []byte{0xff, 0xff})
+		require.NoError(t, err)
+
+		otherStore, err := store.WithRealm([]byte{0xff, 0xfe})
+		require.NoError(t, err, "used db: %s", dbImplementation)
+
+		for i := 0; i < 100; i++ {
+			err = store.Set([]byte{0xff, byte(i)}, []byte{byte(i)})
+			require.NoError(t, err, "used db: %s", dbImplementation)
+
+			err = otherStore.Set([]byte{0xff, byte(i)}, []byte{byte(i)})
+			require.NoError(t, err, "used db: %s", dbImplementation)
+		}
+
+		require.NoError(t, store.DeletePrefix([]byte{0xff}), "used db: %s", dbImplementation)
+		require.Equal(t, 0, countKeys(t, store), "used db: %s", dbImplementation)
+		require.Equal(t, 100, countKeys(t, otherStore), "used db: %s", dbImplementation)
+
+		require.NoError(t, otherStore.Set([]byte{0xff, 0xff, 0xff}, []byte{1}), "used db: %s", dbImplementation)
+		require.NoError(t, otherStore.Clear(), "used db: %s", dbImplementation)
+		require.Equal(t, 0, countKeys(t, otherStore), "used db: %s", dbImplementation)
+	}
+}
+
+func TestCompactRange(t *testing.T) {
+
+	prefix := []byte("testPrefix")
+	for _, dbImplementation := range dbImplementations {
+		store, err := testStore(t, dbImplementation, prefix)
+		require.NoError(t, err)
+
+		for i := 0; i < 1000; i++ {
+			str := strconv.FormatInt(int64(i), 10)
+			err = store.Set([]byte("testKey"+str), []byte("testValue"+str))
+			require.NoError(t, err, "used db: %s", dbImplementation)
+
+			err = store.Set([]byte("someOtherKey"+str), []byte(str))
+			require.NoError(t, err, "used db: %s", dbImplementation)
+		}
+
+		require.NoError(t, store.DeletePrefix([]byte("someOtherKey")), "used db: %s", dbImplementation)
+		require.NoError(t, store.CompactRange([]byte("someOtherKey")), "used db: %s", dbImplementation)
+		require.NoError(t, store.CompactRange(kvstore.EmptyPrefix), "used db: %s", dbImplementation)
+
+		// compacting must not change the content of the store
+		require.Equal(t, 1000, countKeys(t, store), "used db: %s", dbImplementation)
+
+		value, err := store.Get([]byte("testKey42"))
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.Equal(t, []byte("testValue42"), value, "used db: %s", dbImplementation)
+	}
+}
+
+func TestEstimateSizeAndCount(t *testing.T) {
+
+	prefix := []byte("testPrefix")
+	for _, dbImplementation := range dbImplementations {
+		store, err := testStore(t, dbImplementation, prefix)
+		require.NoError(t, err)
+
+		count := 1000
+
+		for i := 0; i < count; i++ {
+			str := fmt.Sprintf("%04d", i)
+			err = store.Set([]byte("testKey"+str), []byte("testValue"+str))
+			require.NoError(t, err, "used db: %s", dbImplementation)
+
+			err = store.Set([]byte("someOtherKey"+str), []byte(str))
+			require.NoError(t, err, "used db: %s", dbImplementation)
+		}
+		require.NoError(t, store.Flush(), "used db: %s", dbImplementation)
+
+		exactCount, err := kvstore.Count(store, []byte("testKey"))
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.EqualValues(t, count, exactCount, "used db: %s", dbImplementation)
+
+		exactSize, err := kvstore.Size(store, []byte("testKey"))
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.EqualValues(t, count*(len("testKey0000")+len("testValue0000")), exactSize, "used db: %s", dbImplementation)
+
+		estimatedCount, err := store.EstimateCount([]byte("testKey"))
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.InDelta(t, exactCount, estimatedCount, float64(count)/2, "used db: %s", dbImplementation)
+
+		estimatedSize, err := store.EstimateSize([]byte("testKey"))
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.Greater(t, estimatedSize, uint64(0), "used db: %s", dbImplementation)
+
+		estimatedSize, err = store.EstimateSize([]byte("unknownKey"))
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.Zero(t, estimatedSize, "used db: %s", dbImplementation)
+
+		// entries that were not flushed yet are taken into account
+		for i := 0; i < 3*count; i++ {
+			str := fmt.Sprintf("%04d", i)
+			err = store.Set([]byte("testKeyUnflushed"+str), []byte("testValue"+str))
+			require.NoError(t, err, "used db: %s", dbImplementation)
+		}
+
+		estimatedCount, err = store.EstimateCount([]byte("testKey"))
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.InDelta(t, 4*count, estimatedCount, float64(count)/2, "used db: %s", dbImplementation)
+
+		require.NoError(t, store.Clear(), "used db: %s", dbImplementation)
+
+		exactCount, err = kvstore.Count(store, kvstore.EmptyPrefix)
+		require.NoError(t, err, "used db: %s", dbImplementation)
+		require.Zero(t, exactCount, "used db: %s", dbImplementation)
+	}
+}
+
 func TestSetAndOverwrite(t *testing.T) {
 
 	prefix := []byte("testPrefix")
@@ -837,6 +1027,9 @@ func TestStoreClosed(t *testing.T) {
 		_, err = store.Get(kvstore.Key{0})
 		require.ErrorIs(t, err, kvstore.ErrStoreClosed, "used db: %s", dbImplementation)
 
+		_, _, err = store.GetMany([]kvstore.Key{{0}})
+		require.ErrorIs(t, err, kvstore.ErrStoreClosed, "used db: %s", dbImplementation)
+
 		err = store.Set(kvstore.Key{0}, []byte{1})
 		require.ErrorIs(t, err, kvstore.ErrStoreClosed, "used db: %s", dbImplementation)
 
@@ -849,6 +1042,15 @@ func TestStoreClosed(t *testing.T) {
 		err = store.DeletePrefix(kvstore.EmptyPrefix)
 		require.ErrorIs(t, err, kvstore.ErrStoreClosed, "used db: %s", dbImplementation)
 
+		err = store.CompactRange(kvstore.EmptyPrefix)
+		require.ErrorIs(t, err, kvstore.ErrStoreClosed, "used db: %s", dbImplementation)
+
+		_, err = store.EstimateSize(kvstore.EmptyPrefix)
+		require.ErrorIs(t, err, kvstore.ErrStoreClosed, "used db: %s", dbImplementation)
+
+		_, err = store.EstimateCount(kvstore.EmptyPrefix)
+		require.ErrorIs(t, err, kvstore.ErrStoreClosed, "used db: %s", dbImplementation)
+
 		err = store.Flush()
 		require.ErrorIs(t, err, kvstore.ErrStoreClosed, "used db: %s", dbImplementation)
 
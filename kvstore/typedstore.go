@@ -60,6 +60,35 @@ func (t *TypedStore[K, V]) Get(key K) (value V, err error) {
 	return v, nil
 }
 
+// GetMany gets the values for the given keys or an error if an error occurred.
+// For each key that doesn't exist, the value is the zero value and the corresponding exists flag is false.
+func (t *TypedStore[K, V]) GetMany(keys []K) (values []V, exists []bool, err error) {
+	keysBytes := make([]Key, len(keys))
+	for i, key := range keys {
+		if keysBytes[i], err = t.keyToBytes(key); err != nil {
+			return nil, nil, ierrors.Wrap(err, "failed to encode key")
+		}
+	}
+
+	valuesBytes, exists, err := t.kv.GetMany(keysBytes)
+	if err != nil {
+		return nil, nil, ierrors.Wrap(err, "failed to retrieve from KV store")
+	}
+
+	values = make([]V, len(keys))
+	for i, valueBytes := range valuesBytes {
+		if !exists[i] {
+			continue
+		}
+
+		if values[i], _, err = t.bytesToValue(valueBytes); err != nil {
+			return nil, nil, ierrors.Wrap(err, "failed to decode value")
+		}
+	}
+
+	return values, exists, nil
+}
+
 // Has checks whether the given key exists.
 func (t *TypedStore[K, V]) Has(key K) (has bool, err error) {
 	keyBytes, err := t.keyToBytes(key)
@@ -152,6 +181,10 @@ func (t *TypedStore[K, V]) DeletePrefix(prefix KeyPrefix) error {
 	return t.kv.DeletePrefix(prefix)
 }
 
+func (t *TypedStore[K, V]) CompactRange(prefix KeyPrefix) error {
+	return t.kv.CompactRange(prefix)
+}
+
 func (t *TypedStore[K, V]) Clear() error {
 	return t.kv.Clear()
 }
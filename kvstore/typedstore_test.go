@@ -0,0 +1,30 @@
+package kvstore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+func TestTypedStoreGetMany(t *testing.T) {
+	kvStore := mapdb.NewMapDB()
+	defer kvStore.Close()
+
+	typedStore := kvstore.NewTypedStore[int, int](kvStore, intToBytes, bytesToInt, intToBytes, bytesToInt)
+
+	require.NoError(t, typedStore.Set(1, 10))
+	require.NoError(t, typedStore.Set(3, 30))
+
+	values, exists, err := typedStore.GetMany([]int{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 0, 30}, values)
+	require.Equal(t, []bool{true, false, true}, exists)
+
+	values, exists, err = typedStore.GetMany(nil)
+	require.NoError(t, err)
+	require.Empty(t, values)
+	require.Empty(t, exists)
+}
@@ -1,6 +1,9 @@
 package serializer
 
-import "math"
+import (
+	"encoding/binary"
+	"math"
+)
 
 const (
 	// OneByte is the byte size of a single byte.
@@ -23,6 +26,8 @@ const (
 	UInt256ByteSize = 32
 	// Float64ByteSize is the byte size of a float64.
 	Float64ByteSize = 8
+	// MaxVarintByteSize is the maximum byte size of a variable-length encoded uint64.
+	MaxVarintByteSize = binary.MaxVarintLen64
 	// TypeDenotationByteSize is the size of a type denotation.
 	TypeDenotationByteSize = UInt32ByteSize
 	// SmallTypeDenotationByteSize is the size of a type denotation for a small range of possible values.
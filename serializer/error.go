@@ -52,6 +52,8 @@ var (
 	ErrUint256TooBig = ierrors.New("uint256 big int is too big")
 	// ErrUint256Nil gets returned when a uint256 *big.Int is nil.
 	ErrUint256Nil = ierrors.New("uint256 must not be nil")
+	// ErrVarintInvalid gets returned when a variable-length integer overflows or is not encoded in its shortest form.
+	ErrVarintInvalid = ierrors.New("invalid variable-length integer")
 )
 
 // CheckType checks that the denoted type equals the shouldType.
@@ -42,6 +42,9 @@ const (
 	SeriLengthPrefixTypeAsUint32
 	// SeriLengthPrefixTypeAsUint64 defines a collection length to be denoted by an uint64.
 	SeriLengthPrefixTypeAsUint64
+	// SeriLengthPrefixTypeAsVarint defines a collection length to be denoted by an unsigned LEB128 variable-length
+	// integer, which takes a single byte for lengths up to 127.
+	SeriLengthPrefixTypeAsVarint
 )
 
 // NewSerializer creates a new Serializer.
@@ -245,6 +248,17 @@ func (s *Serializer) writeSliceLength(l int, lenType SeriLengthPrefixType, errPr
 		if err := binary.Write(&s.buf, binary.LittleEndian, uint32(l)); err != nil {
 			s.err = errProducer(err)
 
+			return
+		}
+	case SeriLengthPrefixTypeAsVarint:
+		if l < 0 {
+			s.err = errProducer(ierrors.Errorf("unable to serialize collection length: length %d is negative", l))
+
+			return
+		}
+		if _, err := s.buf.Write(binary.AppendUvarint(nil, uint64(l))); err != nil {
+			s.err = errProducer(err)
+
 			return
 		}
 	default:
@@ -825,6 +839,17 @@ func (d *Deserializer) readSliceLength(lenType SeriLengthPrefixType, errProducer
 		l = UInt32ByteSize
 		sliceLength = int(binary.LittleEndian.Uint32(d.src[d.offset : d.offset+UInt32ByteSize]))
 
+	case SeriLengthPrefixTypeAsVarint:
+		length, bytesRead, err := ReadVarint(d.src[d.offset:])
+		if err != nil {
+			return 0, errProducer(err)
+		}
+		if length > math.MaxInt {
+			return 0, errProducer(ierrors.Wrapf(ErrDeserializationLengthInvalid, "length %d is out of range", length))
+		}
+		l = bytesRead
+		sliceLength = int(length)
+
 	default:
 		panic(fmt.Sprintf("unknown slice length type %v", lenType))
 	}
@@ -225,6 +225,56 @@ func TestDeserializer_ReadString(t *testing.T) {
 	}
 }
 
+func TestReadWriteVarintLength(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		prefix []byte
+	}{
+		{name: "empty", length: 0, prefix: []byte{0}},
+		{name: "single byte", length: 127, prefix: []byte{0x7f}},
+		{name: "two bytes", length: 128, prefix: []byte{0x80, 0x01}},
+		{name: "three bytes", length: 20000, prefix: []byte{0xa0, 0x9c, 0x01}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := bytes.Repeat([]byte{1}, tt.length)
+			serialized, err := serializer.NewSerializer().
+				WriteVariableByteSlice(data, serializer.SeriLengthPrefixTypeAsVarint, func(err error) error { return err }, 0, 0).
+				Serialize()
+			require.NoError(t, err)
+			require.Equal(t, append(tt.prefix, data...), serialized)
+			require.Equal(t, len(tt.prefix), serializer.VarintSize(uint64(tt.length)))
+
+			var deserialized []byte
+			bytesRead, err := serializer.NewDeserializer(serialized).
+				ReadVariableByteSlice(&deserialized, serializer.SeriLengthPrefixTypeAsVarint, func(err error) error { return err }, 0, 0).
+				Done()
+			require.NoError(t, err)
+			require.Equal(t, len(serialized), bytesRead)
+			require.Equal(t, data, deserialized)
+		})
+	}
+
+	invalid := map[string]struct {
+		data []byte
+		err  error
+	}{
+		"not shortest form": {data: []byte{0x81, 0x00, 1}, err: serializer.ErrVarintInvalid},
+		"overflow":          {data: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02}, err: serializer.ErrVarintInvalid},
+		"out of range":      {data: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, err: serializer.ErrDeserializationLengthInvalid},
+		"not enough data":   {data: []byte{0x80}, err: serializer.ErrDeserializationNotEnoughData},
+	}
+	for name, tt := range invalid {
+		var deserialized []byte
+		_, err := serializer.NewDeserializer(tt.data).
+			ReadVariableByteSlice(&deserialized, serializer.SeriLengthPrefixTypeAsVarint, func(err error) error { return err }, 0, 0).
+			Done()
+		require.ErrorIs(t, err, tt.err, name)
+	}
+}
+
 func TestReadWriteNum(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,233 @@
+package serix
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+var (
+	// ErrNonCanonicalizableJSON gets returned when JSON can't be transformed into its canonical form.
+	ErrNonCanonicalizableJSON = ierrors.New("JSON can't be canonicalized")
+)
+
+// WithCanonicalJSON returns an Option that lets API.JSONEncode produce the canonical form of the JSON representation
+// as defined by RFC 8785 (JSON Canonicalization Scheme): the keys of objects are sorted, numbers are normalized and no
+// insignificant whitespace is written. The canonical form is deterministic, so it can be hashed and signed.
+func WithCanonicalJSON() Option {
+	return func(o *options) {
+		o.canonicalJSON = true
+	}
+}
+
+// canonicalizeJSON transforms the given JSON into its canonical form as defined by RFC 8785.
+func canonicalizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var buf bytes.Buffer
+	if err := writeCanonicalJSONValue(&buf, decoder); err != nil {
+		return nil, ierrors.Join(ErrNonCanonicalizableJSON, err)
+	}
+
+	if _, err := decoder.Token(); !ierrors.Is(err, io.EOF) {
+		return nil, ierrors.Wrap(ErrNonCanonicalizableJSON, "unexpected data after the top-level value")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeCanonicalJSONValue(buf *bytes.Buffer, decoder *json.Decoder) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		switch token {
+		case '{':
+			return writeCanonicalJSONObject(buf, decoder)
+		case '[':
+			return writeCanonicalJSONArray(buf, decoder)
+		default:
+			return ierrors.Errorf("unexpected delimiter %s", token)
+		}
+	case json.Number:
+		return writeCanonicalJSONNumber(buf, token)
+	case string:
+		writeCanonicalJSONString(buf, token)
+	case bool:
+		buf.WriteString(strconv.FormatBool(token))
+	case nil:
+		buf.WriteString("null")
+	default:
+		return ierrors.Errorf("unexpected token %v", token)
+	}
+
+	return nil
+}
+
+func writeCanonicalJSONObject(buf *bytes.Buffer, decoder *json.Decoder) error {
+	type member struct {
+		key   string
+		value []byte
+	}
+
+	var members []member
+	for decoder.More() {
+		keyToken, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := keyToken.(string)
+		if !ok {
+			return ierrors.Errorf("unexpected object key %v", keyToken)
+		}
+
+		var valueBuf bytes.Buffer
+		if err := writeCanonicalJSONValue(&valueBuf, decoder); err != nil {
+			return ierrors.Wrapf(err, "failed to canonicalize the value of key %s", key)
+		}
+		members = append(members, member{key: key, value: valueBuf.Bytes()})
+	}
+
+	// consume the closing delimiter
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	// the keys are sorted by their UTF-16 code units
+	slices.SortFunc(members, func(a, b member) int {
+		return slices.Compare(utf16.Encode([]rune(a.key)), utf16.Encode([]rune(b.key)))
+	})
+
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			if m.key == members[i-1].key {
+				return ierrors.Errorf("duplicate object key %s", m.key)
+			}
+			buf.WriteByte(',')
+		}
+		writeCanonicalJSONString(buf, m.key)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+func writeCanonicalJSONArray(buf *bytes.Buffer, decoder *json.Decoder) error {
+	buf.WriteByte('[')
+	for i := 0; decoder.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeCanonicalJSONValue(buf, decoder); err != nil {
+			return ierrors.Wrapf(err, "failed to canonicalize array element %d", i)
+		}
+	}
+	buf.WriteByte(']')
+
+	// consume the closing delimiter
+	_, err := decoder.Token()
+
+	return err
+}
+
+// writeCanonicalJSONNumber writes the number like ECMAScript's Number.prototype.toString serializes the closest
+// IEEE 754 double.
+func writeCanonicalJSONNumber(buf *bytes.Buffer, number json.Number) error {
+	value, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return ierrors.Errorf("number %s can't be represented as IEEE 754 double", number)
+	}
+
+	if value == 0 {
+		// this also normalizes negative zero
+		buf.WriteByte('0')
+
+		return nil
+	}
+	if value < 0 {
+		buf.WriteByte('-')
+		value = -value
+	}
+
+	// the shortest digits that represent the value and the decimal exponent of the first digit
+	mantissa, exponentRaw, _ := strings.Cut(strconv.FormatFloat(value, 'e', -1, 64), "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	exponent, _ := strconv.Atoi(exponentRaw)
+
+	// the position of the decimal point relative to the digits
+	point := exponent + 1
+	switch {
+	case len(digits) <= point && point <= 21:
+		buf.WriteString(digits)
+		buf.WriteString(strings.Repeat("0", point-len(digits)))
+	case 0 < point && point <= 21:
+		buf.WriteString(digits[:point])
+		buf.WriteByte('.')
+		buf.WriteString(digits[point:])
+	case -6 < point && point <= 0:
+		buf.WriteString("0.")
+		buf.WriteString(strings.Repeat("0", -point))
+		buf.WriteString(digits)
+	default:
+		buf.WriteString(digits[:1])
+		if len(digits) > 1 {
+			buf.WriteByte('.')
+			buf.WriteString(digits[1:])
+		}
+		buf.WriteByte('e')
+		if exponent > 0 {
+			buf.WriteByte('+')
+		}
+		buf.WriteString(strconv.Itoa(exponent))
+	}
+
+	return nil
+}
+
+// writeCanonicalJSONString writes the string with only the characters escaped that must be escaped.
+func writeCanonicalJSONString(buf *bytes.Buffer, str string) {
+	const hexDigits = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[r>>4])
+				buf.WriteByte(hexDigits[r&0xF])
+
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
@@ -0,0 +1,87 @@
+package serix_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+// canonicalRawJSON is serialized into the given JSON.
+type canonicalRawJSON string
+
+func (r canonicalRawJSON) EncodeJSON() (any, error) {
+	return json.RawMessage(r), nil
+}
+
+type canonicalObject struct {
+	Zeta  uint8             `serix:""`
+	Alpha string            `serix:",lenPrefix=uint8"`
+	Raw   canonicalRawJSON  `serix:""`
+	Map   map[uint64]uint64 `serix:",lenPrefix=uint8"`
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	api := serix.NewAPI()
+	ctx := context.Background()
+
+	obj := &canonicalObject{
+		Zeta:  1,
+		Alpha: "<€>\u000f\n",
+		// the example of RFC 8785
+		Raw: `{"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+			"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+			"literals": [null, true, false]}`,
+		Map: map[uint64]uint64{10: 1, 2: 2},
+	}
+
+	encoded, err := api.JSONEncode(ctx, obj)
+	require.NoError(t, err)
+	// the default encoding keeps the order of the struct fields
+	require.Regexp(t, `^{"zeta":1,"alpha":`, string(encoded))
+
+	canonical, err := api.JSONEncode(ctx, obj, serix.WithCanonicalJSON())
+	require.NoError(t, err)
+	require.Equal(t, `{"alpha":"<€>\u000f\n","map":{"10":"1","2":"2"},"raw":{"literals":[null,true,false],`+
+		`"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"},"zeta":1}`, string(canonical))
+
+	// the canonical form doesn't change when it is canonicalized again
+	nested, err := api.JSONEncode(ctx, &canonicalObject{Raw: canonicalRawJSON(canonical)}, serix.WithCanonicalJSON())
+	require.NoError(t, err)
+	require.Contains(t, string(nested), `"raw":`+string(canonical)+`,`)
+}
+
+func TestCanonicalJSONNumbers(t *testing.T) {
+	api := serix.NewAPI()
+	ctx := context.Background()
+
+	tests := map[string]string{
+		"0":                      "0",
+		"-0":                     "0",
+		"-0.0e5":                 "0",
+		"1.0":                    "1",
+		"100":                    "100",
+		"1e21":                   "1e+21",
+		"123456789012345678901":  "123456789012345680000",
+		"0.000001":               "0.000001",
+		"0.0000001":              "1e-7",
+		"-1.5e-10":               "-1.5e-10",
+		"9007199254740993":       "9007199254740992",
+		"1.7976931348623157e308": "1.7976931348623157e+308",
+		"5e-324":                 "5e-324",
+	}
+
+	for number, expected := range tests {
+		canonical, err := api.JSONEncode(ctx, &canonicalObject{Raw: canonicalRawJSON(number)}, serix.WithCanonicalJSON())
+		require.NoError(t, err, number)
+		require.Contains(t, string(canonical), `"raw":`+expected+`,`, number)
+	}
+
+	for _, raw := range []string{"1e400", `{"a":1,"a":2}`} {
+		_, err := api.JSONEncode(ctx, &canonicalObject{Raw: canonicalRawJSON(raw)}, serix.WithCanonicalJSON())
+		require.ErrorIs(t, err, serix.ErrNonCanonicalizableJSON, raw)
+	}
+}
@@ -0,0 +1,761 @@
+package serix
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2"
+)
+
+var (
+	// ErrGeneratedCodeMismatch gets returned if the generated methods of an object don't produce the same result as the
+	// serialization via reflection.
+	ErrGeneratedCodeMismatch = ierrors.New("generated code doesn't match the serialization via reflection")
+)
+
+var (
+	byteType                       = reflect.TypeOf(byte(0))
+	serializableType               = reflect.TypeOf((*Serializable)(nil)).Elem()
+	deserializableType             = reflect.TypeOf((*Deserializable)(nil)).Elem()
+	contextAwareDeserializableType = reflect.TypeOf((*ContextAwareDeserializable)(nil)).Elem()
+	generatedSerializableType      = reflect.TypeOf((*GeneratedSerializable)(nil)).Elem()
+	generatedDeserializableType    = reflect.TypeOf((*GeneratedDeserializable)(nil)).Elem()
+	generatedSerializableJSONType  = reflect.TypeOf((*GeneratedSerializableJSON)(nil)).Elem()
+	generatedCodeType              = reflect.TypeOf((*GeneratedCode)(nil)).Elem()
+)
+
+// GenerateCode generates the source code of a file of the given package that contains the SerixEncode, SerixDecode
+// and SerixEncodeJSON methods (see GeneratedSerializable) of the given structs.
+//
+// The generated methods serialize the following fields themselves, based on the serix struct tags and the registered
+// type settings:
+//   - bools, numbers, strings with a length prefix and byte arrays/slices (with a length prefix),
+//   - structs and pointers to structs of the same package whose methods are generated as well,
+//   - slices (with a length prefix) of such structs or pointers to them, with at most min/max length array rules.
+//
+// All other fields, as well as fields that are customized via optional/inlined/omitempty tags, protocol versions,
+// registered validators, custom serialization methods, max byte sizes or value rules, are delegated to the
+// serialization via reflection (see API.EncodeStructField). The structs must be defined in the package of the
+// generated file.
+//
+// The generated code also contains the fingerprint of the struct tags and type settings it was generated for (see
+// GeneratedCode). An API only uses the generated methods if its own settings produce the same fingerprint and falls
+// back to reflection otherwise, so the code needs to be regenerated whenever the struct tags or the registered type
+// settings of the structs or their fields change, see API.VerifyGeneratedCode.
+func (api *API) GenerateCode(packageName string, objs ...any) ([]byte, error) {
+	generator := newCodeGenerator(api)
+
+	structTypes := make([]reflect.Type, 0, len(objs))
+	for _, obj := range objs {
+		structType := DeRefPointer(reflect.TypeOf(obj))
+		if structType.Kind() != reflect.Struct || structType.Name() == "" || strings.Contains(structType.Name(), "[") {
+			return nil, ierrors.Errorf("can't generate code for %T: only named non-generic structs are supported", obj)
+		}
+
+		if len(structTypes) != 0 && structType.PkgPath() != structTypes[0].PkgPath() {
+			return nil, ierrors.Errorf("can't generate code for %T: all structs must be defined in package %s", obj, structTypes[0].PkgPath())
+		}
+
+		structTypes = append(structTypes, structType)
+		generator.generated[structType] = true
+	}
+
+	// the fingerprints also cover nested structs that are not part of the generated file, so they are computed by a
+	// separate generator to not add their imports to the file
+	fingerprints := newCodeGenerator(api)
+	fingerprints.generated = generator.generated
+
+	for _, structType := range structTypes {
+		generated, err := generator.generateStruct(structType)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "can't generate code for %s", structType)
+		}
+
+		fingerprint, err := fingerprints.fingerprint(structType)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "can't generate code for %s", structType)
+		}
+
+		generator.methods.WriteString(generated.source)
+		fmt.Fprintf(&generator.methods, "\n// SerixFingerprint returns the fingerprint of the struct tags and type settings the methods of %s were generated for.\nfunc (%s %s) SerixFingerprint() string {\nreturn %s\n}\n",
+			structType.Name(), generated.receiver, structType.Name(), strconv.Quote(fingerprint))
+	}
+
+	source, err := format.Source(generator.file(packageName))
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to format generated code")
+	}
+
+	return source, nil
+}
+
+// VerifyGeneratedCode checks that the generated methods of the given objects produce exactly the same results as the
+// serialization via reflection, with and without validation.
+// The objects should contain representative values, since only the given values are compared.
+func (api *API) VerifyGeneratedCode(ctx context.Context, objs ...any) error {
+	for _, obj := range objs {
+		if err := api.verifyGeneratedCode(ctx, obj); err != nil {
+			return ierrors.Wrapf(err, "failed to verify generated code of %T", obj)
+		}
+	}
+
+	return nil
+}
+
+func (api *API) verifyGeneratedCode(ctx context.Context, obj any) error {
+	structType := DeRefPointer(reflect.TypeOf(obj))
+	if !hasGeneratedMethods(structType) {
+		return ierrors.Wrap(ErrGeneratedCodeMismatch, "type has no generated methods")
+	}
+
+	if !api.generatedCodeMatches(structType, reflect.New(structType).Interface()) {
+		return ierrors.Wrap(ErrGeneratedCodeMismatch, "the code was generated with different struct tags or type settings")
+	}
+
+	compare := func(description string, format string, generated []byte, generatedErr error, reflection []byte, reflectionErr error) error {
+		if (generatedErr == nil) != (reflectionErr == nil) {
+			return ierrors.Wrapf(ErrGeneratedCodeMismatch, "%s: generated error '%v', reflection error '%v'", description, generatedErr, reflectionErr)
+		}
+
+		if !bytes.Equal(generated, reflection) {
+			return ierrors.Wrapf(ErrGeneratedCodeMismatch, "%s: generated "+format+", reflection "+format, description, generated, reflection)
+		}
+
+		return nil
+	}
+
+	for _, opts := range [][]Option{{}, {WithValidation()}} {
+		reflectionOpts := append([]Option{WithoutGeneratedCode()}, opts...)
+
+		generatedBytes, generatedErr := api.Encode(ctx, obj, opts...)
+		reflectionBytes, reflectionErr := api.Encode(ctx, obj, reflectionOpts...)
+		if err := compare("encode", "%x", generatedBytes, generatedErr, reflectionBytes, reflectionErr); err != nil {
+			return err
+		}
+
+		generatedJSON, generatedErr := api.JSONEncode(ctx, obj, opts...)
+		reflectionJSON, reflectionErr := api.JSONEncode(ctx, obj, reflectionOpts...)
+		if err := compare("JSON encode", "%s", generatedJSON, generatedErr, reflectionJSON, reflectionErr); err != nil {
+			return err
+		}
+
+		if reflectionErr != nil {
+			continue
+		}
+
+		generatedObj, reflectionObj := reflect.New(structType), reflect.New(structType)
+		generatedRead, generatedErr := api.Decode(ctx, reflectionBytes, generatedObj.Interface(), opts...)
+		reflectionRead, reflectionErr := api.Decode(ctx, reflectionBytes, reflectionObj.Interface(), reflectionOpts...)
+		if err := compare("decode", "%x", nil, generatedErr, nil, reflectionErr); err != nil {
+			return err
+		}
+
+		if generatedRead != reflectionRead || !reflect.DeepEqual(generatedObj.Interface(), reflectionObj.Interface()) {
+			return ierrors.Wrapf(ErrGeneratedCodeMismatch, "decode: generated %+v (%d bytes), reflection %+v (%d bytes)",
+				generatedObj.Elem().Interface(), generatedRead, reflectionObj.Elem().Interface(), reflectionRead)
+		}
+	}
+
+	return nil
+}
+
+// codeGenerator generates the methods of the structs for which code is generated.
+type codeGenerator struct {
+	api *API
+	// imports holds the import paths that are used by the generated code.
+	imports map[string]bool
+	// generated holds the structs whose methods are generated together with the current ones.
+	generated map[reflect.Type]bool
+	// structs caches the generated methods of the structs.
+	structs map[reflect.Type]*generatedStruct
+	// methods holds the generated methods.
+	methods bytes.Buffer
+}
+
+// generatedStruct holds the generated methods of a single struct.
+type generatedStruct struct {
+	// receiver is the name of the receiver of the methods.
+	receiver string
+	// source is the code of the methods.
+	source string
+	// nested holds the structs whose generated methods are called by the methods.
+	nested []reflect.Type
+}
+
+// generatedField holds the code of a single struct field in the generated methods.
+type generatedField struct {
+	encode string
+	decode string
+	json   string
+	// nested is the struct whose generated methods are called for the field (nil if there is none).
+	nested reflect.Type
+}
+
+func newCodeGenerator(api *API) *codeGenerator {
+	return &codeGenerator{
+		api:       api,
+		imports:   make(map[string]bool),
+		generated: make(map[reflect.Type]bool),
+		structs:   make(map[reflect.Type]*generatedStruct),
+	}
+}
+
+// file returns the source of the generated file.
+func (g *codeGenerator) file(packageName string) []byte {
+	g.imports["context"] = true
+	g.imports["github.com/iancoleman/orderedmap"] = true
+	g.imports[reflect.TypeOf(serializer.Serializer{}).PkgPath()] = true
+	g.imports[reflect.TypeOf(API{}).PkgPath()] = true
+
+	// the imports are grouped into standard library, third party and hive.go imports
+	importGroups := make([][]string, 3)
+	for importPath := range g.imports {
+		switch {
+		case strings.HasPrefix(importPath, "github.com/iotaledger/hive.go/"):
+			importGroups[2] = append(importGroups[2], strconv.Quote(importPath))
+		case strings.Contains(importPath, "."):
+			importGroups[1] = append(importGroups[1], strconv.Quote(importPath))
+		default:
+			importGroups[0] = append(importGroups[0], strconv.Quote(importPath))
+		}
+	}
+
+	file := new(bytes.Buffer)
+	fmt.Fprintf(file, "// Code generated by serixgen; DO NOT EDIT.\n\npackage %s\n\n", packageName)
+	file.WriteString("import (\n")
+	for _, importGroup := range importGroups {
+		sort.Strings(importGroup)
+		fmt.Fprintf(file, "%s\n\n", strings.Join(importGroup, "\n"))
+	}
+	file.WriteString(")\n")
+	file.Write(g.methods.Bytes())
+
+	return file.Bytes()
+}
+
+// fingerprint returns the fingerprint of the generated methods of the given struct and of the nested structs whose
+// generated methods they call. The fingerprint changes if any struct tag or type setting that is part of the generated
+// code changes.
+func (g *codeGenerator) fingerprint(structType reflect.Type) (string, error) {
+	reachable := make(map[reflect.Type]*generatedStruct)
+
+	var collect func(structType reflect.Type) error
+	collect = func(structType reflect.Type) error {
+		if _, collected := reachable[structType]; collected {
+			return nil
+		}
+
+		generated, err := g.generateStruct(structType)
+		if err != nil {
+			return err
+		}
+		reachable[structType] = generated
+
+		for _, nested := range generated.nested {
+			if err := collect(nested); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := collect(structType); err != nil {
+		return "", err
+	}
+
+	// the structs are hashed in a fixed order, so that the fingerprint doesn't depend on where the traversal started
+	structTypes := make([]reflect.Type, 0, len(reachable))
+	for reachableType := range reachable {
+		structTypes = append(structTypes, reachableType)
+	}
+	sort.Slice(structTypes, func(i, j int) bool {
+		return structTypes[i].String() < structTypes[j].String()
+	})
+
+	hasher := sha256.New()
+	for _, reachableType := range structTypes {
+		fmt.Fprintf(hasher, "%s\n%s\n", reachableType, reachable[reachableType].source)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)[:16]), nil
+}
+
+// generateStruct generates the methods of the given struct.
+func (g *codeGenerator) generateStruct(structType reflect.Type) (*generatedStruct, error) {
+	if generated, exists := g.structs[structType]; exists {
+		return generated, nil
+	}
+
+	for _, implementedType := range []reflect.Type{serializableType, deserializableType, serializableJSONType} {
+		if structType.Implements(implementedType) || reflect.PointerTo(structType).Implements(implementedType) {
+			return nil, ierrors.Errorf("the struct implements %s", implementedType)
+		}
+	}
+
+	structFields, err := g.api.getStructFields(structType)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "can't parse struct type %s", structType)
+	}
+
+	generated := &generatedStruct{
+		receiver: "x",
+		nested:   make([]reflect.Type, 0),
+	}
+	if firstRune := []rune(structType.Name())[0]; unicode.IsLetter(firstRune) {
+		generated.receiver = string(unicode.ToLower(firstRune))
+	}
+
+	fields := make([]generatedField, 0, len(structFields))
+	for _, sField := range structFields {
+		field, err := g.generateField(structType, sField)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "can't generate code for struct field %s", sField.name)
+		}
+		fields = append(fields, field)
+
+		if field.nested != nil {
+			generated.nested = append(generated.nested, field.nested)
+		}
+	}
+
+	var methods strings.Builder
+	writeMethod := func(comment string, signature string, code func(field generatedField) string) {
+		var body strings.Builder
+		for _, field := range fields {
+			body.WriteString(strings.ReplaceAll(code(field), "$x", generated.receiver))
+		}
+
+		fmt.Fprintf(&methods, "\n// %s\nfunc (%s %s) error {\n", comment, generated.receiver, signature)
+		if strings.Contains(body.String(), "if validation") {
+			methods.WriteString("validation := serix.ValidationEnabled(opts...)\n\n")
+		}
+		methods.WriteString(body.String())
+		methods.WriteString("\nreturn nil\n}\n")
+	}
+
+	name := structType.Name()
+	writeMethod(
+		fmt.Sprintf("SerixEncode serializes the fields of %s.", name),
+		fmt.Sprintf("%s) SerixEncode(ctx context.Context, api *serix.API, seri *serializer.Serializer, opts ...serix.Option", name),
+		func(field generatedField) string { return field.encode },
+	)
+	writeMethod(
+		fmt.Sprintf("SerixDecode deserializes the fields of %s.", name),
+		fmt.Sprintf("*%s) SerixDecode(ctx context.Context, api *serix.API, deseri *serializer.Deserializer, opts ...serix.Option", name),
+		func(field generatedField) string { return field.decode },
+	)
+	writeMethod(
+		fmt.Sprintf("SerixEncodeJSON serializes the fields of %s into the given ordered map.", name),
+		fmt.Sprintf("%s) SerixEncodeJSON(ctx context.Context, api *serix.API, obj *orderedmap.OrderedMap, opts ...serix.Option", name),
+		func(field generatedField) string { return field.json },
+	)
+	generated.source = methods.String()
+
+	g.structs[structType] = generated
+
+	return generated, nil
+}
+
+// generateField generates the code of the given struct field. Fields that are not serialized by the generated code
+// itself are delegated to the serialization via reflection.
+func (g *codeGenerator) generateField(structType reflect.Type, sField structField) (generatedField, error) {
+	ts, nested, native := g.nativeTypeSettings(structType, sField)
+	if !native {
+		return generatedField{
+			encode: fmt.Sprintf("if err := api.EncodeStructField(ctx, seri, &$x, %d, opts...); err != nil {\nreturn err\n}\n", sField.index),
+			decode: fmt.Sprintf("if err := api.DecodeStructField(ctx, deseri, $x, %d, opts...); err != nil {\nreturn err\n}\n", sField.index),
+			json:   fmt.Sprintf("if err := api.MapEncodeStructField(ctx, obj, &$x, %d, opts...); err != nil {\nreturn err\n}\n", sField.index),
+		}, nil
+	}
+
+	g.imports["github.com/iotaledger/hive.go/ierrors"] = true
+
+	field := "$x." + sField.name
+	fieldKey, set := sField.settings.ts.FieldKey()
+	if !set {
+		fieldKey = FieldKeyString(sField.name)
+	}
+	errProducer := fmt.Sprintf("func(err error) error {\nreturn ierrors.Wrap(err, %s)\n}", strconv.Quote("failed to serialize struct field "+sField.name))
+	decodeErrProducer := fmt.Sprintf("func(err error) error {\nreturn ierrors.Wrap(err, %s)\n}", strconv.Quote("failed to deserialize struct field "+sField.name))
+	minLen, maxLen := ts.MinMaxLen()
+
+	if nested != nil {
+		if sField.fType.Kind() == reflect.Slice {
+			return g.generateSliceOfStructsField(sField, ts, nested, fieldKey, errProducer, decodeErrProducer), nil
+		}
+
+		return g.generateStructField(sField, ts, nested, fieldKey), nil
+	}
+
+	var generated generatedField
+	var jsonValue, nonEmpty string
+	switch kind := sField.fType.Kind(); kind {
+	case reflect.Bool:
+		generated.encode = fmt.Sprintf("seri.WriteBool(bool(%s), %s)\n", field, errProducer)
+		generated.decode = fmt.Sprintf("deseri.ReadBool((*bool)(&%s), %s)\n", field, decodeErrProducer)
+		jsonValue, nonEmpty = fmt.Sprintf("bool(%s)", field), field
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		generated.encode = fmt.Sprintf("seri.WriteNum(%s(%s), %s)\n", kind, field, errProducer)
+		generated.decode = fmt.Sprintf("deseri.ReadNum((*%s)(&%s), %s)\n", kind, field, decodeErrProducer)
+		nonEmpty = field + " != 0"
+
+		switch kind {
+		case reflect.Int8, reflect.Int16, reflect.Int32:
+			jsonValue = fmt.Sprintf("int64(%s)", field)
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+			jsonValue = fmt.Sprintf("uint64(%s)", field)
+		case reflect.Int64:
+			jsonValue = fmt.Sprintf("strconv.FormatInt(int64(%s), 10)", field)
+			g.imports["strconv"] = true
+		case reflect.Uint64:
+			jsonValue = fmt.Sprintf("strconv.FormatUint(uint64(%s), 10)", field)
+			g.imports["strconv"] = true
+		default:
+			jsonValue = fmt.Sprintf("strconv.FormatFloat(float64(%s), 'g', -1, 64)", field)
+			g.imports["strconv"] = true
+		}
+
+	case reflect.String:
+		g.imports["unicode/utf8"] = true
+		lengthPrefixType, _ := ts.LengthPrefixType()
+		validation := fmt.Sprintf("if !utf8.ValidString(string(%s)) {\nreturn ierrors.Errorf(\"can't serialize 'string' type: %%w\", serix.ErrNonUTF8String)\n}\n", field)
+		if minLen != 0 || maxLen != 0 {
+			validation = fmt.Sprintf("if err := serix.CheckLengthBounds(len(%s), %d, %d); err != nil {\nreturn ierrors.Wrap(err, \"can't serialize 'string' type\")\n}\n", field, minLen, maxLen) + validation
+		}
+
+		generated.encode = fmt.Sprintf("if validation {\n%s}\nseri.WriteString(string(%s), %s, %s, 0, 0)\n", validation, field, lengthPrefixTypeCode(lengthPrefixType), errProducer)
+
+		readString := fmt.Sprintf("deseri.ReadString((*string)(&%s), %s, serix.DecodeLengthErrProducer(\"failed to read string value from the deserializer\")", field, lengthPrefixTypeCode(lengthPrefixType))
+		decodeValidation := fmt.Sprintf("if !utf8.ValidString(string(%s)) {\nreturn ierrors.Errorf(\"can't deserialize 'string' type: %%w\", serix.ErrNonUTF8String)\n}\n", field)
+		if minLen != 0 || maxLen != 0 {
+			generated.decode = fmt.Sprintf("if validation {\n%s, %d, %d)\n%s} else {\n%s, 0, 0)\n}\n", readString, minLen, maxLen, decodeValidation, readString)
+		} else {
+			generated.decode = fmt.Sprintf("%s, 0, 0)\nif validation {\n%s}\n", readString, decodeValidation)
+		}
+
+		jsonValidation := fmt.Sprintf("if !utf8.ValidString(string(%s)) {\nreturn serix.ErrNonUTF8String\n}\n", field)
+		if minLen != 0 || maxLen != 0 {
+			jsonValidation = fmt.Sprintf("if err := serix.CheckLengthBounds(len(%s), %d, %d); err != nil {\nreturn ierrors.Wrap(err, \"can't serialize 'string' type\")\n}\n", field, minLen, maxLen) + jsonValidation
+		}
+		jsonValue, nonEmpty = fmt.Sprintf("string(%s)", field), field+` != ""`
+		generated.json = fmt.Sprintf("if validation {\n%s}\n", jsonValidation)
+
+	case reflect.Slice:
+		lengthPrefixType, _ := ts.LengthPrefixType()
+
+		validation := sliceBoundsValidation(field, minLen, maxLen)
+		generated.encode = fmt.Sprintf("%sseri.WriteVariableByteSlice(%s, %s, %s, %d, %d)\n", validation, field, lengthPrefixTypeCode(lengthPrefixType), errProducer, minLen, maxLen)
+		generated.decode = fmt.Sprintf("deseri.ReadVariableByteSlice((*[]byte)(&%s), %s, serix.DecodeLengthErrProducer(\"failed to read bytes from the deserializer\"), %d, %d)\n", field, lengthPrefixTypeCode(lengthPrefixType), minLen, maxLen)
+		jsonValue, nonEmpty = fmt.Sprintf("serix.EncodeHex(%s)", field), fmt.Sprintf("len(%s) != 0", field)
+		generated.json = validation
+
+	case reflect.Array:
+		generated.encode = fmt.Sprintf("seri.WriteBytes(%s[:], %s)\n", field, errProducer)
+		generated.decode = fmt.Sprintf("deseri.ReadBytesInPlace(%s[:], %s)\n", field, decodeErrProducer)
+		jsonValue, nonEmpty = fmt.Sprintf("serix.EncodeHex(%s[:])", field), fmt.Sprintf("%s != [%d]byte{}", field, sField.fType.Len())
+
+	default:
+		return generatedField{}, ierrors.Errorf("unsupported type %s", sField.fType)
+	}
+
+	generated.json += fmt.Sprintf("obj.Set(%s, %s)\n", strconv.Quote(fieldKey), jsonValue)
+	if sField.settings.omitEmpty {
+		generated.json = fmt.Sprintf("if %s {\n%s}\n", nonEmpty, generated.json)
+	}
+
+	return generated, nil
+}
+
+// generateStructField generates the code of a struct field that holds a struct (or a pointer to a struct) whose
+// methods are generated as well.
+func (g *codeGenerator) generateStructField(sField structField, ts TypeSettings, nested reflect.Type, fieldKey string) generatedField {
+	field := "$x." + sField.name
+
+	var nilCheck, allocation string
+	if sField.fType.Kind() == reflect.Ptr {
+		nilCheck = fmt.Sprintf("if %s == nil {\nreturn ierrors.Errorf(%s)\n}\n", field, strconv.Quote(fmt.Sprintf("failed to serialize struct field %s: unexpected nil pointer for type %s", sField.name, sField.fType)))
+		allocation = fmt.Sprintf("if %s == nil {\n%s = new(%s)\n}\n", field, field, nested.Name())
+	}
+
+	writeObjectType, checkObjectType, jsonObjectType := objectTypeCode(ts, "seri", "deseri", "fieldObj")
+	if checkObjectType != "" {
+		// don't decode the fields of an object of the wrong type
+		checkObjectType += "if _, err := deseri.Done(); err != nil {\nreturn err\n}\n"
+	}
+
+	return generatedField{
+		encode: fmt.Sprintf("%s%sif err := %s.SerixEncode(ctx, api, seri, opts...); err != nil {\nreturn ierrors.Wrap(err, %s)\n}\n",
+			nilCheck, writeObjectType, field, strconv.Quote("failed to serialize struct field "+sField.name)),
+		decode: fmt.Sprintf("%s%sif err := %s.SerixDecode(ctx, api, deseri, opts...); err != nil {\nreturn ierrors.Wrap(err, %s)\n}\n",
+			allocation, checkObjectType, field, strconv.Quote("failed to deserialize struct field "+sField.name)),
+		json: fmt.Sprintf("%s{\nfieldObj := orderedmap.New()\n%sif err := %s.SerixEncodeJSON(ctx, api, fieldObj, opts...); err != nil {\nreturn ierrors.Wrap(err, %s)\n}\nobj.Set(%s, fieldObj)\n}\n",
+			nilCheck, jsonObjectType, field, strconv.Quote("failed to serialize struct field "+sField.name), strconv.Quote(fieldKey)),
+		nested: nested,
+	}
+}
+
+// generateSliceOfStructsField generates the code of a struct field that holds a slice of structs (or of pointers to
+// structs) whose methods are generated as well.
+func (g *codeGenerator) generateSliceOfStructsField(sField structField, ts TypeSettings, nested reflect.Type, fieldKey string, errProducer string, decodeErrProducer string) generatedField {
+	field := "$x." + sField.name
+	elemType := sField.fType.Elem()
+	elemTS, _ := g.api.typeSettingsRegistry.GetByType(elemType)
+	lengthPrefixType, _ := ts.LengthPrefixType()
+	minLen, maxLen := ts.MinMaxLen()
+	elementErr := strconv.Quote("failed to encode element with index %d of struct field " + sField.name)
+
+	var nilCheck, element, elementValue string
+	if elemType.Kind() == reflect.Ptr {
+		nilCheck = fmt.Sprintf("if element == nil {\nreturn ierrors.Errorf(%s, i)\n}\n", strconv.Quote("failed to encode element with index %d of struct field "+sField.name+": unexpected nil pointer"))
+		element, elementValue = "element := new("+nested.Name()+")\n", "element"
+	} else {
+		element, elementValue = "element := new("+nested.Name()+")\n", "*element"
+	}
+
+	writeObjectType, checkObjectType, jsonObjectType := objectTypeCode(elemTS, "elementSeri", "elementDeseri", "elementObj")
+	if checkObjectType != "" {
+		// don't decode the fields of an object of the wrong type
+		checkObjectType += "if _, err := elementDeseri.Done(); err != nil {\nreturn 0, err\n}\n"
+	}
+	validation := sliceBoundsValidation(field, minLen, maxLen)
+
+	return generatedField{
+		encode: fmt.Sprintf("%s{\nelements := make([][]byte, len(%s))\nfor i, element := range %s {\n%selementSeri := serializer.NewSerializer()\n%sif err := element.SerixEncode(ctx, api, elementSeri, opts...); err != nil {\nreturn ierrors.Wrapf(err, %s, i)\n}\nelementBytes, err := elementSeri.Serialize()\nif err != nil {\nreturn ierrors.Wrapf(err, %s, i)\n}\nelements[i] = elementBytes\n}\nserix.WriteSliceOfObjects(seri, elements, %s, %d, %d, %s, opts...)\n}\n",
+			validation, field, field, nilCheck, writeObjectType, elementErr, elementErr, lengthPrefixTypeCode(lengthPrefixType), minLen, maxLen, errProducer),
+		decode: fmt.Sprintf("serix.ReadSliceOfObjects(deseri, func(b []byte) (int, error) {\nelementDeseri := serializer.NewDeserializer(b)\n%s%sif err := element.SerixDecode(ctx, api, elementDeseri, opts...); err != nil {\nreturn 0, err\n}\n%s = append(%s, %s)\n\nreturn elementDeseri.Done()\n}, %s, %d, %d, %s, opts...)\nif %s == nil {\n%s = make(%s, 0)\n}\n",
+			element, checkObjectType, field, field, elementValue, lengthPrefixTypeCode(lengthPrefixType), minLen, maxLen, decodeErrProducer, field, field, sliceTypeCode(sField.fType, nested)),
+		json: fmt.Sprintf("%s{\nelements := make([]any, len(%s))\nfor i, element := range %s {\n%selementObj := orderedmap.New()\n%sif err := element.SerixEncodeJSON(ctx, api, elementObj, opts...); err != nil {\nreturn ierrors.Wrapf(err, %s, i)\n}\nelements[i] = elementObj\n}\nobj.Set(%s, elements)\n}\n",
+			validation, field, field, nilCheck, jsonObjectType, elementErr, strconv.Quote(fieldKey)),
+		nested: nested,
+	}
+}
+
+// nativeTypeSettings returns the type settings of the given struct field of the given struct, whether the field is
+// serialized by the generated code itself and the nested struct whose generated methods are called for it.
+//
+// The generated code serializes fields of primitive types, strings and byte slices/arrays as well as structs (or
+// pointers to structs) of the same package whose methods are generated too and slices of them, as long as they are not
+// customized via registered validators, custom serialization methods, object types, max byte sizes, value rules,
+// array rules other than length bounds or protocol versions.
+func (g *codeGenerator) nativeTypeSettings(structType reflect.Type, sField structField) (TypeSettings, reflect.Type, bool) {
+	fieldType := sField.fType
+	if sField.isEmbedded || sField.settings.inlined || sField.settings.isOptional || !sField.settings.versionRange.isUnbounded() {
+		return TypeSettings{}, nil, false
+	}
+
+	for _, customType := range []reflect.Type{serializableType, deserializableType, serializableJSONType} {
+		if fieldType.Implements(customType) || reflect.PointerTo(fieldType).Implements(customType) {
+			return TypeSettings{}, nil, false
+		}
+	}
+
+	if g.hasValidator(fieldType) {
+		return TypeSettings{}, nil, false
+	}
+
+	globalTS, _ := g.api.typeSettingsRegistry.GetByType(fieldType)
+	ts := sField.settings.ts.merge(globalTS)
+	if _, hasMaxByteSize := ts.MaxByteSize(); hasMaxByteSize || ts.ValueRules() != nil {
+		return TypeSettings{}, nil, false
+	}
+
+	_, hasLengthPrefixType := ts.LengthPrefixType()
+	minLen, maxLen := ts.MinMaxLen()
+
+	switch fieldType.Kind() {
+	case reflect.Struct, reflect.Ptr:
+		// the JSON serialization via reflection omits structs based on their zero value
+		nested, isNested := g.nestedStructType(structType, fieldType)
+		if !isNested || sField.settings.omitEmpty || !objectTypeSupported(ts) {
+			return TypeSettings{}, nil, false
+		}
+
+		return ts, nested, true
+	}
+
+	if ts.ObjectType() != nil {
+		return TypeSettings{}, nil, false
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return ts, nil, true
+	case reflect.String:
+		return ts, nil, hasLengthPrefixType
+	case reflect.Slice:
+		if fieldType.Elem() == byteType {
+			return ts, nil, hasLengthPrefixType
+		}
+
+		nested, isNested := g.nestedStructType(structType, fieldType.Elem())
+		if !isNested || !hasLengthPrefixType || sField.settings.omitEmpty || !onlyLengthBounds(ts) {
+			return TypeSettings{}, nil, false
+		}
+
+		return ts, nested, true
+	case reflect.Array:
+		return ts, nil, fieldType.Elem() == byteType && minLen == 0 && maxLen == 0
+	default:
+		return TypeSettings{}, nil, false
+	}
+}
+
+// nestedStructType returns the struct of the given type (a struct or a pointer to a struct) if it is defined in the
+// package of the given struct, its methods are generated as well and it is serialized without further customization.
+func (g *codeGenerator) nestedStructType(structType reflect.Type, fieldType reflect.Type) (reflect.Type, bool) {
+	nested := fieldType
+	if nested.Kind() == reflect.Ptr {
+		nested = nested.Elem()
+	}
+
+	if nested.Kind() != reflect.Struct || nested.PkgPath() != structType.PkgPath() || !g.isGenerated(nested) {
+		return nil, false
+	}
+
+	if reflect.PointerTo(nested).Implements(contextAwareDeserializableType) || g.hasValidator(fieldType) {
+		return nil, false
+	}
+
+	globalTS, _ := g.api.typeSettingsRegistry.GetByType(fieldType)
+	if _, hasMaxByteSize := globalTS.MaxByteSize(); hasMaxByteSize || globalTS.ValueRules() != nil || !objectTypeSupported(globalTS) {
+		return nil, false
+	}
+
+	return nested, true
+}
+
+// isGenerated returns whether the methods of the given struct are generated.
+func (g *codeGenerator) isGenerated(structType reflect.Type) bool {
+	return g.generated[structType] || hasGeneratedMethods(structType)
+}
+
+// hasValidator returns whether a syntactic validator is registered for the given type (or the type it points to).
+func (g *codeGenerator) hasValidator(fieldType reflect.Type) bool {
+	if _, hasValidator := g.api.validatorsRegistry.Get(fieldType); hasValidator {
+		return true
+	}
+
+	if fieldType.Kind() == reflect.Ptr {
+		_, hasValidator := g.api.validatorsRegistry.Get(fieldType.Elem())
+
+		return hasValidator
+	}
+
+	return false
+}
+
+// hasGeneratedMethods returns whether the given struct has the methods that are generated by API.GenerateCode.
+func hasGeneratedMethods(structType reflect.Type) bool {
+	return structType.Implements(generatedSerializableType) &&
+		structType.Implements(generatedSerializableJSONType) &&
+		structType.Implements(generatedCodeType) &&
+		reflect.PointerTo(structType).Implements(generatedDeserializableType)
+}
+
+// objectTypeSupported returns whether the object type of the given type settings can be written by the generated code,
+// which writes the code of the object type as a plain number into the JSON objects.
+func objectTypeSupported(ts TypeSettings) bool {
+	objectType := ts.ObjectType()
+	if objectType == nil {
+		return true
+	}
+
+	if _, isJSONMarshaler := objectType.(json.Marshaler); isJSONMarshaler {
+		return false
+	}
+	if _, isTextMarshaler := objectType.(encoding.TextMarshaler); isTextMarshaler {
+		return false
+	}
+
+	_, _, err := getTypeDenotationAndCode(objectType)
+
+	return err == nil
+}
+
+// onlyLengthBounds returns whether the given type settings of a slice only restrict its length, which is the only
+// array rule the generated code supports.
+func onlyLengthBounds(ts TypeSettings) bool {
+	if lexicalOrdering, set := ts.LexicalOrdering(); set && lexicalOrdering {
+		return false
+	}
+
+	arrayRules := ts.ArrayRules()
+
+	return arrayRules == nil || (len(arrayRules.MustOccur) == 0 && arrayRules.ValidationMode == 0 &&
+		arrayRules.Guards.ReadGuard == nil && arrayRules.Guards.PostReadGuard == nil && arrayRules.Guards.WriteGuard == nil)
+}
+
+// objectTypeCode returns the code that writes, checks and sets the object type of the given type settings (empty if
+// there is no object type) with the given serializer, deserializer and ordered map.
+func objectTypeCode(ts TypeSettings, seri string, deseri string, obj string) (write string, check string, json string) {
+	objectType := ts.ObjectType()
+	if objectType == nil {
+		return "", "", ""
+	}
+
+	//nolint:errcheck // the object type was checked by objectTypeSupported
+	typeDen, objectCode, _ := getTypeDenotationAndCode(objectType)
+	value, denotation := fmt.Sprintf("uint8(%d)", objectCode), "serializer.TypeDenotationByte"
+	if typeDen == serializer.TypeDenotationUint32 {
+		value, denotation = fmt.Sprintf("uint32(%d)", objectCode), "serializer.TypeDenotationUint32"
+	}
+
+	write = fmt.Sprintf("%s.WriteNum(%s, func(err error) error {\nreturn ierrors.Wrap(err, \"failed to write object type code into serializer\")\n})\n", seri, value)
+	check = fmt.Sprintf("%s.CheckTypePrefix(%d, %s, func(err error) error {\nreturn ierrors.Wrap(err, \"failed to check object type\")\n})\n", deseri, objectCode, denotation)
+	json = fmt.Sprintf("%s.Set(%s, %s)\n", obj, strconv.Quote(keyType), value)
+
+	return write, check, json
+}
+
+// sliceBoundsValidation returns the code that checks the length bounds of the given slice during the validation.
+func sliceBoundsValidation(field string, minLen int, maxLen int) string {
+	if minLen == 0 && maxLen == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("if validation {\nif err := serix.CheckLengthBounds(len(%s), %d, %d); err != nil {\nreturn ierrors.Wrap(err, \"can't serialize 'slice' type\")\n}\n}\n", field, minLen, maxLen)
+}
+
+// sliceTypeCode returns the code of the given slice type of structs of the generated package.
+func sliceTypeCode(sliceType reflect.Type, nested reflect.Type) string {
+	if sliceType.Elem().Kind() == reflect.Ptr {
+		return "[]*" + nested.Name()
+	}
+
+	return "[]" + nested.Name()
+}
+
+// lengthPrefixTypeCode returns the code of the serializer.SeriLengthPrefixType constant of the given LengthPrefixType.
+func lengthPrefixTypeCode(lengthPrefixType LengthPrefixType) string {
+	switch lengthPrefixType {
+	case LengthPrefixTypeAsByte:
+		return "serializer.SeriLengthPrefixTypeAsByte"
+	case LengthPrefixTypeAsUint16:
+		return "serializer.SeriLengthPrefixTypeAsUint16"
+	case LengthPrefixTypeAsUint32:
+		return "serializer.SeriLengthPrefixTypeAsUint32"
+	case LengthPrefixTypeAsVarint:
+		return "serializer.SeriLengthPrefixTypeAsVarint"
+	default:
+		return "serializer.SeriLengthPrefixTypeAsUint64"
+	}
+}
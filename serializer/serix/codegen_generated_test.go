@@ -0,0 +1,500 @@
+// Code generated by serixgen; DO NOT EDIT.
+
+package serix_test
+
+import (
+	"context"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/iancoleman/orderedmap"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+// SerixEncode serializes the fields of codegenOutput.
+func (c codegenOutput) SerixEncode(ctx context.Context, api *serix.API, seri *serializer.Serializer, opts ...serix.Option) error {
+	validation := serix.ValidationEnabled(opts...)
+
+	seri.WriteNum(uint64(c.Amount), func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field Amount")
+	})
+	seri.WriteNum(uint16(c.Mana), func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field Mana")
+	})
+	seri.WriteNum(int8(c.Delta), func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field Delta")
+	})
+	seri.WriteNum(float32(c.Ratio), func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field Ratio")
+	})
+	seri.WriteBool(bool(c.Locked), func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field Locked")
+	})
+	if validation {
+		if err := serix.CheckLengthBounds(len(c.Name), 1, 16); err != nil {
+			return ierrors.Wrap(err, "can't serialize 'string' type")
+		}
+		if !utf8.ValidString(string(c.Name)) {
+			return ierrors.Errorf("can't serialize 'string' type: %w", serix.ErrNonUTF8String)
+		}
+	}
+	seri.WriteString(string(c.Name), serializer.SeriLengthPrefixTypeAsByte, func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field Name")
+	}, 0, 0)
+	if validation {
+		if err := serix.CheckLengthBounds(len(c.Tag), 0, 8); err != nil {
+			return ierrors.Wrap(err, "can't serialize 'slice' type")
+		}
+	}
+	seri.WriteVariableByteSlice(c.Tag, serializer.SeriLengthPrefixTypeAsByte, func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field Tag")
+	}, 0, 8)
+	seri.WriteBytes(c.ID[:], func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field ID")
+	})
+	if err := api.EncodeStructField(ctx, seri, &c, 8, opts...); err != nil {
+		return err
+	}
+	if err := api.EncodeStructField(ctx, seri, &c, 9, opts...); err != nil {
+		return err
+	}
+	if err := api.EncodeStructField(ctx, seri, &c, 10, opts...); err != nil {
+		return err
+	}
+	{
+		elements := make([][]byte, len(c.Nested))
+		for i, element := range c.Nested {
+			if element == nil {
+				return ierrors.Errorf("failed to encode element with index %d of struct field Nested: unexpected nil pointer", i)
+			}
+			elementSeri := serializer.NewSerializer()
+			elementSeri.WriteNum(uint32(4), func(err error) error {
+				return ierrors.Wrap(err, "failed to write object type code into serializer")
+			})
+			if err := element.SerixEncode(ctx, api, elementSeri, opts...); err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of struct field Nested", i)
+			}
+			elementBytes, err := elementSeri.Serialize()
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of struct field Nested", i)
+			}
+			elements[i] = elementBytes
+		}
+		serix.WriteSliceOfObjects(seri, elements, serializer.SeriLengthPrefixTypeAsByte, 0, 0, func(err error) error {
+			return ierrors.Wrap(err, "failed to serialize struct field Nested")
+		}, opts...)
+	}
+	if err := api.EncodeStructField(ctx, seri, &c, 12, opts...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SerixDecode deserializes the fields of codegenOutput.
+func (c *codegenOutput) SerixDecode(ctx context.Context, api *serix.API, deseri *serializer.Deserializer, opts ...serix.Option) error {
+	validation := serix.ValidationEnabled(opts...)
+
+	deseri.ReadNum((*uint64)(&c.Amount), func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field Amount")
+	})
+	deseri.ReadNum((*uint16)(&c.Mana), func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field Mana")
+	})
+	deseri.ReadNum((*int8)(&c.Delta), func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field Delta")
+	})
+	deseri.ReadNum((*float32)(&c.Ratio), func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field Ratio")
+	})
+	deseri.ReadBool((*bool)(&c.Locked), func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field Locked")
+	})
+	if validation {
+		deseri.ReadString((*string)(&c.Name), serializer.SeriLengthPrefixTypeAsByte, serix.DecodeLengthErrProducer("failed to read string value from the deserializer"), 1, 16)
+		if !utf8.ValidString(string(c.Name)) {
+			return ierrors.Errorf("can't deserialize 'string' type: %w", serix.ErrNonUTF8String)
+		}
+	} else {
+		deseri.ReadString((*string)(&c.Name), serializer.SeriLengthPrefixTypeAsByte, serix.DecodeLengthErrProducer("failed to read string value from the deserializer"), 0, 0)
+	}
+	deseri.ReadVariableByteSlice((*[]byte)(&c.Tag), serializer.SeriLengthPrefixTypeAsByte, serix.DecodeLengthErrProducer("failed to read bytes from the deserializer"), 0, 8)
+	deseri.ReadBytesInPlace(c.ID[:], func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field ID")
+	})
+	if err := api.DecodeStructField(ctx, deseri, c, 8, opts...); err != nil {
+		return err
+	}
+	if err := api.DecodeStructField(ctx, deseri, c, 9, opts...); err != nil {
+		return err
+	}
+	if err := api.DecodeStructField(ctx, deseri, c, 10, opts...); err != nil {
+		return err
+	}
+	serix.ReadSliceOfObjects(deseri, func(b []byte) (int, error) {
+		elementDeseri := serializer.NewDeserializer(b)
+		element := new(codegenMetadata)
+		elementDeseri.CheckTypePrefix(4, serializer.TypeDenotationUint32, func(err error) error {
+			return ierrors.Wrap(err, "failed to check object type")
+		})
+		if _, err := elementDeseri.Done(); err != nil {
+			return 0, err
+		}
+		if err := element.SerixDecode(ctx, api, elementDeseri, opts...); err != nil {
+			return 0, err
+		}
+		c.Nested = append(c.Nested, element)
+
+		return elementDeseri.Done()
+	}, serializer.SeriLengthPrefixTypeAsByte, 0, 0, func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field Nested")
+	}, opts...)
+	if c.Nested == nil {
+		c.Nested = make([]*codegenMetadata, 0)
+	}
+	if err := api.DecodeStructField(ctx, deseri, c, 12, opts...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SerixEncodeJSON serializes the fields of codegenOutput into the given ordered map.
+func (c codegenOutput) SerixEncodeJSON(ctx context.Context, api *serix.API, obj *orderedmap.OrderedMap, opts ...serix.Option) error {
+	validation := serix.ValidationEnabled(opts...)
+
+	obj.Set("amount", strconv.FormatUint(uint64(c.Amount), 10))
+	if c.Mana != 0 {
+		obj.Set("mana", uint64(c.Mana))
+	}
+	obj.Set("delta", int64(c.Delta))
+	obj.Set("ratio", strconv.FormatFloat(float64(c.Ratio), 'g', -1, 64))
+	if c.Locked {
+		obj.Set("locked", bool(c.Locked))
+	}
+	if validation {
+		if err := serix.CheckLengthBounds(len(c.Name), 1, 16); err != nil {
+			return ierrors.Wrap(err, "can't serialize 'string' type")
+		}
+		if !utf8.ValidString(string(c.Name)) {
+			return serix.ErrNonUTF8String
+		}
+	}
+	obj.Set("outputName", string(c.Name))
+	if len(c.Tag) != 0 {
+		if validation {
+			if err := serix.CheckLengthBounds(len(c.Tag), 0, 8); err != nil {
+				return ierrors.Wrap(err, "can't serialize 'slice' type")
+			}
+		}
+		obj.Set("tag", serix.EncodeHex(c.Tag))
+	}
+	obj.Set("id", serix.EncodeHex(c.ID[:]))
+	if err := api.MapEncodeStructField(ctx, obj, &c, 8, opts...); err != nil {
+		return err
+	}
+	if err := api.MapEncodeStructField(ctx, obj, &c, 9, opts...); err != nil {
+		return err
+	}
+	if err := api.MapEncodeStructField(ctx, obj, &c, 10, opts...); err != nil {
+		return err
+	}
+	{
+		elements := make([]any, len(c.Nested))
+		for i, element := range c.Nested {
+			if element == nil {
+				return ierrors.Errorf("failed to encode element with index %d of struct field Nested: unexpected nil pointer", i)
+			}
+			elementObj := orderedmap.New()
+			elementObj.Set("type", uint32(4))
+			if err := element.SerixEncodeJSON(ctx, api, elementObj, opts...); err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of struct field Nested", i)
+			}
+			elements[i] = elementObj
+		}
+		obj.Set("nested", elements)
+	}
+	if err := api.MapEncodeStructField(ctx, obj, &c, 12, opts...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SerixFingerprint returns the fingerprint of the struct tags and type settings the methods of codegenOutput were generated for.
+func (c codegenOutput) SerixFingerprint() string {
+	return "231912a2210a61d189c2b33689604ffd"
+}
+
+// SerixEncode serializes the fields of codegenMetadata.
+func (c codegenMetadata) SerixEncode(ctx context.Context, api *serix.API, seri *serializer.Serializer, opts ...serix.Option) error {
+	validation := serix.ValidationEnabled(opts...)
+
+	if validation {
+		if !utf8.ValidString(string(c.Note)) {
+			return ierrors.Errorf("can't serialize 'string' type: %w", serix.ErrNonUTF8String)
+		}
+	}
+	seri.WriteString(string(c.Note), serializer.SeriLengthPrefixTypeAsUint16, func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field Note")
+	}, 0, 0)
+	seri.WriteNum(int64(c.Count), func(err error) error {
+		return ierrors.Wrap(err, "failed to serialize struct field Count")
+	})
+
+	return nil
+}
+
+// SerixDecode deserializes the fields of codegenMetadata.
+func (c *codegenMetadata) SerixDecode(ctx context.Context, api *serix.API, deseri *serializer.Deserializer, opts ...serix.Option) error {
+	validation := serix.ValidationEnabled(opts...)
+
+	deseri.ReadString((*string)(&c.Note), serializer.SeriLengthPrefixTypeAsUint16, serix.DecodeLengthErrProducer("failed to read string value from the deserializer"), 0, 0)
+	if validation {
+		if !utf8.ValidString(string(c.Note)) {
+			return ierrors.Errorf("can't deserialize 'string' type: %w", serix.ErrNonUTF8String)
+		}
+	}
+	deseri.ReadNum((*int64)(&c.Count), func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field Count")
+	})
+
+	return nil
+}
+
+// SerixEncodeJSON serializes the fields of codegenMetadata into the given ordered map.
+func (c codegenMetadata) SerixEncodeJSON(ctx context.Context, api *serix.API, obj *orderedmap.OrderedMap, opts ...serix.Option) error {
+	validation := serix.ValidationEnabled(opts...)
+
+	if validation {
+		if !utf8.ValidString(string(c.Note)) {
+			return serix.ErrNonUTF8String
+		}
+	}
+	obj.Set("note", string(c.Note))
+	obj.Set("count", strconv.FormatInt(int64(c.Count), 10))
+
+	return nil
+}
+
+// SerixFingerprint returns the fingerprint of the struct tags and type settings the methods of codegenMetadata were generated for.
+func (c codegenMetadata) SerixFingerprint() string {
+	return "10894500a8c6bdbb45e03aae5c573b58"
+}
+
+// SerixEncode serializes the fields of codegenTransaction.
+func (c codegenTransaction) SerixEncode(ctx context.Context, api *serix.API, seri *serializer.Serializer, opts ...serix.Option) error {
+	validation := serix.ValidationEnabled(opts...)
+
+	if validation {
+		if err := serix.CheckLengthBounds(len(c.Outputs), 1, 0); err != nil {
+			return ierrors.Wrap(err, "can't serialize 'slice' type")
+		}
+	}
+	{
+		elements := make([][]byte, len(c.Outputs))
+		for i, element := range c.Outputs {
+			if element == nil {
+				return ierrors.Errorf("failed to encode element with index %d of struct field Outputs: unexpected nil pointer", i)
+			}
+			elementSeri := serializer.NewSerializer()
+			elementSeri.WriteNum(uint8(3), func(err error) error {
+				return ierrors.Wrap(err, "failed to write object type code into serializer")
+			})
+			if err := element.SerixEncode(ctx, api, elementSeri, opts...); err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of struct field Outputs", i)
+			}
+			elementBytes, err := elementSeri.Serialize()
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of struct field Outputs", i)
+			}
+			elements[i] = elementBytes
+		}
+		serix.WriteSliceOfObjects(seri, elements, serializer.SeriLengthPrefixTypeAsUint16, 1, 0, func(err error) error {
+			return ierrors.Wrap(err, "failed to serialize struct field Outputs")
+		}, opts...)
+	}
+	seri.WriteNum(uint32(4), func(err error) error {
+		return ierrors.Wrap(err, "failed to write object type code into serializer")
+	})
+	if err := c.Context.SerixEncode(ctx, api, seri, opts...); err != nil {
+		return ierrors.Wrap(err, "failed to serialize struct field Context")
+	}
+	if c.Author == nil {
+		return ierrors.Errorf("failed to serialize struct field Author: unexpected nil pointer for type *serix_test.codegenMetadata")
+	}
+	seri.WriteNum(uint32(4), func(err error) error {
+		return ierrors.Wrap(err, "failed to write object type code into serializer")
+	})
+	if err := c.Author.SerixEncode(ctx, api, seri, opts...); err != nil {
+		return ierrors.Wrap(err, "failed to serialize struct field Author")
+	}
+	if validation {
+		if err := serix.CheckLengthBounds(len(c.Notes), 0, 2); err != nil {
+			return ierrors.Wrap(err, "can't serialize 'slice' type")
+		}
+	}
+	{
+		elements := make([][]byte, len(c.Notes))
+		for i, element := range c.Notes {
+			elementSeri := serializer.NewSerializer()
+			elementSeri.WriteNum(uint32(4), func(err error) error {
+				return ierrors.Wrap(err, "failed to write object type code into serializer")
+			})
+			if err := element.SerixEncode(ctx, api, elementSeri, opts...); err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of struct field Notes", i)
+			}
+			elementBytes, err := elementSeri.Serialize()
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of struct field Notes", i)
+			}
+			elements[i] = elementBytes
+		}
+		serix.WriteSliceOfObjects(seri, elements, serializer.SeriLengthPrefixTypeAsByte, 0, 2, func(err error) error {
+			return ierrors.Wrap(err, "failed to serialize struct field Notes")
+		}, opts...)
+	}
+
+	return nil
+}
+
+// SerixDecode deserializes the fields of codegenTransaction.
+func (c *codegenTransaction) SerixDecode(ctx context.Context, api *serix.API, deseri *serializer.Deserializer, opts ...serix.Option) error {
+	serix.ReadSliceOfObjects(deseri, func(b []byte) (int, error) {
+		elementDeseri := serializer.NewDeserializer(b)
+		element := new(codegenOutput)
+		elementDeseri.CheckTypePrefix(3, serializer.TypeDenotationByte, func(err error) error {
+			return ierrors.Wrap(err, "failed to check object type")
+		})
+		if _, err := elementDeseri.Done(); err != nil {
+			return 0, err
+		}
+		if err := element.SerixDecode(ctx, api, elementDeseri, opts...); err != nil {
+			return 0, err
+		}
+		c.Outputs = append(c.Outputs, element)
+
+		return elementDeseri.Done()
+	}, serializer.SeriLengthPrefixTypeAsUint16, 1, 0, func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field Outputs")
+	}, opts...)
+	if c.Outputs == nil {
+		c.Outputs = make([]*codegenOutput, 0)
+	}
+	deseri.CheckTypePrefix(4, serializer.TypeDenotationUint32, func(err error) error {
+		return ierrors.Wrap(err, "failed to check object type")
+	})
+	if _, err := deseri.Done(); err != nil {
+		return err
+	}
+	if err := c.Context.SerixDecode(ctx, api, deseri, opts...); err != nil {
+		return ierrors.Wrap(err, "failed to deserialize struct field Context")
+	}
+	if c.Author == nil {
+		c.Author = new(codegenMetadata)
+	}
+	deseri.CheckTypePrefix(4, serializer.TypeDenotationUint32, func(err error) error {
+		return ierrors.Wrap(err, "failed to check object type")
+	})
+	if _, err := deseri.Done(); err != nil {
+		return err
+	}
+	if err := c.Author.SerixDecode(ctx, api, deseri, opts...); err != nil {
+		return ierrors.Wrap(err, "failed to deserialize struct field Author")
+	}
+	serix.ReadSliceOfObjects(deseri, func(b []byte) (int, error) {
+		elementDeseri := serializer.NewDeserializer(b)
+		element := new(codegenMetadata)
+		elementDeseri.CheckTypePrefix(4, serializer.TypeDenotationUint32, func(err error) error {
+			return ierrors.Wrap(err, "failed to check object type")
+		})
+		if _, err := elementDeseri.Done(); err != nil {
+			return 0, err
+		}
+		if err := element.SerixDecode(ctx, api, elementDeseri, opts...); err != nil {
+			return 0, err
+		}
+		c.Notes = append(c.Notes, *element)
+
+		return elementDeseri.Done()
+	}, serializer.SeriLengthPrefixTypeAsByte, 0, 2, func(err error) error {
+		return ierrors.Wrap(err, "failed to deserialize struct field Notes")
+	}, opts...)
+	if c.Notes == nil {
+		c.Notes = make([]codegenMetadata, 0)
+	}
+
+	return nil
+}
+
+// SerixEncodeJSON serializes the fields of codegenTransaction into the given ordered map.
+func (c codegenTransaction) SerixEncodeJSON(ctx context.Context, api *serix.API, obj *orderedmap.OrderedMap, opts ...serix.Option) error {
+	validation := serix.ValidationEnabled(opts...)
+
+	if validation {
+		if err := serix.CheckLengthBounds(len(c.Outputs), 1, 0); err != nil {
+			return ierrors.Wrap(err, "can't serialize 'slice' type")
+		}
+	}
+	{
+		elements := make([]any, len(c.Outputs))
+		for i, element := range c.Outputs {
+			if element == nil {
+				return ierrors.Errorf("failed to encode element with index %d of struct field Outputs: unexpected nil pointer", i)
+			}
+			elementObj := orderedmap.New()
+			elementObj.Set("type", uint8(3))
+			if err := element.SerixEncodeJSON(ctx, api, elementObj, opts...); err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of struct field Outputs", i)
+			}
+			elements[i] = elementObj
+		}
+		obj.Set("outputs", elements)
+	}
+	{
+		fieldObj := orderedmap.New()
+		fieldObj.Set("type", uint32(4))
+		if err := c.Context.SerixEncodeJSON(ctx, api, fieldObj, opts...); err != nil {
+			return ierrors.Wrap(err, "failed to serialize struct field Context")
+		}
+		obj.Set("context", fieldObj)
+	}
+	if c.Author == nil {
+		return ierrors.Errorf("failed to serialize struct field Author: unexpected nil pointer for type *serix_test.codegenMetadata")
+	}
+	{
+		fieldObj := orderedmap.New()
+		fieldObj.Set("type", uint32(4))
+		if err := c.Author.SerixEncodeJSON(ctx, api, fieldObj, opts...); err != nil {
+			return ierrors.Wrap(err, "failed to serialize struct field Author")
+		}
+		obj.Set("author", fieldObj)
+	}
+	if validation {
+		if err := serix.CheckLengthBounds(len(c.Notes), 0, 2); err != nil {
+			return ierrors.Wrap(err, "can't serialize 'slice' type")
+		}
+	}
+	{
+		elements := make([]any, len(c.Notes))
+		for i, element := range c.Notes {
+			elementObj := orderedmap.New()
+			elementObj.Set("type", uint32(4))
+			if err := element.SerixEncodeJSON(ctx, api, elementObj, opts...); err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of struct field Notes", i)
+			}
+			elements[i] = elementObj
+		}
+		obj.Set("notes", elements)
+	}
+
+	return nil
+}
+
+// SerixFingerprint returns the fingerprint of the struct tags and type settings the methods of codegenTransaction were generated for.
+func (c codegenTransaction) SerixFingerprint() string {
+	return "4e15c7185d024284ed63e76f9456adb1"
+}
@@ -0,0 +1,218 @@
+package serix_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+const codegenGeneratedFile = "codegen_generated_test.go"
+
+type codegenAmount uint16
+
+type codegenOutput struct {
+	Amount   uint64               `serix:""`
+	Mana     codegenAmount        `serix:",omitempty"`
+	Delta    int8                 `serix:""`
+	Ratio    float32              `serix:""`
+	Locked   bool                 `serix:",omitempty"`
+	Name     string               `serix:"outputName,lenPrefix=uint8,minLen=1,maxLen=16"`
+	Tag      []byte               `serix:",lenPrefix=uint8,maxLen=8,omitempty"`
+	ID       [4]byte              `serix:""`
+	Address  schemaAddress        `serix:""`
+	Metadata *codegenMetadata     `serix:",optional"`
+	Features map[uint64]uint16    `serix:",lenPrefix=uint8"`
+	Nested   []*codegenMetadata   `serix:",lenPrefix=uint8"`
+	Key      schemaEd25519Address `serix:""`
+}
+
+type codegenMetadata struct {
+	Note  string `serix:",lenPrefix=uint16"`
+	Count int64  `serix:""`
+}
+
+type codegenTransaction struct {
+	Outputs []*codegenOutput  `serix:",lenPrefix=uint16,minLen=1"`
+	Context codegenMetadata   `serix:""`
+	Author  *codegenMetadata  `serix:""`
+	Notes   []codegenMetadata `serix:",lenPrefix=uint8,maxLen=2"`
+}
+
+var updateGeneratedCode = os.Getenv("SERIX_UPDATE_GENERATED_CODE") != ""
+
+func newCodegenTestAPI(t *testing.T) *serix.API {
+	api := newSchemaTestAPI(t)
+	require.NoError(t, api.RegisterTypeSettings(codegenTransaction{}, serix.TypeSettings{}.WithObjectType(uint8(2))))
+	require.NoError(t, api.RegisterTypeSettings(codegenOutput{}, serix.TypeSettings{}.WithObjectType(uint8(3))))
+	require.NoError(t, api.RegisterTypeSettings(codegenMetadata{}, serix.TypeSettings{}.WithObjectType(uint32(4))))
+
+	return api
+}
+
+func TestGenerateCode(t *testing.T) {
+	source, err := newCodegenTestAPI(t).GenerateCode("serix_test", codegenOutput{}, codegenMetadata{}, codegenTransaction{})
+	require.NoError(t, err)
+
+	if updateGeneratedCode {
+		require.NoError(t, os.WriteFile(codegenGeneratedFile, source, 0600))
+	}
+
+	existingSource, err := os.ReadFile(codegenGeneratedFile)
+	require.NoError(t, err)
+	require.Equal(t, string(existingSource), string(source), "generated code is outdated, run with SERIX_UPDATE_GENERATED_CODE=1")
+
+	_, err = newCodegenTestAPI(t).GenerateCode("serix_test", schemaEd25519Address{})
+	require.Error(t, err)
+}
+
+func TestVerifyGeneratedCode(t *testing.T) {
+	api := newCodegenTestAPI(t)
+	ctx := context.Background()
+
+	key := schemaEd25519Address{1, 2, 3, 4}
+	output := &codegenOutput{
+		Amount:   1_000_000_000_000,
+		Mana:     42,
+		Delta:    -7,
+		Ratio:    0.25,
+		Locked:   true,
+		Name:     "output",
+		Tag:      []byte{0xca, 0xfe},
+		ID:       [4]byte{5, 6, 7, 8},
+		Address:  &schemaAccountAddress{AccountID: [2]byte{9, 10}},
+		Metadata: &codegenMetadata{Note: "note", Count: -1},
+		Features: map[uint64]uint16{1: 1},
+		Nested:   []*codegenMetadata{{Note: "nested", Count: 3}},
+		Key:      key,
+	}
+	emptyOutput := &codegenOutput{Address: key, Features: map[uint64]uint16{}, Nested: []*codegenMetadata{}}
+	invalidOutput := &codegenOutput{Name: "\xff", Address: key, Features: map[uint64]uint16{}, Nested: []*codegenMetadata{}}
+	transaction := &codegenTransaction{
+		Outputs: []*codegenOutput{output},
+		Context: codegenMetadata{Note: "context", Count: 5},
+		Author:  &codegenMetadata{Note: "author"},
+		Notes:   []codegenMetadata{{Note: "first"}, {Count: 2}},
+	}
+	invalidTransaction := &codegenTransaction{Outputs: []*codegenOutput{}, Author: &codegenMetadata{}}
+	nilTransaction := &codegenTransaction{Outputs: []*codegenOutput{nil}}
+
+	require.NoError(t, api.VerifyGeneratedCode(ctx, output, emptyOutput, invalidOutput, transaction, invalidTransaction, nilTransaction, &codegenMetadata{}))
+
+	// the generated code is used for nested structs as well
+	generatedBytes, err := api.Encode(ctx, transaction, serix.WithValidation())
+	require.NoError(t, err)
+	reflectionBytes, err := api.Encode(ctx, transaction, serix.WithValidation(), serix.WithoutGeneratedCode())
+	require.NoError(t, err)
+	require.Equal(t, reflectionBytes, generatedBytes)
+
+	decoded := new(codegenTransaction)
+	bytesRead, err := api.Decode(ctx, generatedBytes, decoded, serix.WithValidation())
+	require.NoError(t, err)
+	require.Equal(t, len(generatedBytes), bytesRead)
+	require.Equal(t, transaction, decoded)
+
+	_, err = api.Encode(ctx, invalidOutput, serix.WithValidation())
+	require.True(t, ierrors.Is(err, serix.ErrNonUTF8String))
+
+	require.True(t, ierrors.Is(api.VerifyGeneratedCode(ctx, &schemaAccountAddress{}), serix.ErrGeneratedCodeMismatch))
+}
+
+func TestGeneratedCodeSettings(t *testing.T) {
+	ctx := context.Background()
+	metadata := &codegenMetadata{Note: "note", Count: 1}
+	transaction := &codegenTransaction{
+		Outputs: []*codegenOutput{},
+		Author:  metadata,
+		Notes:   []codegenMetadata{*metadata},
+	}
+
+	// an API with other type settings than the generating API ignores the generated code that depends on them
+	api := newSchemaTestAPI(t)
+	require.NoError(t, api.RegisterTypeSettings(codegenMetadata{}, serix.TypeSettings{}.WithObjectType(uint32(5))))
+	require.NoError(t, api.VerifyGeneratedCode(ctx, metadata))
+	require.True(t, ierrors.Is(api.VerifyGeneratedCode(ctx, transaction), serix.ErrGeneratedCodeMismatch))
+
+	generatedBytes, err := api.Encode(ctx, transaction)
+	require.NoError(t, err)
+	reflectionBytes, err := api.Encode(ctx, transaction, serix.WithoutGeneratedCode())
+	require.NoError(t, err)
+	require.Equal(t, reflectionBytes, generatedBytes)
+
+	// the fingerprints are computed again after the registration of new type settings
+	api = newSchemaTestAPI(t)
+	require.NoError(t, api.RegisterTypeSettings(codegenTransaction{}, serix.TypeSettings{}.WithObjectType(uint8(2))))
+	require.NoError(t, api.RegisterTypeSettings(codegenOutput{}, serix.TypeSettings{}.WithObjectType(uint8(3))))
+	require.True(t, ierrors.Is(api.VerifyGeneratedCode(ctx, transaction), serix.ErrGeneratedCodeMismatch))
+	require.NoError(t, api.RegisterTypeSettings(codegenMetadata{}, serix.TypeSettings{}.WithObjectType(uint32(4))))
+	require.NoError(t, api.VerifyGeneratedCode(ctx, transaction))
+
+	// the fingerprint of a struct covers the settings of the nested structs whose generated methods it calls
+	api = newSchemaTestAPI(t)
+	require.NoError(t, api.RegisterTypeSettings(codegenTransaction{}, serix.TypeSettings{}.WithObjectType(uint8(2))))
+	require.NoError(t, api.RegisterTypeSettings(codegenOutput{}, serix.TypeSettings{}.WithObjectType(uint8(3))))
+	require.NoError(t, api.RegisterTypeSettings(codegenMetadata{}, serix.TypeSettings{}.WithObjectType(uint32(4)).WithMaxByteSize(100)))
+	require.NoError(t, api.VerifyGeneratedCode(ctx, metadata))
+	require.True(t, ierrors.Is(api.VerifyGeneratedCode(ctx, transaction), serix.ErrGeneratedCodeMismatch))
+}
+
+func BenchmarkGeneratedCode(b *testing.B) {
+	api := newCodegenTestAPI(&testing.T{})
+	ctx := context.Background()
+
+	outputs := make([]*codegenOutput, 0, 16)
+	for i := range 16 {
+		outputs = append(outputs, &codegenOutput{
+			Amount:   uint64(i),
+			Name:     "output",
+			Tag:      []byte{byte(i)},
+			Address:  &schemaAccountAddress{AccountID: [2]byte{byte(i)}},
+			Features: map[uint64]uint16{},
+			Nested:   []*codegenMetadata{{Note: "nested", Count: int64(i)}},
+			Key:      schemaEd25519Address{byte(i)},
+		})
+	}
+	transaction := &codegenTransaction{
+		Outputs: outputs,
+		Author:  &codegenMetadata{Note: "author"},
+		Notes:   []codegenMetadata{{Note: "note"}},
+	}
+	transactionBytes, err := api.Encode(ctx, transaction)
+	require.NoError(b, err)
+
+	for _, mode := range []struct {
+		name string
+		opts []serix.Option
+	}{
+		{"generated", []serix.Option{serix.WithValidation()}},
+		{"reflection", []serix.Option{serix.WithValidation(), serix.WithoutGeneratedCode()}},
+	} {
+		b.Run("Encode/"+mode.name, func(b *testing.B) {
+			for range b.N {
+				if _, err := api.Encode(ctx, transaction, mode.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run("Decode/"+mode.name, func(b *testing.B) {
+			for range b.N {
+				if _, err := api.Decode(ctx, transactionBytes, new(codegenTransaction), mode.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run("JSONEncode/"+mode.name, func(b *testing.B) {
+			for range b.N {
+				if _, err := api.JSONEncode(ctx, transaction, mode.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
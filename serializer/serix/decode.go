@@ -40,12 +40,14 @@ func (api *API) decode(ctx context.Context, b []byte, value reflect.Value, ts Ty
 		}
 	}
 
+	globalTS, _ := api.typeSettingsRegistry.GetByType(valueType)
+
 	if deserializable != nil {
-		typeSettingValue := value
+		// the type settings of the pointed-to type take precedence over the ones of the pointer
 		if valueType.Kind() == reflect.Ptr {
-			typeSettingValue = value.Elem()
+			elemTS, _ := api.typeSettingsRegistry.GetByType(valueType.Elem())
+			ts = ts.merge(elemTS)
 		}
-		globalTS, _ := api.typeSettingsRegistry.GetByType(typeSettingValue.Type())
 		ts = ts.merge(globalTS)
 		if objectType := ts.ObjectType(); objectType != nil {
 			typeDen, objectCode, err := getTypeDenotationAndCode(objectType)
@@ -54,9 +56,7 @@ func (api *API) decode(ctx context.Context, b []byte, value reflect.Value, ts Ty
 			}
 
 			deseri := serializer.NewDeserializer(b)
-			deseri.CheckTypePrefix(objectCode, typeDen, func(err error) error {
-				return ierrors.Wrap(err, "failed to check object type")
-			})
+			deseri.CheckTypePrefix(objectCode, typeDen, typePrefixErrProducer(b, typeDen, objectCode))
 			b = deseri.RemainingBytes()
 			prefixBytesRead, err := deseri.Done()
 			if err != nil {
@@ -76,6 +76,8 @@ func (api *API) decode(ctx context.Context, b []byte, value reflect.Value, ts Ty
 			contextAwareDeserializable.SetDeserializationContext(ctx)
 		}
 	} else {
+		ts = ts.merge(globalTS)
+
 		var err error
 		bytesRead, err = api.decodeBasedOnType(ctx, b, value, valueType, ts, opts)
 		if err != nil {
@@ -83,7 +85,14 @@ func (api *API) decode(ctx context.Context, b []byte, value reflect.Value, ts Ty
 		}
 	}
 
+	if err := checkMaxByteSize(valueType, ts, bytesRead); err != nil {
+		return 0, err
+	}
+
 	if opts.validation {
+		if err := checkValueRules(value, ts); err != nil {
+			return 0, err
+		}
 		if err := api.callSyntacticValidator(ctx, value, valueType); err != nil {
 			return 0, ierrors.Errorf("post-deserialization validation failed: %w", err)
 		}
@@ -92,10 +101,10 @@ func (api *API) decode(ctx context.Context, b []byte, value reflect.Value, ts Ty
 	return bytesRead, nil
 }
 
+// decodeBasedOnType decodes the given bytes into the given value based on its kind.
+// The given type settings must already be merged with the registered type settings of the value type.
 func (api *API) decodeBasedOnType(ctx context.Context, b []byte, value reflect.Value,
 	valueType reflect.Type, ts TypeSettings, opts *options) (int, error) {
-	globalTS, _ := api.typeSettingsRegistry.GetByType(valueType)
-	ts = ts.merge(globalTS)
 	switch value.Kind() {
 	case reflect.Ptr:
 		if valueType == bigIntPtrType {
@@ -125,7 +134,9 @@ func (api *API) decodeBasedOnType(ctx context.Context, b []byte, value reflect.V
 		case reflect.Slice:
 			return api.decodeSlice(ctx, b, elemValue, elemType, ts, opts)
 		case reflect.Ptr:
-			return api.decodeBasedOnType(ctx, b, elemValue, elemType, ts, opts)
+			elemTS, _ := api.typeSettingsRegistry.GetByType(elemType)
+
+			return api.decodeBasedOnType(ctx, b, elemValue, elemType, ts.merge(elemTS), opts)
 		case reflect.Interface:
 			return api.decodeInterface(ctx, b, elemValue, elemType, ts, opts)
 		case reflect.Map:
@@ -133,7 +144,9 @@ func (api *API) decodeBasedOnType(ctx context.Context, b []byte, value reflect.V
 		case reflect.Array:
 			return api.decodeArray(ctx, b, elemValue, ts, opts)
 		default:
-			return api.decodeBasedOnType(ctx, b, elemValue, elemType, ts, opts)
+			elemTS, _ := api.typeSettingsRegistry.GetByType(elemType)
+
+			return api.decodeBasedOnType(ctx, b, elemValue, elemType, ts.merge(elemTS), opts)
 		}
 
 	case reflect.Struct:
@@ -168,18 +181,8 @@ func (api *API) decodeBasedOnType(ctx context.Context, b []byte, value reflect.V
 		deseri.ReadString(
 			addrValue.Interface().(*string),
 			serializer.SeriLengthPrefixType(lengthPrefixType),
-			func(err error) error {
-				err = ierrors.Wrap(err, "failed to read string value from the deserializer")
-
-				switch {
-				case ierrors.Is(err, serializer.ErrDeserializationLengthMinNotReached):
-					return ierrors.Join(err, serializer.ErrArrayValidationMinElementsNotReached)
-				case ierrors.Is(err, serializer.ErrDeserializationLengthMaxExceeded):
-					return ierrors.Join(err, serializer.ErrArrayValidationMaxElementsExceeded)
-				default:
-					return err
-				}
-			}, minLen, maxLen)
+			DecodeLengthErrProducer("failed to read string value from the deserializer"),
+			minLen, maxLen)
 
 		if opts.validation {
 			// check the string for UTF-8 validity
@@ -233,9 +236,15 @@ func (api *API) decodeInterface(
 		return 0, ierrors.WithStack(err)
 	}
 
-	objectType, exists := iObjects.GetObjectTypeByCode(objectCode)
+	objectType, exists, err := iObjects.objectTypeByCodeInVersion(ctx, objectCode, opts)
+	if err != nil {
+		return 0, ierrors.Wrapf(err, "object code: %d, interface: %s", objectCode, valueType)
+	}
 	if !exists || objectType == nil {
-		return 0, ierrors.Wrapf(ErrInterfaceUnderlyingTypeNotRegistered, "object code: %d, interface: %s", objectCode, valueType)
+		return 0, withTypeCodeMismatch(
+			ierrors.Wrapf(ErrInterfaceUnderlyingTypeNotRegistered, "object code: %d, interface: %s", objectCode, valueType),
+			iObjects.objectCodesInVersion(ctx, opts), objectCode,
+		)
 	}
 
 	objectValue := reflect.New(objectType).Elem()
@@ -267,11 +276,18 @@ func (api *API) decodeStruct(ctx context.Context, b []byte, value reflect.Value,
 		if err != nil {
 			return 0, ierrors.WithStack(err)
 		}
-		deseri.CheckTypePrefix(objectCode, typeDen, func(err error) error {
-			return ierrors.Wrap(err, "failed to check object type")
-		})
+		deseri.CheckTypePrefix(objectCode, typeDen, typePrefixErrProducer(b, typeDen, objectCode))
+
+		// don't decode the fields of an object of the wrong type
+		if _, err := deseri.Done(); err != nil {
+			return 0, err
+		}
 	}
-	if err := api.decodeStructFields(ctx, deseri, value, valueType, opts); err != nil {
+	if generated, ok := api.generatedDeserializable(value, opts); ok {
+		if err := generated.SerixDecode(ctx, api, deseri, opts.toOptions()...); err != nil {
+			return 0, ierrors.WithStack(err)
+		}
+	} else if err := api.decodeStructFields(ctx, deseri, value, valueType, opts); err != nil {
 		return 0, ierrors.WithStack(err)
 	}
 
@@ -290,59 +306,80 @@ func (api *API) decodeStructFields(
 	}
 
 	for _, sField := range structFields {
-		fieldValue := value.Field(sField.index)
-		if sField.isEmbedded && !sField.settings.inlined {
-			fieldType := sField.fType
-			if fieldType.Kind() == reflect.Ptr {
-				if fieldValue.IsNil() {
-					if sField.isUnexported {
-						return ierrors.Errorf(
-							"embedded field %s is a nil pointer, can't initialize because it's unexported",
-							sField.name,
-						)
-					}
-					fieldValue.Set(reflect.New(fieldType.Elem()))
+		if err := api.decodeStructField(ctx, deseri, value, sField, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (api *API) decodeStructField(
+	ctx context.Context, deseri *serializer.Deserializer, value reflect.Value, sField structField, opts *options,
+) error {
+	inVersion, err := opts.inProtocolVersion(ctx, sField.settings.versionRange)
+	if err != nil {
+		return ierrors.Wrapf(err, "can't deserialize struct field %s", sField.name)
+	}
+	if !inVersion {
+		// the field is not part of the protocol version
+		return nil
+	}
+
+	fieldValue := value.Field(sField.index)
+	if sField.isEmbedded && !sField.settings.inlined {
+		fieldType := sField.fType
+		if fieldType.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				if sField.isUnexported {
+					return ierrors.Errorf(
+						"embedded field %s is a nil pointer, can't initialize because it's unexported",
+						sField.name,
+					)
 				}
-				fieldValue = fieldValue.Elem()
-				fieldType = fieldType.Elem()
-			}
-			if err := api.decodeStructFields(ctx, deseri, fieldValue, fieldType, opts); err != nil {
-				return ierrors.Wrapf(err, "can't deserialize embedded struct %s", sField.name)
+				fieldValue.Set(reflect.New(fieldType.Elem()))
 			}
+			fieldValue = fieldValue.Elem()
+			fieldType = fieldType.Elem()
+		}
+		if err := api.decodeStructFields(ctx, deseri, fieldValue, fieldType, opts); err != nil {
+			return ierrors.Wrapf(err, "can't deserialize embedded struct %s", sField.name)
+		}
 
-			continue
+		return nil
+	}
+	var bytesRead int
+	if sField.settings.isOptional {
+		payloadLength, err := deseri.ReadPayloadLength()
+		if err != nil {
+			return ierrors.Wrap(err, "can't read payload length from the deserializer")
+		}
+		if payloadLength == 0 {
+			return nil
 		}
-		var bytesRead int
-		if sField.settings.isOptional {
-			payloadLength, err := deseri.ReadPayloadLength()
-			if err != nil {
-				return ierrors.Wrap(err, "can't read payload length from the deserializer")
-			}
-			if payloadLength == 0 {
-				continue
-			}
 
-			bytesRead, err = api.decode(ctx, deseri.RemainingBytes(), fieldValue, sField.settings.ts, opts)
-			if err != nil {
-				return ierrors.Wrapf(err, "failed to deserialize optional struct field %s", sField.name)
-			}
-			if bytesRead != int(payloadLength) {
-				return ierrors.Wrapf(
-					err,
-					"optional object length isn't equal to the amount of bytes read; length=%d, bytesRead=%d",
-					payloadLength, bytesRead,
-				)
-			}
-		} else {
-			bytesRead, err = api.decode(ctx, deseri.RemainingBytes(), fieldValue, sField.settings.ts, opts)
-			if err != nil {
-				return ierrors.Wrapf(err, "failed to deserialize struct field %s", sField.name)
-			}
+		fieldOffset, _ := deseri.Done()
+		bytesRead, err = api.decode(ctx, deseri.RemainingBytes(), fieldValue, sField.settings.ts, opts)
+		if err != nil {
+			return ierrors.Wrapf(withDecodeErrorLocation(err, fieldPathElement(sField.name), fieldOffset), "failed to deserialize optional struct field %s", sField.name)
+		}
+		if bytesRead != int(payloadLength) {
+			return ierrors.Wrapf(
+				err,
+				"optional object length isn't equal to the amount of bytes read; length=%d, bytesRead=%d",
+				payloadLength, bytesRead,
+			)
+		}
+	} else {
+		fieldOffset, _ := deseri.Done()
+		bytesRead, err = api.decode(ctx, deseri.RemainingBytes(), fieldValue, sField.settings.ts, opts)
+		if err != nil {
+			return ierrors.Wrapf(withDecodeErrorLocation(err, fieldPathElement(sField.name), fieldOffset), "failed to deserialize struct field %s", sField.name)
 		}
-		deseri.Skip(bytesRead, func(err error) error {
-			return ierrors.Wrap(err, "failed to skip amount of bytes read for the struct field")
-		})
 	}
+	deseri.Skip(bytesRead, func(err error) error {
+		return ierrors.Wrap(err, "failed to skip amount of bytes read for the struct field")
+	})
 
 	return nil
 }
@@ -359,9 +396,7 @@ func (api *API) decodeArray(ctx context.Context, b []byte, value reflect.Value,
 			if err != nil {
 				return 0, ierrors.WithStack(err)
 			}
-			deseri.CheckTypePrefix(objectCode, typeDen, func(err error) error {
-				return ierrors.Wrap(err, "failed to check object type")
-			})
+			deseri.CheckTypePrefix(objectCode, typeDen, typePrefixErrProducer(b, typeDen, objectCode))
 		}
 		deseri.ReadBytesInPlace(sliceValue.Bytes(), func(err error) error {
 			return ierrors.Wrap(err, "failed to read array of bytes from the deserializer")
@@ -391,27 +426,19 @@ func (api *API) decodeSlice(ctx context.Context, b []byte, value reflect.Value,
 		deseri.ReadVariableByteSlice(
 			addrValue.Interface().(*[]byte),
 			serializer.SeriLengthPrefixType(lengthPrefixType),
-			func(err error) error {
-				err = ierrors.Wrap(err, "failed to read bytes from the deserializer")
-
-				switch {
-				case ierrors.Is(err, serializer.ErrDeserializationLengthMinNotReached):
-					return ierrors.Join(err, serializer.ErrArrayValidationMinElementsNotReached)
-				case ierrors.Is(err, serializer.ErrDeserializationLengthMaxExceeded):
-					return ierrors.Join(err, serializer.ErrArrayValidationMaxElementsExceeded)
-				default:
-					return err
-				}
-			}, minLen, maxLen)
+			DecodeLengthErrProducer("failed to read bytes from the deserializer"),
+			minLen, maxLen)
 
 		return deseri.Done()
 	}
-	deserializeItem := func(b []byte) (bytesRead int, err error) {
+	var index int
+	deserializeItem := func(itemBytes []byte) (bytesRead int, err error) {
 		elemValue := reflect.New(valueType.Elem()).Elem()
-		bytesRead, err = api.decode(ctx, b, elemValue, TypeSettings{}, opts)
+		bytesRead, err = api.decode(ctx, itemBytes, elemValue, TypeSettings{}, opts)
 		if err != nil {
-			return 0, ierrors.WithStack(err)
+			return 0, withDecodeErrorLocation(ierrors.WithStack(err), indexPathElement(index), len(b)-len(itemBytes))
 		}
+		index++
 		value.Set(reflect.Append(value, elemValue))
 
 		return bytesRead, nil
@@ -448,7 +475,7 @@ func (api *API) decodeMapKVPair(ctx context.Context, b []byte, key, val reflect.
 	b = b[keyBytesRead:]
 	elemBytesRead, err := api.decode(ctx, b, val, valueTypeSettings, opts)
 	if err != nil {
-		return 0, ierrors.Wrapf(err, "failed to decode map element of type %s", val.Type())
+		return 0, ierrors.Wrapf(withDecodeErrorLocation(err, "", keyBytesRead), "failed to decode map element of type %s", val.Type())
 	}
 
 	return keyBytesRead + elemBytesRead, nil
@@ -460,18 +487,23 @@ func (api *API) decodeMap(ctx context.Context, b []byte, value reflect.Value,
 		value.Set(reflect.MakeMap(valueType))
 	}
 
-	deserializeItem := func(b []byte) (bytesRead int, err error) {
+	var index int
+	deserializeItem := func(itemBytes []byte) (bytesRead int, err error) {
 		keyValue := reflect.New(valueType.Key()).Elem()
 		elemValue := reflect.New(valueType.Elem()).Elem()
-		bytesRead, err = api.decodeMapKVPair(ctx, b, keyValue, elemValue, opts)
+		bytesRead, err = api.decodeMapKVPair(ctx, itemBytes, keyValue, elemValue, opts)
 		if err != nil {
-			return 0, ierrors.WithStack(err)
+			return 0, withDecodeErrorLocation(ierrors.WithStack(err), indexPathElement(index), len(b)-len(itemBytes))
 		}
 
 		if value.MapIndex(keyValue).IsValid() {
 			// map entry already exists
-			return 0, ierrors.Wrapf(ErrMapValidationViolatesUniqueness, "map entry with key %v already exists", keyValue.Interface())
+			return 0, withDecodeErrorLocation(
+				ierrors.Wrapf(ErrMapValidationViolatesUniqueness, "map entry with key %v already exists", keyValue.Interface()),
+				indexPathElement(index), len(b)-len(itemBytes),
+			)
 		}
+		index++
 
 		value.SetMapIndex(keyValue, elemValue)
 
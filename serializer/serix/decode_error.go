@@ -0,0 +1,149 @@
+package serix
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2"
+)
+
+// DecodeError is returned by the decoding methods of the API and describes where in the decoded object the decoding
+// or the validation failed. It can be retrieved from the returned errors via ierrors.As.
+// Fields that are decoded by generated code (see API.GenerateCode) are reported with the path of their struct.
+type DecodeError struct {
+	// Path is the path of the value that failed to decode, e.g. "Outputs[3].UnlockConditions[0].Address".
+	// It is empty if the object itself failed to decode.
+	Path string
+	// Offset is the byte offset of the value that failed to decode within the serialized object.
+	// It is -1 if the object was decoded from JSON.
+	Offset int
+	// ExpectedTypeCodes holds the allowed type codes if the value failed to decode because of an unexpected type code.
+	ExpectedTypeCodes []uint32
+	// ActualTypeCode holds the encountered type code if ExpectedTypeCodes is set.
+	ActualTypeCode uint32
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the error message including the location of the failed value.
+func (e *DecodeError) Error() string {
+	var builder strings.Builder
+	builder.WriteString("failed to decode")
+	if e.Path != "" {
+		fmt.Fprintf(&builder, " %s", e.Path)
+	}
+	if e.Offset >= 0 {
+		fmt.Fprintf(&builder, " at offset %d", e.Offset)
+	}
+	if len(e.ExpectedTypeCodes) > 0 {
+		fmt.Fprintf(&builder, " (expected type code %v, got %d)", e.ExpectedTypeCodes, e.ActualTypeCode)
+	}
+	fmt.Fprintf(&builder, ": %s", e.Err)
+
+	return builder.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeErrorLocation is attached to decode errors while they are passed up through the decoding of the nested values,
+// to collect the path and the offset of the value that failed to decode.
+type decodeErrorLocation struct {
+	// reversedPath holds the elements of the path from the innermost to the outermost value.
+	reversedPath      []string
+	offset            int
+	expectedTypeCodes []uint32
+	actualTypeCode    uint32
+	err               error
+}
+
+func (l *decodeErrorLocation) Error() string {
+	return l.err.Error()
+}
+
+func (l *decodeErrorLocation) Unwrap() error {
+	return l.err
+}
+
+// fieldPathElement returns the path element of the struct field with the given name.
+func fieldPathElement(name string) string {
+	return "." + name
+}
+
+// indexPathElement returns the path element of the collection element with the given index or key.
+func indexPathElement(index any) string {
+	return fmt.Sprintf("[%v]", index)
+}
+
+// withDecodeErrorLocation adds the given path element and offset to the location of the given decode error.
+// The offset is the offset of the failed value within the enclosing value, the path element can be empty if the
+// value has no own path element.
+func withDecodeErrorLocation(err error, pathElement string, offset int) error {
+	var location *decodeErrorLocation
+	if !ierrors.As(err, &location) {
+		location = &decodeErrorLocation{err: err}
+		err = location
+	}
+
+	if pathElement != "" {
+		location.reversedPath = append(location.reversedPath, pathElement)
+	}
+	location.offset += offset
+
+	return err
+}
+
+// withTypeCodeMismatch attaches the expected and the actual type code to the given decode error.
+func withTypeCodeMismatch(err error, expectedTypeCodes []uint32, actualTypeCode uint32) error {
+	return &decodeErrorLocation{
+		expectedTypeCodes: expectedTypeCodes,
+		actualTypeCode:    actualTypeCode,
+		err:               err,
+	}
+}
+
+// typePrefixErrProducer returns the error producer for the type prefix check at the start of the given bytes, which
+// attaches the expected and the actual type code to type mismatch errors.
+func typePrefixErrProducer(b []byte, typeDen serializer.TypeDenotationType, objectCode uint32) serializer.ErrProducer {
+	return func(err error) error {
+		err = ierrors.Wrap(err, "failed to check object type")
+		if !ierrors.Is(err, serializer.ErrDeserializationTypeMismatch) {
+			return err
+		}
+
+		actualTypeCode, _ := serializer.NewDeserializer(b).GetObjectType(typeDen)
+
+		return withTypeCodeMismatch(err, []uint32{objectCode}, actualTypeCode)
+	}
+}
+
+// newDecodeError returns the DecodeError of the given error that occurred while decoding an object.
+func newDecodeError(err error, binary bool) error {
+	if err == nil {
+		return nil
+	}
+
+	decodeError := &DecodeError{Err: err}
+	if !binary {
+		decodeError.Offset = -1
+	}
+
+	var location *decodeErrorLocation
+	if ierrors.As(err, &location) {
+		path := slices.Clone(location.reversedPath)
+		slices.Reverse(path)
+
+		decodeError.Path = strings.TrimPrefix(strings.Join(path, ""), ".")
+		decodeError.ExpectedTypeCodes = location.expectedTypeCodes
+		decodeError.ActualTypeCode = location.actualTypeCode
+		if binary {
+			decodeError.Offset = location.offset
+		}
+	}
+
+	return decodeError
+}
@@ -0,0 +1,138 @@
+package serix_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+func newDecodeErrorTestTransaction() *schemaTransaction {
+	return &schemaTransaction{
+		Outputs: []*schemaOutput{
+			{Amount: 1, Address: &schemaEd25519Address{1, 2, 3, 4}, Tag: []byte{1}},
+			{Amount: 2, Address: &schemaAccountAddress{AccountID: [2]byte{5, 6}}, Metadata: &schemaMetadata{Name: "b"}},
+		},
+	}
+}
+
+func requireDecodeError(t *testing.T, err error, path string, offset int) *serix.DecodeError {
+	t.Helper()
+
+	var decodeErr *serix.DecodeError
+	require.True(t, ierrors.As(err, &decodeErr), "expected a DecodeError, got %v", err)
+	require.Equal(t, path, decodeErr.Path)
+	require.Equal(t, offset, decodeErr.Offset)
+
+	return decodeErr
+}
+
+func TestDecodeErrorBinary(t *testing.T) {
+	api := newSchemaTestAPI(t)
+	ctx := context.Background()
+	tx := newDecodeErrorTestTransaction()
+
+	encoded, err := api.Encode(ctx, tx)
+	require.NoError(t, err)
+
+	firstOutput, err := api.Encode(ctx, tx.Outputs[0])
+	require.NoError(t, err)
+
+	// object type (1 byte), outputs length prefix (2 bytes), first output, amount of the second output (8 bytes)
+	addressOffset := 1 + 2 + len(firstOutput) + 8
+
+	t.Run("unknown interface object", func(t *testing.T) {
+		corrupted := bytes.Clone(encoded)
+		corrupted[addressOffset] = 5
+
+		_, err := api.Decode(ctx, corrupted, new(schemaTransaction))
+		require.ErrorIs(t, err, serix.ErrInterfaceUnderlyingTypeNotRegistered)
+		decodeErr := requireDecodeError(t, err, "Outputs[1].Address", addressOffset)
+		require.Equal(t, []uint32{0, 8}, decodeErr.ExpectedTypeCodes)
+		require.EqualValues(t, 5, decodeErr.ActualTypeCode)
+
+		// the streaming decoder reports the same location
+		_, err = api.DecodeFrom(ctx, bytes.NewReader(corrupted), new(schemaTransaction))
+		require.ErrorIs(t, err, serix.ErrInterfaceUnderlyingTypeNotRegistered)
+		decodeErr = requireDecodeError(t, err, "Outputs[1].Address", addressOffset)
+		require.Equal(t, []uint32{0, 8}, decodeErr.ExpectedTypeCodes)
+		require.EqualValues(t, 5, decodeErr.ActualTypeCode)
+	})
+
+	t.Run("object type mismatch", func(t *testing.T) {
+		corrupted := bytes.Clone(encoded)
+		corrupted[0] = 2
+
+		_, err := api.Decode(ctx, corrupted, new(schemaTransaction))
+		require.ErrorIs(t, err, serializer.ErrDeserializationTypeMismatch)
+		decodeErr := requireDecodeError(t, err, "", 0)
+		require.Equal(t, []uint32{1}, decodeErr.ExpectedTypeCodes)
+		require.EqualValues(t, 2, decodeErr.ActualTypeCode)
+	})
+
+	t.Run("not enough data", func(t *testing.T) {
+		_, err := api.Decode(ctx, encoded[:addressOffset+2], new(schemaTransaction))
+		require.ErrorIs(t, err, serializer.ErrDeserializationNotEnoughData)
+		decodeErr := requireDecodeError(t, err, "Outputs[1].Address.AccountID", addressOffset+1)
+		require.Empty(t, decodeErr.ExpectedTypeCodes)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		invalidTx := newDecodeErrorTestTransaction()
+		invalidTx.Outputs[0].Metadata = &schemaMetadata{}
+
+		invalid, err := api.Encode(ctx, invalidTx)
+		require.NoError(t, err)
+
+		_, err = api.Decode(ctx, invalid, new(schemaTransaction), serix.WithValidation())
+		require.ErrorIs(t, err, serializer.ErrArrayValidationMinElementsNotReached)
+		// object type (1 byte), outputs length prefix (2 bytes), amount (8 bytes), address (1 + 4 bytes), tag (1 + 1 bytes),
+		// optional payload length (4 bytes)
+		requireDecodeError(t, err, "Outputs[0].Metadata.Name", 1+2+8+5+2+4)
+	})
+}
+
+func TestDecodeErrorJSON(t *testing.T) {
+	api := newSchemaTestAPI(t)
+	ctx := context.Background()
+
+	encoded, err := api.JSONEncode(ctx, newDecodeErrorTestTransaction())
+	require.NoError(t, err)
+
+	decodeCorrupted := func(corrupt func(outputs []any), opts ...serix.Option) error {
+		m := map[string]any{}
+		require.NoError(t, json.Unmarshal(encoded, &m))
+		//nolint:forcetypeassert // the structure is known
+		corrupt(m["outputs"].([]any))
+
+		return api.MapDecode(ctx, m, new(schemaTransaction), opts...)
+	}
+
+	err = decodeCorrupted(func(outputs []any) {
+		//nolint:forcetypeassert // the structure is known
+		outputs[1].(map[string]any)["address"].(map[string]any)["type"] = float64(5)
+	})
+	require.ErrorIs(t, err, serix.ErrInterfaceUnderlyingTypeNotRegistered)
+	decodeErr := requireDecodeError(t, err, "Outputs[1].Address", -1)
+	require.Equal(t, []uint32{0, 8}, decodeErr.ExpectedTypeCodes)
+	require.EqualValues(t, 5, decodeErr.ActualTypeCode)
+
+	err = decodeCorrupted(func(outputs []any) {
+		//nolint:forcetypeassert // the structure is known
+		delete(outputs[0].(map[string]any), "amount")
+	})
+	requireDecodeError(t, err, "Outputs[0].Amount", -1)
+
+	err = decodeCorrupted(func(outputs []any) {
+		//nolint:forcetypeassert // the structure is known
+		outputs[0].(map[string]any)["metadata"] = map[string]any{"metadataName": ""}
+	}, serix.WithValidation())
+	require.ErrorIs(t, err, serializer.ErrArrayValidationMinElementsNotReached)
+	requireDecodeError(t, err, "Outputs[0].Metadata.Name", -1)
+}
@@ -16,6 +16,7 @@ import (
 func (api *API) encode(ctx context.Context, value reflect.Value, ts TypeSettings, opts *options) (b []byte, err error) {
 	valueI := value.Interface()
 	valueType := value.Type()
+	globalTS, _ := api.typeSettingsRegistry.GetByType(valueType)
 	if opts.validation {
 		if err = api.callSyntacticValidator(ctx, value, valueType); err != nil {
 			return nil, ierrors.Errorf("pre-serialization validation failed: %w", err)
@@ -23,11 +24,11 @@ func (api *API) encode(ctx context.Context, value reflect.Value, ts TypeSettings
 	}
 
 	if serializable, ok := valueI.(Serializable); ok {
-		typeSettingValue := value
+		// the type settings of the dynamic type take precedence over the ones of the interface
 		if valueType.Kind() == reflect.Interface {
-			typeSettingValue = value.Elem()
+			elemTS, _ := api.typeSettingsRegistry.GetByType(value.Elem().Type())
+			ts = ts.merge(elemTS)
 		}
-		globalTS, _ := api.typeSettingsRegistry.GetByType(typeSettingValue.Type())
 		ts = ts.merge(globalTS)
 
 		var bPrefix, bEncoded []byte
@@ -48,21 +49,25 @@ func (api *API) encode(ctx context.Context, value reflect.Value, ts TypeSettings
 		}
 		b = byteutils.ConcatBytes(bPrefix, bEncoded)
 	} else {
+		ts = ts.merge(globalTS)
 		b, err = api.encodeBasedOnType(ctx, value, valueI, valueType, ts, opts)
 		if err != nil {
 			return nil, ierrors.WithStack(err)
 		}
 	}
 
+	if err = checkMaxByteSize(valueType, ts, len(b)); err != nil {
+		return nil, err
+	}
+
 	return b, nil
 }
 
+// encodeBasedOnType encodes the given value based on its kind.
+// The given type settings must already be merged with the registered type settings of the value type.
 func (api *API) encodeBasedOnType(
 	ctx context.Context, value reflect.Value, valueI interface{}, valueType reflect.Type, ts TypeSettings, opts *options,
 ) ([]byte, error) {
-	globalTS, _ := api.typeSettingsRegistry.GetByType(valueType)
-	ts = ts.merge(globalTS)
-
 	if opts.validation {
 		if err := ts.checkMinMaxBounds(value); err != nil {
 			return nil, err
@@ -164,7 +169,11 @@ func (api *API) encodeInterface(
 	}
 
 	elemType := elemValue.Type()
-	if exists := registry.HasObjectType(elemType); !exists {
+	exists, err := registry.hasObjectTypeInVersion(ctx, elemType, opts)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "interface: %s", valueType)
+	}
+	if !exists {
 		return nil, ierrors.Wrapf(ErrInterfaceUnderlyingTypeNotRegistered, "type: %s, interface: %s", elemType, valueType)
 	}
 
@@ -192,7 +201,11 @@ func (api *API) encodeStruct(
 			return ierrors.Wrap(err, "failed to write object type code into serializer")
 		})
 	}
-	if err := api.encodeStructFields(ctx, seri, value, valueType, opts); err != nil {
+	if generated, ok := api.generatedSerializable(valueI, opts); ok {
+		if err := generated.SerixEncode(ctx, api, seri, opts.toOptions()...); err != nil {
+			return nil, ierrors.WithStack(err)
+		}
+	} else if err := api.encodeStructFields(ctx, seri, value, valueType, opts); err != nil {
 		return nil, ierrors.WithStack(err)
 	}
 
@@ -211,58 +224,76 @@ func (api *API) encodeStructFields(
 	}
 
 	for _, sField := range structFields {
-		fieldValue := value.Field(sField.index)
-		if sField.isEmbedded && !sField.settings.inlined {
-			fieldType := sField.fType
-			if fieldValue.Kind() == reflect.Ptr {
-				if fieldValue.IsNil() {
-					continue
-				}
-				fieldValue = fieldValue.Elem()
-				fieldType = fieldType.Elem()
-			}
-			if err := api.encodeStructFields(ctx, s, fieldValue, fieldType, opts); err != nil {
-				return ierrors.Wrapf(err, "can't serialize embedded struct %s", sField.name)
-			}
-
-			continue
+		if err := api.encodeStructField(ctx, s, value, sField, opts); err != nil {
+			return err
 		}
-		var fieldBytes []byte
-		if sField.settings.isOptional {
+	}
+
+	return nil
+}
+
+func (api *API) encodeStructField(
+	ctx context.Context, s *serializer.Serializer, value reflect.Value, sField structField, opts *options,
+) error {
+	inVersion, err := opts.inProtocolVersion(ctx, sField.settings.versionRange)
+	if err != nil {
+		return ierrors.Wrapf(err, "can't serialize struct field %s", sField.name)
+	}
+	if !inVersion {
+		// the field is not part of the protocol version
+		return nil
+	}
+
+	fieldValue := value.Field(sField.index)
+	if sField.isEmbedded && !sField.settings.inlined {
+		fieldType := sField.fType
+		if fieldValue.Kind() == reflect.Ptr {
 			if fieldValue.IsNil() {
-				s.WritePayloadLength(0, func(err error) error {
-					return ierrors.Wrapf(err,
-						"failed to write zero length for an optional struct field %s to serializer",
-						sField.name,
-					)
-				})
-
-				continue
+				return nil
 			}
-			fieldBytes, err = api.encode(ctx, fieldValue, sField.settings.ts, opts)
-			if err != nil {
-				return ierrors.Wrapf(err, "failed to serialize optional struct field %s", sField.name)
-			}
-			s.WritePayloadLength(len(fieldBytes), func(err error) error {
+			fieldValue = fieldValue.Elem()
+			fieldType = fieldType.Elem()
+		}
+		if err := api.encodeStructFields(ctx, s, fieldValue, fieldType, opts); err != nil {
+			return ierrors.Wrapf(err, "can't serialize embedded struct %s", sField.name)
+		}
+
+		return nil
+	}
+	var fieldBytes []byte
+	if sField.settings.isOptional {
+		if fieldValue.IsNil() {
+			s.WritePayloadLength(0, func(err error) error {
 				return ierrors.Wrapf(err,
-					"failed to write length for an optional struct field %s to serializer",
+					"failed to write zero length for an optional struct field %s to serializer",
 					sField.name,
 				)
 			})
-		} else {
-			b, err := api.encode(ctx, fieldValue, sField.settings.ts, opts)
-			if err != nil {
-				return ierrors.Wrapf(err, "failed to serialize struct field %s", sField.name)
-			}
-			fieldBytes = b
+
+			return nil
 		}
-		s.WriteBytes(fieldBytes, func(err error) error {
+		fieldBytes, err = api.encode(ctx, fieldValue, sField.settings.ts, opts)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to serialize optional struct field %s", sField.name)
+		}
+		s.WritePayloadLength(len(fieldBytes), func(err error) error {
 			return ierrors.Wrapf(err,
-				"failed to write serialized struct field bytes to serializer, field=%s",
+				"failed to write length for an optional struct field %s to serializer",
 				sField.name,
 			)
 		})
+	} else {
+		fieldBytes, err = api.encode(ctx, fieldValue, sField.settings.ts, opts)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to serialize struct field %s", sField.name)
+		}
 	}
+	s.WriteBytes(fieldBytes, func(err error) error {
+		return ierrors.Wrapf(err,
+			"failed to write serialized struct field bytes to serializer, field=%s",
+			sField.name,
+		)
+	})
 
 	return nil
 }
@@ -0,0 +1,245 @@
+package serix
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/iancoleman/orderedmap"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2"
+)
+
+// GeneratedSerializable is a struct whose serialization was generated by API.GenerateCode.
+// serix calls SerixEncode to serialize the fields of the struct instead of traversing them via reflection.
+// The object type of the struct is still written by serix.
+type GeneratedSerializable interface {
+	SerixEncode(ctx context.Context, api *API, seri *serializer.Serializer, opts ...Option) error
+}
+
+// GeneratedDeserializable is a struct whose deserialization was generated by API.GenerateCode.
+// serix calls SerixDecode to deserialize the fields of the struct instead of traversing them via reflection.
+// The object type of the struct is still checked by serix.
+type GeneratedDeserializable interface {
+	SerixDecode(ctx context.Context, api *API, deseri *serializer.Deserializer, opts ...Option) error
+}
+
+// GeneratedSerializableJSON is a struct whose JSON serialization was generated by API.GenerateCode.
+// serix calls SerixEncodeJSON to serialize the fields of the struct instead of traversing them via reflection.
+// The object type of the struct is still written by serix.
+type GeneratedSerializableJSON interface {
+	SerixEncodeJSON(ctx context.Context, api *API, obj *orderedmap.OrderedMap, opts ...Option) error
+}
+
+// GeneratedCode is a struct whose methods were generated by API.GenerateCode.
+// SerixFingerprint returns the fingerprint of the struct tags and type settings the methods were generated for. An API
+// only uses the generated methods if its own settings for the struct produce the same fingerprint.
+type GeneratedCode interface {
+	SerixFingerprint() string
+}
+
+// WithoutGeneratedCode returns an Option that tells serix to ignore the generated methods and to always use reflection.
+func WithoutGeneratedCode() Option {
+	return func(o *options) {
+		o.withoutGeneratedCode = true
+	}
+}
+
+// ValidationEnabled returns whether the given options tell serix to perform validation.
+func ValidationEnabled(opts ...Option) bool {
+	return applyOptions(opts).validation
+}
+
+// CheckLengthBounds checks whether the given length is within the given bounds (0 means unbounded).
+func CheckLengthBounds(length int, minLen uint, maxLen uint) error {
+	return TypeSettings{}.WithMinLen(minLen).WithMaxLen(maxLen).checkMinMaxBoundsLength(length)
+}
+
+// DecodeLengthErrProducer returns the serializer.ErrProducer that is used for the length checks of strings and byte
+// slices during the deserialization.
+func DecodeLengthErrProducer(message string) serializer.ErrProducer {
+	return func(err error) error {
+		err = ierrors.Wrap(err, message)
+
+		switch {
+		case ierrors.Is(err, serializer.ErrDeserializationLengthMinNotReached):
+			return ierrors.Join(err, serializer.ErrArrayValidationMinElementsNotReached)
+		case ierrors.Is(err, serializer.ErrDeserializationLengthMaxExceeded):
+			return ierrors.Join(err, serializer.ErrArrayValidationMaxElementsExceeded)
+		default:
+			return err
+		}
+	}
+}
+
+// EncodeStructField serializes the struct field with the given index of obj (a struct or a pointer to a struct) via
+// reflection. It is used by the generated methods for the fields they don't serialize themselves.
+func (api *API) EncodeStructField(ctx context.Context, seri *serializer.Serializer, obj any, fieldIndex int, opts ...Option) error {
+	// abort early if a previous write of the generated code already failed
+	if _, err := seri.Serialize(); err != nil {
+		return err
+	}
+
+	value, sField, err := api.structFieldByIndex(obj, fieldIndex)
+	if err != nil {
+		return err
+	}
+
+	return api.encodeStructField(ctx, seri, value, sField, applyOptions(opts))
+}
+
+// DecodeStructField deserializes the struct field with the given index of obj (a pointer to a struct) via
+// reflection. It is used by the generated methods for the fields they don't deserialize themselves.
+func (api *API) DecodeStructField(ctx context.Context, deseri *serializer.Deserializer, obj any, fieldIndex int, opts ...Option) error {
+	// the field is decoded from the remaining bytes, so previous errors of the deserializer need to be checked first
+	if _, err := deseri.Done(); err != nil {
+		return err
+	}
+
+	value, sField, err := api.structFieldByIndex(obj, fieldIndex)
+	if err != nil {
+		return err
+	}
+
+	if !value.CanAddr() {
+		return ierrors.Errorf("can't decode field %s: the destination object must be a pointer", sField.name)
+	}
+
+	return api.decodeStructField(ctx, deseri, value, sField, applyOptions(opts))
+}
+
+// MapEncodeStructField serializes the struct field with the given index of obj (a struct or a pointer to a struct)
+// into the given ordered map via reflection. It is used by the generated methods for the fields they don't serialize
+// themselves.
+func (api *API) MapEncodeStructField(ctx context.Context, m *orderedmap.OrderedMap, obj any, fieldIndex int, opts ...Option) error {
+	value, sField, err := api.structFieldByIndex(obj, fieldIndex)
+	if err != nil {
+		return err
+	}
+
+	return api.mapEncodeStructField(ctx, m, value, sField, applyOptions(opts))
+}
+
+// structFieldByIndex returns the struct value of obj and the parsed serix settings of its field with the given index.
+func (api *API) structFieldByIndex(obj any, fieldIndex int) (reflect.Value, structField, error) {
+	value := reflect.Indirect(reflect.ValueOf(obj))
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, structField{}, ierrors.Errorf("expected a struct, got %T", obj)
+	}
+
+	structFields, err := api.getStructFields(value.Type())
+	if err != nil {
+		return reflect.Value{}, structField{}, ierrors.Wrapf(err, "can't parse struct type %s", value.Type())
+	}
+
+	for _, sField := range structFields {
+		if sField.index == fieldIndex {
+			return value, sField, nil
+		}
+	}
+
+	return reflect.Value{}, structField{}, ierrors.Errorf("struct type %s has no serix field with index %d", value.Type(), fieldIndex)
+}
+
+// WriteSliceOfObjects writes the given serialized objects as a slice with the given length prefix into the given
+// serializer. It is used by the generated methods for slices of structs.
+func WriteSliceOfObjects(seri *serializer.Serializer, objects [][]byte, lengthPrefixType serializer.SeriLengthPrefixType, minLen uint, maxLen uint, errProducer serializer.ErrProducer, opts ...Option) {
+	seri.WriteSliceOfByteSlices(objects, applyOptions(opts).toMode(), lengthPrefixType, &serializer.ArrayRules{Min: minLen, Max: maxLen}, errProducer)
+}
+
+// ReadSliceOfObjects reads a slice with the given length prefix from the given deserializer and deserializes its
+// elements with the given function. It is used by the generated methods for slices of structs.
+func ReadSliceOfObjects(deseri *serializer.Deserializer, deserializeFunc serializer.DeserializeFunc, lengthPrefixType serializer.SeriLengthPrefixType, minLen uint, maxLen uint, errProducer serializer.ErrProducer, opts ...Option) {
+	deseri.ReadSequenceOfObjects(deserializeFunc, applyOptions(opts).toMode(), lengthPrefixType, &serializer.ArrayRules{Min: minLen, Max: maxLen}, errProducer)
+}
+
+// generatedCodeCache caches the fingerprints of the generated code of the structs for the settings of an API.
+type generatedCodeCache struct {
+	cacheMutex sync.RWMutex
+	cache      map[reflect.Type]string
+}
+
+func newGeneratedCodeCache() *generatedCodeCache {
+	return &generatedCodeCache{
+		cache: make(map[reflect.Type]string),
+	}
+}
+
+func (c *generatedCodeCache) Get(structType reflect.Type) (string, bool) {
+	c.cacheMutex.RLock()
+	defer c.cacheMutex.RUnlock()
+	fingerprint, exists := c.cache[structType]
+
+	return fingerprint, exists
+}
+
+func (c *generatedCodeCache) Set(structType reflect.Type, fingerprint string) {
+	c.cacheMutex.Lock()
+	defer c.cacheMutex.Unlock()
+
+	c.cache[structType] = fingerprint
+}
+
+// Reset clears the cache, since the fingerprints change with the registered type settings and validators.
+func (c *generatedCodeCache) Reset() {
+	c.cacheMutex.Lock()
+	defer c.cacheMutex.Unlock()
+
+	c.cache = make(map[reflect.Type]string)
+}
+
+// generatedCodeMatches returns whether the generated methods of the given struct (or of the pointer to it) were
+// generated for the settings of the API. The fingerprint of structs for which no code can be generated with the
+// settings of the API is empty, so their generated methods are never used.
+func (api *API) generatedCodeMatches(structType reflect.Type, obj any) bool {
+	generatedCode, ok := obj.(GeneratedCode)
+	if !ok {
+		return false
+	}
+
+	fingerprint, exists := api.generatedCodeCache.Get(structType)
+	if !exists {
+		//nolint:errcheck // the error is represented by the empty fingerprint
+		fingerprint, _ = newCodeGenerator(api).fingerprint(structType)
+		api.generatedCodeCache.Set(structType, fingerprint)
+	}
+
+	return fingerprint != "" && generatedCode.SerixFingerprint() == fingerprint
+}
+
+// generatedSerializable returns the generated serialization of the given struct if it exists, is not disabled and
+// matches the settings of the API.
+func (api *API) generatedSerializable(valueI any, opts *options) (GeneratedSerializable, bool) {
+	if opts.withoutGeneratedCode {
+		return nil, false
+	}
+
+	generated, ok := valueI.(GeneratedSerializable)
+
+	return generated, ok && api.generatedCodeMatches(reflect.TypeOf(valueI), valueI)
+}
+
+// generatedDeserializable returns the generated deserialization of the given struct if it exists, is not disabled and
+// matches the settings of the API.
+func (api *API) generatedDeserializable(value reflect.Value, opts *options) (GeneratedDeserializable, bool) {
+	if opts.withoutGeneratedCode || !value.CanAddr() {
+		return nil, false
+	}
+
+	generated, ok := value.Addr().Interface().(GeneratedDeserializable)
+
+	return generated, ok && api.generatedCodeMatches(value.Type(), generated)
+}
+
+// generatedSerializableJSON returns the generated JSON serialization of the given struct if it exists, is not
+// disabled and matches the settings of the API.
+func (api *API) generatedSerializableJSON(valueI any, opts *options) (GeneratedSerializableJSON, bool) {
+	if opts.withoutGeneratedCode {
+		return nil, false
+	}
+
+	generated, ok := valueI.(GeneratedSerializableJSON)
+
+	return generated, ok && api.generatedCodeMatches(reflect.TypeOf(valueI), valueI)
+}
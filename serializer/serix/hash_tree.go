@@ -0,0 +1,650 @@
+package serix
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math/bits"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// hashTreeChunkSize is the size of the leaves of the hash trees and of the hashes of the hash function.
+	hashTreeChunkSize = 32
+)
+
+var (
+	// ErrInvalidHashTreeProof gets returned when a hash tree proof doesn't prove its leaf for the given root.
+	ErrInvalidHashTreeProof = ierrors.New("invalid hash tree proof")
+)
+
+// HashTreeProof is a Merkle proof of the hash tree root of a value within the hash tree of an object.
+type HashTreeProof struct {
+	// Leaf is the hash tree root of the proven value.
+	Leaf []byte
+	// Index is the generalized index of the proven value within the hash tree of the object.
+	Index uint64
+	// Branch holds the sibling hashes of the path from the leaf to the root of the hash tree.
+	Branch [][]byte
+}
+
+// WithHashFunction returns an Option that sets the hash function that is used to build hash trees.
+// The hash function must produce 32 byte hashes. If the option is not provided, SHA-256 is used.
+func WithHashFunction(newHash func() hash.Hash) Option {
+	return func(o *options) {
+		o.newHash = newHash
+	}
+}
+
+// HashTreeRoot returns the hash tree root of the provided object obj.
+// The object is merkleized field by field, similar to SSZ: numbers and other basic values are encoded like in Encode
+// and padded to 32 byte chunks, byte slices and strings are packed into chunks, and the roots of the fields of structs
+// and the elements of slices, arrays and maps are the leaves of their Merkle trees. The trees are padded to the next
+// power of two, or to the maxLen of the value if it is set. The length of slices, maps, byte slices and strings is
+// mixed into their root, as well as the object code of objects and whether optional fields are set.
+// Use the options list opts to set the hash function or the protocol version of the object.
+func (api *API) HashTreeRoot(ctx context.Context, obj any, opts ...Option) ([]byte, error) {
+	value := reflect.ValueOf(obj)
+	if !value.IsValid() {
+		return nil, ierrors.New("invalid value for hash tree root")
+	}
+	opt := api.applyOptions(opts)
+
+	hasher, err := newHashTreeHasher(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := api.hashTreeNode(ctx, hasher, value, opt.ts, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return node.root, nil
+}
+
+// HashTreeProof returns the proof of the hash tree root of the value at the given path within the hash tree of the
+// provided object obj, see HashTreeRoot.
+// The path consists of the names of the struct fields and the indexes of slice and array elements or the keys of
+// map entries, e.g. "Outputs[3].Amount".
+func (api *API) HashTreeProof(ctx context.Context, obj any, path string, opts ...Option) (*HashTreeProof, error) {
+	value := reflect.ValueOf(obj)
+	if !value.IsValid() {
+		return nil, ierrors.New("invalid value for hash tree proof")
+	}
+	opt := api.applyOptions(opts)
+
+	pathElements, err := parseHashTreePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher, err := newHashTreeHasher(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := api.hashTreeNode(ctx, hasher, value, opt.ts, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := hasher.proof(node, pathElements)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to build hash tree proof of %s", path)
+	}
+
+	return proof, nil
+}
+
+// VerifyHashTreeProof verifies that the proof proves its leaf for the given hash tree root.
+// The hash function can be set via the options list opts, see WithHashFunction.
+func VerifyHashTreeProof(root []byte, proof *HashTreeProof, opts ...Option) error {
+	hasher, err := newHashTreeHasher(applyOptions(opts))
+	if err != nil {
+		return err
+	}
+
+	if proof.Index == 0 || bits.Len64(proof.Index)-1 != len(proof.Branch) {
+		return ierrors.Wrapf(ErrInvalidHashTreeProof, "generalized index %d doesn't match the branch length %d", proof.Index, len(proof.Branch))
+	}
+
+	node := proof.Leaf
+	for i, index := 0, proof.Index; index > 1; i, index = i+1, index/2 {
+		if index%2 == 1 {
+			node = hasher.hash(proof.Branch[i], node)
+		} else {
+			node = hasher.hash(node, proof.Branch[i])
+		}
+	}
+
+	if !bytes.Equal(node, root) {
+		return ierrors.Wrapf(ErrInvalidHashTreeProof, "computed root %x doesn't match %x", node, root)
+	}
+
+	return nil
+}
+
+// hashTreeNode is a value within the hash tree of an object.
+type hashTreeNode struct {
+	// root is the hash tree root of the value.
+	root []byte
+	// chunks are the leaves of the Merkle tree of the value.
+	chunks [][]byte
+	// limit is the amount of leaves the Merkle tree is padded to at least.
+	limit int
+	// mixIn is mixed into the root of the Merkle tree if hasMixIn is set (e.g. the length of a slice).
+	mixIn    uint64
+	hasMixIn bool
+	// children are the nodes of the chunks, they are nil for basic values and packed bytes.
+	children []*hashTreeNode
+	// childIndexes maps the path elements of the children to their index.
+	childIndexes map[string]int
+	// passThrough defines whether the path elements are resolved by the only child of the node.
+	passThrough bool
+	// isMapEntry defines whether the node is an entry of a map, whose path element refers to the element of the entry.
+	isMapEntry bool
+}
+
+func (api *API) hashTreeNode(ctx context.Context, hasher *hashTreeHasher, value reflect.Value, ts TypeSettings, opts *options) (*hashTreeNode, error) {
+	valueType := value.Type()
+	globalTS, _ := api.typeSettingsRegistry.GetByType(valueType)
+	ts = ts.merge(globalTS)
+
+	// nil pointers are merkleized like the zero value of their type
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		value = reflect.New(valueType.Elem())
+	}
+
+	if _, ok := value.Interface().(Serializable); ok {
+		// the serialization of the value is defined by the value itself, so its bytes are merkleized
+		encoded, err := api.encode(ctx, value, ts, opts)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "failed to encode %s", valueType)
+		}
+
+		return hasher.bytesNode(encoded, ts), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if valueType == bigIntPtrType {
+			return api.hashTreeBasicNode(ctx, hasher, value, ts, opts)
+		}
+
+		return api.hashTreeNode(ctx, hasher, value.Elem(), ts, opts)
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, ierrors.Errorf("can't build hash tree of interface %s without underlying value", valueType)
+		}
+		registry := api.getInterfaceObjects(valueType)
+		if registry == nil {
+			return nil, ierrors.Errorf("interface %s isn't registered", valueType)
+		}
+		exists, err := registry.hasObjectTypeInVersion(ctx, value.Elem().Type(), opts)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "interface: %s", valueType)
+		}
+		if !exists {
+			return nil, ierrors.Wrapf(ErrInterfaceUnderlyingTypeNotRegistered, "type: %s, interface: %s", value.Elem().Type(), valueType)
+		}
+
+		return api.hashTreeNode(ctx, hasher, value.Elem(), ts, opts)
+
+	case reflect.Struct:
+		if valueType == timeType {
+			return api.hashTreeBasicNode(ctx, hasher, value, ts, opts)
+		}
+
+		return api.hashTreeStructNode(ctx, hasher, value, ts, opts)
+
+	case reflect.Slice, reflect.Array:
+		return api.hashTreeSliceNode(ctx, hasher, value, ts, opts)
+
+	case reflect.Map:
+		return api.hashTreeMapNode(ctx, hasher, value, ts, opts)
+
+	case reflect.String:
+		return hasher.bytesNode([]byte(value.String()), ts), nil
+
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return api.hashTreeBasicNode(ctx, hasher, value, ts, opts)
+
+	default:
+		return nil, ierrors.Errorf("can't build hash tree: unsupported type %s", valueType)
+	}
+}
+
+// hashTreeBasicNode returns the node of a value whose serialized form fits into a single chunk.
+func (api *API) hashTreeBasicNode(ctx context.Context, hasher *hashTreeHasher, value reflect.Value, ts TypeSettings, opts *options) (*hashTreeNode, error) {
+	encoded, err := api.encode(ctx, value, ts, opts)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to encode %s", value.Type())
+	}
+
+	chunk := make([]byte, hashTreeChunkSize)
+	copy(chunk, encoded)
+
+	return hasher.node([][]byte{chunk}, nil, 0), nil
+}
+
+func (api *API) hashTreeStructNode(ctx context.Context, hasher *hashTreeHasher, value reflect.Value, ts TypeSettings, opts *options) (*hashTreeNode, error) {
+	var fieldNames []string
+	var fieldNodes []*hashTreeNode
+	if err := api.hashTreeStructFields(ctx, hasher, value, value.Type(), opts, &fieldNames, &fieldNodes); err != nil {
+		return nil, err
+	}
+
+	node := hasher.childrenNode(fieldNodes, 0)
+	node.childIndexes = make(map[string]int, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		node.childIndexes[fieldName] = i
+	}
+
+	// the object code distinguishes the objects of interfaces
+	if objectType := ts.ObjectType(); objectType != nil {
+		_, objectCode, err := getTypeDenotationAndCode(objectType)
+		if err != nil {
+			return nil, ierrors.WithStack(err)
+		}
+		hasher.setMixIn(node, uint64(objectCode))
+	}
+
+	return node, nil
+}
+
+func (api *API) hashTreeStructFields(
+	ctx context.Context, hasher *hashTreeHasher, value reflect.Value, valueType reflect.Type, opts *options, fieldNames *[]string, fieldNodes *[]*hashTreeNode,
+) error {
+	structFields, err := api.getStructFields(valueType)
+	if err != nil {
+		return ierrors.Wrapf(err, "can't parse struct type %s", valueType)
+	}
+
+	for _, sField := range structFields {
+		inVersion, err := opts.inProtocolVersion(ctx, sField.settings.versionRange)
+		if err != nil {
+			return ierrors.Wrapf(err, "can't hash struct field %s", sField.name)
+		}
+		if !inVersion {
+			// the field is not part of the protocol version
+			continue
+		}
+
+		fieldValue := value.Field(sField.index)
+
+		// the fields of embedded structs are part of the parent struct
+		if sField.isEmbedded && !sField.settings.inlined {
+			fieldType := sField.fType
+			if fieldType.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					fieldValue = reflect.New(fieldType.Elem())
+				}
+				fieldValue = fieldValue.Elem()
+				fieldType = fieldType.Elem()
+			}
+			if err := api.hashTreeStructFields(ctx, hasher, fieldValue, fieldType, opts, fieldNames, fieldNodes); err != nil {
+				return ierrors.Wrapf(err, "can't build hash tree of embedded struct %s", sField.name)
+			}
+
+			continue
+		}
+
+		var fieldNode *hashTreeNode
+		if sField.settings.isOptional {
+			fieldNode, err = api.hashTreeOptionalNode(ctx, hasher, fieldValue, sField.settings.ts, opts)
+		} else {
+			fieldNode, err = api.hashTreeNode(ctx, hasher, fieldValue, sField.settings.ts, opts)
+		}
+		if err != nil {
+			return ierrors.Wrapf(err, "can't build hash tree of struct field %s", sField.name)
+		}
+
+		*fieldNames = append(*fieldNames, fieldPathElement(sField.name))
+		*fieldNodes = append(*fieldNodes, fieldNode)
+	}
+
+	return nil
+}
+
+// hashTreeOptionalNode returns the node of an optional value, which mixes in whether the value is set.
+func (api *API) hashTreeOptionalNode(ctx context.Context, hasher *hashTreeHasher, value reflect.Value, ts TypeSettings, opts *options) (*hashTreeNode, error) {
+	if value.IsNil() {
+		node := hasher.node([][]byte{hasher.zeroHash(0)}, nil, 0)
+		hasher.setMixIn(node, 0)
+
+		return node, nil
+	}
+
+	valueNode, err := api.hashTreeNode(ctx, hasher, value, ts, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	node := hasher.childrenNode([]*hashTreeNode{valueNode}, 0)
+	node.passThrough = true
+	hasher.setMixIn(node, 1)
+
+	return node, nil
+}
+
+func (api *API) hashTreeSliceNode(ctx context.Context, hasher *hashTreeHasher, value reflect.Value, ts TypeSettings, opts *options) (*hashTreeNode, error) {
+	isArray := value.Kind() == reflect.Array
+	if value.Type().Elem().Kind() == reflect.Uint8 {
+		var node *hashTreeNode
+		if isArray {
+			node = hasher.node(packChunks(sliceFromArray(value).Bytes()), nil, 0)
+		} else {
+			node = hasher.bytesNode(value.Bytes(), ts)
+		}
+
+		// byte arrays with an object code are objects, e.g. addresses
+		if objectType := ts.ObjectType(); objectType != nil {
+			_, objectCode, err := getTypeDenotationAndCode(objectType)
+			if err != nil {
+				return nil, ierrors.WithStack(err)
+			}
+			hasher.setMixIn(node, uint64(objectCode))
+		}
+
+		return node, nil
+	}
+
+	elemNodes := make([]*hashTreeNode, value.Len())
+	childIndexes := make(map[string]int, value.Len())
+	for i := range value.Len() {
+		elemNode, err := api.hashTreeNode(ctx, hasher, value.Index(i), TypeSettings{}, opts)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "can't build hash tree of element %d", i)
+		}
+		elemNodes[i] = elemNode
+		childIndexes[indexPathElement(i)] = i
+	}
+
+	if isArray {
+		node := hasher.childrenNode(elemNodes, 0)
+		node.childIndexes = childIndexes
+
+		return node, nil
+	}
+
+	maxLen, _ := ts.MaxLen()
+	node := hasher.childrenNode(elemNodes, int(maxLen))
+	node.childIndexes = childIndexes
+	hasher.setMixIn(node, uint64(value.Len()))
+
+	return node, nil
+}
+
+func (api *API) hashTreeMapNode(ctx context.Context, hasher *hashTreeHasher, value reflect.Value, ts TypeSettings, opts *options) (*hashTreeNode, error) {
+	type mapEntry struct {
+		key     reflect.Value
+		keyNode *hashTreeNode
+	}
+
+	// the entries are ordered by the roots of their keys to be independent of the iteration order of the map
+	entries := make([]mapEntry, 0, value.Len())
+	iter := value.MapRange()
+	for iter.Next() {
+		keyNode, err := api.hashTreeNode(ctx, hasher, iter.Key(), TypeSettings{}, opts)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "can't build hash tree of map key %v", iter.Key().Interface())
+		}
+		entries = append(entries, mapEntry{key: iter.Key(), keyNode: keyNode})
+	}
+	slices.SortFunc(entries, func(a, b mapEntry) int {
+		return bytes.Compare(a.keyNode.root, b.keyNode.root)
+	})
+
+	entryNodes := make([]*hashTreeNode, len(entries))
+	childIndexes := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		elemNode, err := api.hashTreeNode(ctx, hasher, value.MapIndex(entry.key), TypeSettings{}, opts)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "can't build hash tree of map element with key %v", entry.key.Interface())
+		}
+
+		entryNode := hasher.childrenNode([]*hashTreeNode{entry.keyNode, elemNode}, 0)
+		entryNode.isMapEntry = true
+
+		entryNodes[i] = entryNode
+		childIndexes[indexPathElement(entry.key.Interface())] = i
+	}
+
+	maxLen, _ := ts.MaxLen()
+	node := hasher.childrenNode(entryNodes, int(maxLen))
+	node.childIndexes = childIndexes
+	hasher.setMixIn(node, uint64(len(entries)))
+
+	return node, nil
+}
+
+// hashTreeHasher builds the hash trees with a hash function.
+type hashTreeHasher struct {
+	hashFunc hash.Hash
+	// zeroHashes holds the roots of the Merkle trees of zero chunks with the depth of the index.
+	zeroHashes [][]byte
+}
+
+func newHashTreeHasher(opts *options) (*hashTreeHasher, error) {
+	newHash := opts.newHash
+	if newHash == nil {
+		newHash = sha256.New
+	}
+
+	hasher := &hashTreeHasher{hashFunc: newHash()}
+	if hasher.hashFunc.Size() != hashTreeChunkSize {
+		return nil, ierrors.Errorf("hash function must produce %d byte hashes, got %d", hashTreeChunkSize, hasher.hashFunc.Size())
+	}
+	hasher.zeroHashes = [][]byte{make([]byte, hashTreeChunkSize)}
+
+	return hasher, nil
+}
+
+func (h *hashTreeHasher) hash(left []byte, right []byte) []byte {
+	h.hashFunc.Reset()
+	h.hashFunc.Write(left)
+	h.hashFunc.Write(right)
+
+	return h.hashFunc.Sum(nil)
+}
+
+// zeroHash returns the root of the Merkle tree of zero chunks with the given depth.
+func (h *hashTreeHasher) zeroHash(depth int) []byte {
+	for len(h.zeroHashes) <= depth {
+		previous := h.zeroHashes[len(h.zeroHashes)-1]
+		h.zeroHashes = append(h.zeroHashes, h.hash(previous, previous))
+	}
+
+	return h.zeroHashes[depth]
+}
+
+// node returns the node with the given chunks and the nodes of the chunks.
+func (h *hashTreeHasher) node(chunks [][]byte, children []*hashTreeNode, limit int) *hashTreeNode {
+	root, _, _ := h.merkleize(chunks, limit, -1)
+
+	return &hashTreeNode{
+		root:     root,
+		chunks:   chunks,
+		limit:    limit,
+		children: children,
+	}
+}
+
+// childrenNode returns the node whose chunks are the roots of the given children.
+func (h *hashTreeHasher) childrenNode(children []*hashTreeNode, limit int) *hashTreeNode {
+	chunks := make([][]byte, len(children))
+	for i, child := range children {
+		chunks[i] = child.root
+	}
+
+	return h.node(chunks, children, limit)
+}
+
+// bytesNode returns the node of a byte slice or a string, which mixes in the length of the bytes.
+func (h *hashTreeHasher) bytesNode(data []byte, ts TypeSettings) *hashTreeNode {
+	maxLen, _ := ts.MaxLen()
+	node := h.node(packChunks(data), nil, int(maxLen+hashTreeChunkSize-1)/hashTreeChunkSize)
+	h.setMixIn(node, uint64(len(data)))
+
+	return node
+}
+
+// setMixIn mixes the given value into the root of the node.
+func (h *hashTreeHasher) setMixIn(node *hashTreeNode, mixIn uint64) {
+	if node.hasMixIn {
+		// the node already mixes in a value, so it is wrapped, e.g. a byte slice with an object code
+		inner := *node
+		*node = *h.childrenNode([]*hashTreeNode{&inner}, 0)
+		node.passThrough = true
+	}
+
+	node.mixIn = mixIn
+	node.hasMixIn = true
+	node.root = h.hash(node.root, mixInChunk(mixIn))
+}
+
+// merkleize returns the root of the Merkle tree of the chunks that is padded with zero chunks to the next power of
+// two of the given limit or the amount of chunks. If index is not negative, the sibling hashes of the path from the
+// chunk with the index to the root are returned as well.
+func (h *hashTreeHasher) merkleize(chunks [][]byte, limit int, index int) (root []byte, branch [][]byte, depth int) {
+	depth = bits.Len(uint(max(len(chunks), limit, 1) - 1))
+
+	layer := chunks
+	for level := range depth {
+		if index >= 0 {
+			if sibling := index ^ 1; sibling < len(layer) {
+				branch = append(branch, layer[sibling])
+			} else {
+				branch = append(branch, h.zeroHash(level))
+			}
+			index /= 2
+		}
+
+		nextLayer := make([][]byte, (len(layer)+1)/2)
+		for i := 0; i < len(layer); i += 2 {
+			right := h.zeroHash(level)
+			if i+1 < len(layer) {
+				right = layer[i+1]
+			}
+			nextLayer[i/2] = h.hash(layer[i], right)
+		}
+		layer = nextLayer
+	}
+
+	if len(layer) == 0 {
+		return h.zeroHash(depth), branch, depth
+	}
+
+	return layer[0], branch, depth
+}
+
+// proof returns the proof of the value at the given path elements within the hash tree of the given node.
+func (h *hashTreeHasher) proof(node *hashTreeNode, pathElements []string) (*HashTreeProof, error) {
+	if len(pathElements) == 0 && !node.isMapEntry {
+		return &HashTreeProof{Leaf: node.root, Index: 1}, nil
+	}
+
+	var childIndex int
+	remainingPathElements := pathElements
+	switch {
+	case node.isMapEntry:
+		// the element is the second child of the entry
+		childIndex = 1
+	case node.passThrough:
+		childIndex = 0
+	case node.childIndexes != nil:
+		index, exists := node.childIndexes[pathElements[0]]
+		if !exists {
+			return nil, ierrors.Errorf("unknown path element %s", pathElements[0])
+		}
+		childIndex = index
+		remainingPathElements = pathElements[1:]
+	default:
+		return nil, ierrors.Errorf("path element %s refers to a value without children", pathElements[0])
+	}
+
+	proof, err := h.proof(node.children[childIndex], remainingPathElements)
+	if err != nil {
+		return nil, err
+	}
+
+	_, branch, depth := h.merkleize(node.chunks, node.limit, childIndex)
+	localIndex := uint64(1)<<depth + uint64(childIndex)
+	if node.hasMixIn {
+		// the root of the Merkle tree is the left child of the root of the node
+		branch = append(branch, mixInChunk(node.mixIn))
+		localIndex = uint64(2)<<depth + uint64(childIndex)
+	}
+
+	// the generalized index of the leaf is the concatenation of the path to the child and the path within the child
+	childDepth := bits.Len64(proof.Index) - 1
+	if bits.Len64(localIndex)+childDepth > 64 {
+		return nil, ierrors.New("the hash tree is too deep to build a proof")
+	}
+	proof.Index = localIndex<<childDepth | (proof.Index - 1<<childDepth)
+	proof.Branch = append(proof.Branch, branch...)
+
+	return proof, nil
+}
+
+// packChunks packs the bytes into chunks, the last chunk is padded with zeros.
+func packChunks(data []byte) [][]byte {
+	chunks := make([][]byte, 0, (len(data)+hashTreeChunkSize-1)/hashTreeChunkSize)
+	for offset := 0; offset < len(data); offset += hashTreeChunkSize {
+		chunk := make([]byte, hashTreeChunkSize)
+		copy(chunk, data[offset:])
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+// mixInChunk returns the chunk of a value that is mixed into a root.
+func mixInChunk(value uint64) []byte {
+	chunk := make([]byte, hashTreeChunkSize)
+	binary.LittleEndian.PutUint64(chunk, value)
+
+	return chunk
+}
+
+// parseHashTreePath splits the given path into its elements, e.g. "Outputs[3].Amount" into ".Outputs", "[3]" and
+// ".Amount".
+func parseHashTreePath(path string) ([]string, error) {
+	var pathElements []string
+	for remaining := path; remaining != ""; {
+		if remaining[0] == '[' {
+			end := strings.IndexByte(remaining, ']')
+			if end < 0 {
+				return nil, ierrors.Errorf("invalid path %s: missing closing bracket", path)
+			}
+			pathElements = append(pathElements, remaining[:end+1])
+			remaining = remaining[end+1:]
+
+			continue
+		}
+
+		remaining = strings.TrimPrefix(remaining, ".")
+		end := strings.IndexAny(remaining, ".[")
+		if end < 0 {
+			end = len(remaining)
+		}
+		if end == 0 {
+			return nil, ierrors.Errorf("invalid path %s: empty field name", path)
+		}
+		pathElements = append(pathElements, fieldPathElement(remaining[:end]))
+		remaining = remaining[end:]
+	}
+
+	return pathElements, nil
+}
@@ -0,0 +1,182 @@
+package serix_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+func newHashTreeTestTransaction() *schemaTransaction {
+	return &schemaTransaction{
+		Outputs: []*schemaOutput{
+			{
+				Amount:   1,
+				Address:  &schemaEd25519Address{1, 2, 3, 4},
+				Tag:      []byte("tag"),
+				Metadata: &schemaMetadata{Name: "name"},
+				Features: map[string]uint16{"a": 1, "b": 2},
+			},
+			{
+				Amount:   500,
+				Address:  &schemaAccountAddress{AccountID: [2]byte{5, 6}},
+				Features: map[string]uint16{"c": 3},
+			},
+		},
+	}
+}
+
+func hashTreeChunk(data []byte) []byte {
+	chunk := make([]byte, 32)
+	copy(chunk, data)
+
+	return chunk
+}
+
+func hashTreeUint64Chunk(value uint64) []byte {
+	return hashTreeChunk(binary.LittleEndian.AppendUint64(nil, value))
+}
+
+func TestHashTreeRoot(t *testing.T) {
+	api := newSchemaTestAPI(t)
+	ctx := context.Background()
+
+	// basic values are padded to a single chunk
+	root, err := api.HashTreeRoot(ctx, uint64(5))
+	require.NoError(t, err)
+	require.Equal(t, hashTreeUint64Chunk(5), root)
+
+	// the length of byte slices is mixed into their root
+	root, err = api.HashTreeRoot(ctx, []byte{1, 2, 3})
+	require.NoError(t, err)
+	expected := sha256.Sum256(append(hashTreeChunk([]byte{1, 2, 3}), hashTreeUint64Chunk(3)...))
+	require.Equal(t, expected[:], root)
+
+	tx := newHashTreeTestTransaction()
+	root, err = api.HashTreeRoot(ctx, tx)
+	require.NoError(t, err)
+	require.Len(t, root, 32)
+
+	// the root is independent of the iteration order of maps
+	for range 10 {
+		otherRoot, err := api.HashTreeRoot(ctx, newHashTreeTestTransaction())
+		require.NoError(t, err)
+		require.Equal(t, root, otherRoot)
+	}
+
+	// building the hash tree doesn't modify the object
+	require.Equal(t, newHashTreeTestTransaction(), tx)
+
+	modifications := []func(tx *schemaTransaction){
+		func(tx *schemaTransaction) { tx.Outputs[1].Amount = 501 },
+		func(tx *schemaTransaction) { tx.Outputs[1].Address = &schemaEd25519Address{5, 6, 7, 8} },
+		func(tx *schemaTransaction) { tx.Outputs[0].Tag = append(tx.Outputs[0].Tag, 0) },
+		func(tx *schemaTransaction) { tx.Outputs[0].Metadata = nil },
+		func(tx *schemaTransaction) { tx.Outputs[0].Features["a"] = 2 },
+		func(tx *schemaTransaction) { tx.Outputs = tx.Outputs[:1] },
+	}
+	for i, modify := range modifications {
+		modified := newHashTreeTestTransaction()
+		modify(modified)
+
+		modifiedRoot, err := api.HashTreeRoot(ctx, modified)
+		require.NoError(t, err)
+		require.NotEqual(t, root, modifiedRoot, "modification %d", i)
+	}
+
+	// the hash function can be replaced
+	otherRoot, err := api.HashTreeRoot(ctx, tx, serix.WithHashFunction(sha512.New512_256))
+	require.NoError(t, err)
+	require.NotEqual(t, root, otherRoot)
+
+	_, err = api.HashTreeRoot(ctx, tx, serix.WithHashFunction(sha512.New))
+	require.Error(t, err)
+}
+
+func TestHashTreeRootProtocolVersions(t *testing.T) {
+	api := newVersionsTestAPI(t)
+	ctx := context.Background()
+
+	output := &versionedOutput{Amount: 1, Mana: 5, Legacy: 7, Feature: &versionedTagFeature{Tag: 3}}
+	rootV1, err := api.HashTreeRoot(ctx, output, serix.WithProtocolVersion(1))
+	require.NoError(t, err)
+
+	// fields that are not part of the protocol version are not part of the hash tree
+	output.Mana = 6
+	otherRootV1, err := api.HashTreeRoot(ctx, output, serix.WithProtocolVersion(1))
+	require.NoError(t, err)
+	require.Equal(t, rootV1, otherRootV1)
+
+	_, err = api.HashTreeRoot(ctx, output, serix.WithProtocolVersion(2))
+	require.ErrorIs(t, err, serix.ErrInterfaceUnderlyingTypeNotRegistered)
+}
+
+func TestHashTreeProof(t *testing.T) {
+	api := newSchemaTestAPI(t)
+	ctx := context.Background()
+
+	tx := newHashTreeTestTransaction()
+	root, err := api.HashTreeRoot(ctx, tx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		leaf []byte
+	}{
+		{path: "", leaf: root},
+		{path: "Outputs[1].Amount", leaf: hashTreeUint64Chunk(500)},
+		{path: "Outputs[0].Amount", leaf: hashTreeUint64Chunk(1)},
+		{path: "Outputs[0].Features[b]", leaf: hashTreeChunk([]byte{2, 0})},
+		{path: "Outputs[0].Metadata.Name"},
+		{path: "Outputs[0].Metadata"},
+		{path: "Outputs[1].Address"},
+		{path: "Outputs"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			proof, err := api.HashTreeProof(ctx, tx, test.path)
+			require.NoError(t, err)
+			if test.leaf != nil {
+				require.Equal(t, test.leaf, proof.Leaf)
+			}
+			require.NoError(t, serix.VerifyHashTreeProof(root, proof))
+
+			if len(proof.Branch) == 0 {
+				return
+			}
+
+			// the proof is bound to the hash function
+			require.ErrorIs(t, serix.VerifyHashTreeProof(root, proof, serix.WithHashFunction(sha512.New512_256)), serix.ErrInvalidHashTreeProof)
+
+			tamperedLeaf := *proof
+			tamperedLeaf.Leaf = hashTreeUint64Chunk(1000)
+			require.ErrorIs(t, serix.VerifyHashTreeProof(root, &tamperedLeaf), serix.ErrInvalidHashTreeProof)
+
+			tamperedIndex := *proof
+			tamperedIndex.Index ^= 1
+			require.ErrorIs(t, serix.VerifyHashTreeProof(root, &tamperedIndex), serix.ErrInvalidHashTreeProof)
+
+			tamperedBranch := *proof
+			tamperedBranch.Branch = proof.Branch[1:]
+			require.ErrorIs(t, serix.VerifyHashTreeProof(root, &tamperedBranch), serix.ErrInvalidHashTreeProof)
+		})
+	}
+
+	// the amounts of different outputs are proven with different generalized indexes
+	proof0, err := api.HashTreeProof(ctx, tx, "Outputs[0].Amount")
+	require.NoError(t, err)
+	proof1, err := api.HashTreeProof(ctx, tx, "Outputs[1].Amount")
+	require.NoError(t, err)
+	require.NotEqual(t, proof0.Index, proof1.Index)
+
+	for _, path := range []string{"Outputs[2].Amount", "Outputs[0].Unknown", "Outputs[0].Amount.Value", "Outputs[1].Metadata.Name", "Outputs[0", "Outputs..Amount"} {
+		_, err := api.HashTreeProof(ctx, tx, path)
+		require.Error(t, err, path)
+	}
+}
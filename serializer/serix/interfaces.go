@@ -1,7 +1,9 @@
 package serix
 
 import (
+	"context"
 	"reflect"
+	"slices"
 	"sync"
 
 	hiveorderedmap "github.com/iotaledger/hive.go/ds/orderedmap"
@@ -19,6 +21,8 @@ type InterfaceObjects struct {
 	typeDenotation serializer.TypeDenotationType
 	fromCodeToType *hiveorderedmap.OrderedMap[uint32, reflect.Type]
 	fromTypeToCode *hiveorderedmap.OrderedMap[reflect.Type, uint32]
+	// versionRanges holds the protocol versions of the objects that were registered for a version range.
+	versionRanges *hiveorderedmap.OrderedMap[reflect.Type, VersionRange]
 }
 
 func NewInterfaceObjects(typeDenotation serializer.TypeDenotationType) *InterfaceObjects {
@@ -26,6 +30,7 @@ func NewInterfaceObjects(typeDenotation serializer.TypeDenotationType) *Interfac
 		typeDenotation: typeDenotation,
 		fromCodeToType: hiveorderedmap.New[uint32, reflect.Type](),
 		fromTypeToCode: hiveorderedmap.New[reflect.Type, uint32](),
+		versionRanges:  hiveorderedmap.New[reflect.Type, VersionRange](),
 	}
 }
 
@@ -38,6 +43,22 @@ func (i *InterfaceObjects) AddObject(objCode uint32, objType reflect.Type) {
 	i.fromTypeToCode.Set(objType, objCode)
 }
 
+// AddObjectWithVersionRange adds an object that is only part of the given range of protocol versions.
+// Objects of different version ranges can share the same object code. Without a protocol version, the object code
+// refers to the object that was added first.
+func (i *InterfaceObjects) AddObjectWithVersionRange(objCode uint32, objType reflect.Type, versionRange VersionRange) {
+	if !i.fromCodeToType.Has(objCode) {
+		i.fromCodeToType.Set(objCode, objType)
+	}
+	i.fromTypeToCode.Set(objType, objCode)
+	i.versionRanges.Set(objType, versionRange)
+}
+
+// VersionRange returns the range of protocol versions the object type was added for.
+func (i *InterfaceObjects) VersionRange(objType reflect.Type) (VersionRange, bool) {
+	return i.versionRanges.Get(objType)
+}
+
 func (i *InterfaceObjects) HasObjectType(objType reflect.Type) bool {
 	_, exists := i.fromTypeToCode.Get(objType)
 
@@ -68,6 +89,85 @@ func (i *InterfaceObjects) ForEachObjectType(f func(objType reflect.Type, objCod
 	})
 }
 
+// inProtocolVersion returns whether the given object type is part of the protocol version of the options, context or
+// API.
+func (i *InterfaceObjects) inProtocolVersion(ctx context.Context, objType reflect.Type, opts *options) (bool, error) {
+	versionRange, exists := i.versionRanges.Get(objType)
+	if !exists {
+		return true, nil
+	}
+
+	inVersion, err := opts.inProtocolVersion(ctx, versionRange)
+	if err != nil {
+		return false, ierrors.Wrapf(err, "object type %s", objType)
+	}
+
+	return inVersion, nil
+}
+
+// hasObjectTypeInVersion returns whether the given object type is registered for the protocol version of the options,
+// context or API.
+func (i *InterfaceObjects) hasObjectTypeInVersion(ctx context.Context, objType reflect.Type, opts *options) (bool, error) {
+	if !i.HasObjectType(objType) {
+		return false, nil
+	}
+
+	return i.inProtocolVersion(ctx, objType, opts)
+}
+
+// objectTypeByCodeInVersion returns the object type that is registered with the given object code for the protocol
+// version of the options, context or API.
+func (i *InterfaceObjects) objectTypeByCodeInVersion(ctx context.Context, objCode uint32, opts *options) (reflect.Type, bool, error) {
+	objType, exists := i.GetObjectTypeByCode(objCode)
+	if !exists || i.versionRanges.IsEmpty() {
+		return objType, exists, nil
+	}
+
+	inVersion, err := i.inProtocolVersion(ctx, objType, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	if inVersion {
+		return objType, true, nil
+	}
+
+	// the object code might be shared with an object of another version range
+	var versionedObjType reflect.Type
+	i.ForEachObjectCode(func(code uint32, candidate reflect.Type) bool {
+		if code != objCode {
+			return true
+		}
+
+		if inVersion, err = i.inProtocolVersion(ctx, candidate, opts); err == nil && inVersion {
+			versionedObjType = candidate
+		}
+
+		return err == nil && !inVersion
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return versionedObjType, versionedObjType != nil, nil
+}
+
+// objectCodesInVersion returns the sorted object codes of the object types that are registered for the protocol
+// version of the options, context or API.
+func (i *InterfaceObjects) objectCodesInVersion(ctx context.Context, opts *options) []uint32 {
+	objectCodes := make([]uint32, 0, i.fromTypeToCode.Size())
+	i.ForEachObjectCode(func(objCode uint32, objType reflect.Type) bool {
+		// without a protocol version, all object codes are listed
+		if inVersion, err := i.inProtocolVersion(ctx, objType, opts); (err != nil || inVersion) && !slices.Contains(objectCodes, objCode) {
+			objectCodes = append(objectCodes, objCode)
+		}
+
+		return true
+	})
+	slices.Sort(objectCodes)
+
+	return objectCodes
+}
+
 type InterfacesRegistry struct {
 	// the registered interfaces and their known objects
 	registryMutex sync.RWMutex
@@ -103,6 +203,20 @@ func (r *InterfacesRegistry) ForEach(consumer func(objType reflect.Type, interfa
 }
 
 func (r *InterfacesRegistry) RegisterInterfaceObjects(typeSettingsRegistry *TypeSettingsRegistry, iType interface{}, objs ...interface{}) error {
+	return r.registerInterfaceObjects(typeSettingsRegistry, iType, nil, objs...)
+}
+
+// RegisterInterfaceObjectsWithVersionRange registers the objects for the interface, but only for the given range of
+// protocol versions.
+func (r *InterfacesRegistry) RegisterInterfaceObjectsWithVersionRange(typeSettingsRegistry *TypeSettingsRegistry, iType interface{}, versionRange VersionRange, objs ...interface{}) error {
+	if versionRange.Until != 0 && versionRange.Until < versionRange.Since {
+		return ierrors.Errorf("invalid version range: until %d is less than since %d", versionRange.Until, versionRange.Since)
+	}
+
+	return r.registerInterfaceObjects(typeSettingsRegistry, iType, &versionRange, objs...)
+}
+
+func (r *InterfacesRegistry) registerInterfaceObjects(typeSettingsRegistry *TypeSettingsRegistry, iType interface{}, versionRange *VersionRange, objs ...interface{}) error {
 	ptrType := reflect.TypeOf(iType)
 	if ptrType == nil {
 		return ierrors.New("'iType' is a nil interface, it needs to be a pointer to an interface")
@@ -151,7 +265,11 @@ func (r *InterfacesRegistry) RegisterInterfaceObjects(typeSettingsRegistry *Type
 			)
 		}
 
-		iRegistry.AddObject(objMeta.Code, objMeta.Type)
+		if versionRange != nil {
+			iRegistry.AddObjectWithVersionRange(objMeta.Code, objMeta.Type, *versionRange)
+		} else {
+			iRegistry.AddObject(objMeta.Code, objMeta.Type)
+		}
 	}
 
 	if !exists {
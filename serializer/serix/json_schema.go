@@ -0,0 +1,603 @@
+package serix
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// jsonSchemaDialect is the JSON Schema dialect of the exported schemas.
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	// jsonSchemaRefPrefix is the prefix of the references to the definitions of a JSON Schema document.
+	jsonSchemaRefPrefix = "#/$defs/"
+	// openAPIRefPrefix is the prefix of the references to the schemas of the OpenAPI components.
+	openAPIRefPrefix = "#/components/schemas/"
+
+	// the pattern of hex encoded byte slices.
+	patternHex = "^(0x[0-9a-fA-F]*)?$"
+	// the pattern of hex encoded uint256 numbers.
+	patternUint256 = "^0x[0-9a-fA-F]+$"
+	// the pattern of base 10 encoded signed numbers.
+	patternInt = "^-?[0-9]+$"
+	// the pattern of base 10 encoded unsigned numbers.
+	patternUint = "^[0-9]+$"
+)
+
+var (
+	serializableJSONType = reflect.TypeOf((*SerializableJSON)(nil)).Elem()
+)
+
+// ExportJSONSchema returns a JSON Schema (draft 2020-12) document that describes the JSON representation of all
+// registered types and interfaces.
+// The schemas are contained in the "$defs" of the document and are named after their Go types.
+// Registered interfaces are described as a "oneOf" of their registered objects, which are distinguished by the
+// object code in their "type" field.
+// The schemas cover all protocol versions: struct fields that are only part of some protocol versions are not
+// required, and interfaces list their objects of all protocol versions.
+func (api *API) ExportJSONSchema() ([]byte, error) {
+	definitions, err := newSchemaExporter(api, jsonSchemaRefPrefix, false).export()
+	if err != nil {
+		return nil, err
+	}
+
+	document := orderedmap.New()
+	document.Set("$schema", jsonSchemaDialect)
+	document.Set("$defs", definitions)
+
+	return json.Marshal(document)
+}
+
+// ExportOpenAPIComponents returns the "components" section of an OpenAPI 3.1 document that describes the JSON
+// representation of all registered types and interfaces.
+// In addition to the schemas returned by ExportJSONSchema, registered interfaces contain a discriminator that maps
+// the object codes to the schemas of the registered objects.
+func (api *API) ExportOpenAPIComponents() ([]byte, error) {
+	schemas, err := newSchemaExporter(api, openAPIRefPrefix, true).export()
+	if err != nil {
+		return nil, err
+	}
+
+	components := orderedmap.New()
+	components.Set("schemas", schemas)
+
+	document := orderedmap.New()
+	document.Set("components", components)
+
+	return json.Marshal(document)
+}
+
+// schemaExporter builds the schemas of the JSON representation of the types known to an API.
+type schemaExporter struct {
+	api *API
+	// refPrefix is the prefix of the references to the named schemas.
+	refPrefix string
+	// discriminators defines whether OpenAPI discriminators are added to the schemas of interfaces.
+	discriminators bool
+	// definitions holds the named schemas.
+	definitions *orderedmap.OrderedMap
+	// names holds the names of the types that have a named schema.
+	names map[reflect.Type]string
+	// usedNames holds the types of the used names.
+	usedNames map[string]reflect.Type
+}
+
+func newSchemaExporter(api *API, refPrefix string, discriminators bool) *schemaExporter {
+	return &schemaExporter{
+		api:            api,
+		refPrefix:      refPrefix,
+		discriminators: discriminators,
+		definitions:    orderedmap.New(),
+		names:          make(map[reflect.Type]string),
+		usedNames:      make(map[string]reflect.Type),
+	}
+}
+
+// export builds the named schemas of all registered types and interfaces.
+func (e *schemaExporter) export() (*orderedmap.OrderedMap, error) {
+	var err error
+	e.api.ForEachRegisteredTypeSetting(func(objType reflect.Type, ts TypeSettings) bool {
+		_, err = e.namedSchema(objType)
+
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	e.api.ForEachRegisteredInterfaceObjects(func(iType reflect.Type, _ *InterfaceObjects) bool {
+		_, err = e.namedSchema(iType)
+
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return e.definitions, nil
+}
+
+// hasNamedSchema returns whether the given type is described by a named schema instead of an inlined one.
+func (e *schemaExporter) hasNamedSchema(objType reflect.Type) bool {
+	if objType.Name() == "" || objType == timeType || objType == bigIntPtrType.Elem() {
+		return false
+	}
+
+	switch objType.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Slice, reflect.Array:
+		// byte slices and arrays with an object code are encoded as objects
+		ts, _ := e.api.typeSettingsRegistry.GetByType(objType)
+
+		return ts.ObjectType() != nil && objType.Elem().Kind() == reflect.Uint8
+	default:
+		return false
+	}
+}
+
+// namedSchema returns a reference to the named schema of the given type and builds the schema if necessary.
+// Types without a named schema are described by the schema that is based on their registered type settings.
+func (e *schemaExporter) namedSchema(objType reflect.Type) (*orderedmap.OrderedMap, error) {
+	objType = DeRefPointer(objType)
+	if !e.hasNamedSchema(objType) {
+		return e.schema(objType, TypeSettings{})
+	}
+
+	if name, exists := e.names[objType]; exists {
+		return e.ref(name), nil
+	}
+
+	// the name is reserved before the schema is built to support recursive types
+	name := e.reserveName(objType)
+	e.definitions.Set(name, orderedmap.New())
+
+	ts, _ := e.api.typeSettingsRegistry.GetByType(objType)
+
+	var schema *orderedmap.OrderedMap
+	var err error
+	if objType.Kind() == reflect.Interface {
+		schema, err = e.interfaceSchema(objType)
+	} else {
+		schema, err = e.schemaBasedOnType(objType, ts)
+	}
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to build schema for type %s", objType)
+	}
+	setDescription(schema, ts)
+	e.definitions.Set(name, schema)
+
+	return e.ref(name), nil
+}
+
+// schema returns the schema of the given type with the given type settings merged with the registered ones.
+func (e *schemaExporter) schema(objType reflect.Type, ts TypeSettings) (*orderedmap.OrderedMap, error) {
+	globalTS, _ := e.api.typeSettingsRegistry.GetByType(objType)
+
+	if e.hasNamedSchema(DeRefPointer(objType)) {
+		schema, err := e.namedSchema(objType)
+		if err != nil {
+			return nil, err
+		}
+		setDescription(schema, ts)
+
+		return schema, nil
+	}
+
+	mergedTS := ts.merge(globalTS)
+	schema, err := e.schemaBasedOnType(objType, mergedTS)
+	if err != nil {
+		return nil, err
+	}
+	setValueRules(schema, objType, mergedTS)
+
+	// the description of the field takes precedence over the description of the type
+	if ts.Description() == "" {
+		ts = ts.WithDescription(globalTS.Description())
+	}
+	setDescription(schema, ts)
+
+	return schema, nil
+}
+
+func (e *schemaExporter) schemaBasedOnType(objType reflect.Type, ts TypeSettings) (*orderedmap.OrderedMap, error) {
+	if objType.Implements(serializableJSONType) || reflect.PointerTo(objType).Implements(serializableJSONType) {
+		// the JSON representation is defined by the type itself
+		return orderedmap.New(), nil
+	}
+
+	switch objType {
+	case bigIntPtrType:
+		return stringSchema(patternUint256), nil
+	case timeType:
+		return stringSchema(patternUint), nil
+	}
+
+	switch objType.Kind() {
+	case reflect.Ptr:
+		return e.schema(objType.Elem(), ts)
+	case reflect.Struct:
+		return e.structSchema(objType, ts)
+	case reflect.Slice:
+		return e.sliceSchema(objType, ts, -1)
+	case reflect.Array:
+		return e.sliceSchema(objType, ts, objType.Len())
+	case reflect.Map:
+		return e.mapSchema(objType, ts)
+	case reflect.Interface:
+		return e.namedSchema(objType)
+	case reflect.String:
+		schema := stringSchema("")
+		setLengthBounds(schema, "minLength", "maxLength", ts, 1, 0)
+
+		return schema, nil
+	case reflect.Bool:
+		return typeSchema("boolean"), nil
+	case reflect.Int8:
+		return integerSchema(math.MinInt8, math.MaxInt8), nil
+	case reflect.Int16:
+		return integerSchema(math.MinInt16, math.MaxInt16), nil
+	case reflect.Int32:
+		return integerSchema(math.MinInt32, math.MaxInt32), nil
+	case reflect.Uint8:
+		return integerSchema(0, math.MaxUint8), nil
+	case reflect.Uint16:
+		return integerSchema(0, math.MaxUint16), nil
+	case reflect.Uint32:
+		return integerSchema(0, math.MaxUint32), nil
+	case reflect.Int64:
+		return stringSchema(patternInt), nil
+	case reflect.Uint64:
+		return stringSchema(patternUint), nil
+	case reflect.Float32, reflect.Float64:
+		return stringSchema(""), nil
+	default:
+		return nil, ierrors.Errorf("can't build schema: unsupported type %s", objType)
+	}
+}
+
+func (e *schemaExporter) interfaceSchema(iType reflect.Type) (*orderedmap.OrderedMap, error) {
+	iObjects := e.api.getInterfaceObjects(iType)
+	if iObjects == nil {
+		return nil, ierrors.Errorf("interface %s isn't registered", iType)
+	}
+
+	oneOf := make([]any, 0)
+	mapping := orderedmap.New()
+	var err error
+	iObjects.ForEachObjectType(func(objType reflect.Type, objCode uint32) bool {
+		var ref *orderedmap.OrderedMap
+		if ref, err = e.namedSchema(objType); err != nil {
+			return false
+		}
+
+		oneOf = append(oneOf, ref)
+		if refValue, isRef := ref.Get("$ref"); isRef {
+			mapping.Set(strconv.FormatUint(uint64(objCode), 10), refValue)
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schema := orderedmap.New()
+	schema.Set("oneOf", oneOf)
+	if e.discriminators {
+		discriminator := orderedmap.New()
+		discriminator.Set("propertyName", keyType)
+		discriminator.Set("mapping", mapping)
+		schema.Set("discriminator", discriminator)
+	}
+
+	return schema, nil
+}
+
+func (e *schemaExporter) structSchema(structType reflect.Type, ts TypeSettings) (*orderedmap.OrderedMap, error) {
+	properties := orderedmap.New()
+	required := make([]any, 0)
+	allOf := make([]any, 0)
+
+	if ts.ObjectType() != nil {
+		typeProperty, err := objectTypeSchema(ts)
+		if err != nil {
+			return nil, err
+		}
+		properties.Set(keyType, typeProperty)
+		required = append(required, keyType)
+	}
+
+	if err := e.structFieldsSchema(structType, properties, &required, &allOf); err != nil {
+		return nil, err
+	}
+
+	schema := typeSchema("object")
+	schema.Set("properties", properties)
+	if len(required) > 0 {
+		schema.Set("required", required)
+	}
+	if len(allOf) > 0 {
+		schema.Set("allOf", allOf)
+	}
+
+	return schema, nil
+}
+
+func (e *schemaExporter) structFieldsSchema(structType reflect.Type, properties *orderedmap.OrderedMap, required *[]any, allOf *[]any) error {
+	structFields, err := e.api.getStructFields(structType)
+	if err != nil {
+		return ierrors.Wrapf(err, "can't parse struct type %s", structType)
+	}
+
+	for _, sField := range structFields {
+		fieldType := DeRefPointer(sField.fType)
+
+		// the fields of embedded and inlined structs are added to the parent object
+		if (sField.isEmbedded && !sField.settings.inlined) || (sField.settings.inlined && fieldType.Kind() == reflect.Struct) {
+			if err := e.structFieldsSchema(fieldType, properties, required, allOf); err != nil {
+				return ierrors.Wrapf(err, "can't build schema of embedded struct %s", sField.name)
+			}
+
+			continue
+		}
+
+		fieldSchema, err := e.schema(sField.fType, sField.settings.ts)
+		if err != nil {
+			return ierrors.Wrapf(err, "can't build schema of struct field %s", sField.name)
+		}
+
+		if sField.settings.inlined {
+			*allOf = append(*allOf, fieldSchema)
+
+			continue
+		}
+
+		fieldKey, set := sField.settings.ts.FieldKey()
+		if !set {
+			fieldKey = FieldKeyString(sField.name)
+		}
+
+		properties.Set(fieldKey, fieldSchema)
+		// the schema describes all protocol versions, so fields that are only part of some of them are not required
+		if !sField.settings.omitEmpty && !sField.settings.isOptional && sField.settings.versionRange.isUnbounded() {
+			*required = append(*required, fieldKey)
+		}
+	}
+
+	return nil
+}
+
+// sliceSchema returns the schema of a slice or an array with the given length (-1 for slices).
+func (e *schemaExporter) sliceSchema(sliceType reflect.Type, ts TypeSettings, length int) (*orderedmap.OrderedMap, error) {
+	if sliceType.Elem().Kind() == reflect.Uint8 {
+		// bytes are encoded as hex strings with two characters per byte and the 0x prefix
+		hexSchema := stringSchema(patternHex)
+		if length >= 0 {
+			ts = ts.WithMinLen(uint(length)).WithMaxLen(uint(length))
+		}
+		setLengthBounds(hexSchema, "minLength", "maxLength", ts, 2, 2)
+
+		if ts.ObjectType() == nil {
+			return hexSchema, nil
+		}
+
+		typeProperty, err := objectTypeSchema(ts)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldKey, set := ts.FieldKey()
+		if !set {
+			fieldKey = keyDefaultSliceArray
+		}
+
+		properties := orderedmap.New()
+		properties.Set(keyType, typeProperty)
+		properties.Set(fieldKey, hexSchema)
+
+		schema := typeSchema("object")
+		schema.Set("properties", properties)
+		schema.Set("required", []any{keyType, fieldKey})
+
+		return schema, nil
+	}
+
+	itemsSchema, err := e.schema(sliceType.Elem(), TypeSettings{})
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "can't build schema of the elements of %s", sliceType)
+	}
+
+	if length >= 0 {
+		ts = ts.WithMinLen(uint(length)).WithMaxLen(uint(length))
+	}
+
+	schema := typeSchema("array")
+	schema.Set("items", itemsSchema)
+	setLengthBounds(schema, "minItems", "maxItems", ts, 1, 0)
+
+	return schema, nil
+}
+
+func (e *schemaExporter) mapSchema(mapType reflect.Type, ts TypeSettings) (*orderedmap.OrderedMap, error) {
+	valueSchema, err := e.schema(mapType.Elem(), TypeSettings{})
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "can't build schema of the elements of %s", mapType)
+	}
+
+	schema := typeSchema("object")
+	schema.Set("additionalProperties", valueSchema)
+	setLengthBounds(schema, "minProperties", "maxProperties", ts, 1, 0)
+
+	return schema, nil
+}
+
+// reserveName returns a unique name for the schema of the given type.
+func (e *schemaExporter) reserveName(objType reflect.Type) string {
+	name := schemaName(objType.Name())
+	if _, taken := e.usedNames[name]; taken {
+		pkgPath := strings.Split(objType.PkgPath(), "/")
+		name = schemaName(pkgPath[len(pkgPath)-1] + "_" + objType.Name())
+	}
+
+	for uniqueName, i := name, 2; ; i++ {
+		if _, taken := e.usedNames[uniqueName]; !taken {
+			e.names[objType] = uniqueName
+			e.usedNames[uniqueName] = objType
+
+			return uniqueName
+		}
+		uniqueName = name + strconv.Itoa(i)
+	}
+}
+
+func (e *schemaExporter) ref(name string) *orderedmap.OrderedMap {
+	schema := orderedmap.New()
+	schema.Set("$ref", e.refPrefix+name)
+
+	return schema
+}
+
+// schemaName replaces the characters of the given type name that are not allowed in schema names (e.g. the brackets
+// of generic types).
+func schemaName(typeName string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '_' || r == '-' || ('0' <= r && r <= '9') || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') {
+			return r
+		}
+
+		return '_'
+	}, typeName)
+}
+
+func typeSchema(jsonType string) *orderedmap.OrderedMap {
+	schema := orderedmap.New()
+	schema.Set("type", jsonType)
+
+	return schema
+}
+
+func stringSchema(pattern string) *orderedmap.OrderedMap {
+	schema := typeSchema("string")
+	if pattern != "" {
+		schema.Set("pattern", pattern)
+	}
+
+	return schema
+}
+
+func integerSchema(minimum int64, maximum uint64) *orderedmap.OrderedMap {
+	schema := typeSchema("integer")
+	schema.Set("minimum", minimum)
+	schema.Set("maximum", maximum)
+
+	return schema
+}
+
+func objectTypeSchema(ts TypeSettings) (*orderedmap.OrderedMap, error) {
+	_, objectCode, err := getTypeDenotationAndCode(ts.ObjectType())
+	if err != nil {
+		return nil, ierrors.WithStack(err)
+	}
+
+	schema := typeSchema("integer")
+	schema.Set("const", objectCode)
+
+	return schema, nil
+}
+
+// setLengthBounds sets the min/max length of the type settings as the given schema keywords.
+// The lengths are converted to the length of the JSON representation with the given factor and offset.
+func setLengthBounds(schema *orderedmap.OrderedMap, minKeyword string, maxKeyword string, ts TypeSettings, factor uint, offset uint) {
+	if minLen, set := ts.MinLen(); set {
+		schema.Set(minKeyword, minLen*factor+offset)
+	}
+	if maxLen, set := ts.MaxLen(); set {
+		schema.Set(maxKeyword, maxLen*factor+offset)
+	}
+}
+
+// setValueRules sets the value rules of the type settings as the keywords of the given schema.
+// The bounds of numbers that are encoded as strings can't be described by the schema and are omitted.
+func setValueRules(schema *orderedmap.OrderedMap, objType reflect.Type, ts TypeSettings) {
+	rules := ts.ValueRules()
+	if rules == nil {
+		return
+	}
+
+	jsonType, _ := schema.Get("type")
+	if jsonType == "integer" {
+		if rules.Min != nil {
+			schema.Set("minimum", json.Number(rules.Min.Text('f', -1)))
+		}
+		if rules.Max != nil {
+			schema.Set("maximum", json.Number(rules.Max.Text('f', -1)))
+		}
+	}
+
+	// uint256 numbers are encoded as hex strings, so their base 10 enum values are omitted
+	if len(rules.Enum) > 0 && objType != bigIntPtrType {
+		enum := make([]any, 0, len(rules.Enum))
+		for _, value := range rules.Enum {
+			if jsonType == "integer" {
+				enum = append(enum, json.Number(value))
+			} else {
+				enum = append(enum, value)
+			}
+		}
+		schema.Set("enum", enum)
+	}
+
+	if rules.Pattern != nil && jsonType == "string" {
+		schema.Set("pattern", rules.Pattern.String())
+	}
+
+	if rules.NonZero {
+		setNonZero(schema, objType, jsonType)
+	}
+}
+
+// setNonZero adds the keywords to the given schema that exclude the zero value of the given type.
+func setNonZero(schema *orderedmap.OrderedMap, objType reflect.Type, jsonType any) {
+	if objType != bigIntPtrType {
+		objType = DeRefPointer(objType)
+	}
+
+	notZero := orderedmap.New()
+	switch {
+	case jsonType == "integer":
+		notZero.Set("const", 0)
+		schema.Set("not", notZero)
+	case objType == bigIntPtrType:
+		notZero.Set("const", "0x0")
+		schema.Set("not", notZero)
+	case objType.Kind() == reflect.String:
+		if minLength, has := schema.Get("minLength"); !has || minLength == uint(0) {
+			schema.Set("minLength", 1)
+		}
+	case jsonType == "string" && objType != timeType && objType.Kind() != reflect.Slice && objType.Kind() != reflect.Array:
+		// numbers that are encoded as strings
+		notZero.Set("const", "0")
+		schema.Set("not", notZero)
+	case jsonType == "array":
+		if _, has := schema.Get("minItems"); !has {
+			schema.Set("minItems", 1)
+		}
+	case jsonType == "object" && objType.Kind() == reflect.Map:
+		if _, has := schema.Get("minProperties"); !has {
+			schema.Set("minProperties", 1)
+		}
+	}
+}
+
+func setDescription(schema *orderedmap.OrderedMap, ts TypeSettings) {
+	if description := ts.Description(); description != "" {
+		schema.Set("description", description)
+	}
+}
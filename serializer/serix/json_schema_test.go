@@ -0,0 +1,156 @@
+package serix_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+type schemaAddress interface {
+	Key() string
+}
+
+type schemaEd25519Address [4]byte
+
+func (a schemaEd25519Address) Key() string { return string(a[:]) }
+
+type schemaAccountAddress struct {
+	AccountID [2]byte `serix:""`
+}
+
+func (a *schemaAccountAddress) Key() string { return string(a.AccountID[:]) }
+
+type schemaOutput struct {
+	Amount   uint64            `serix:""`
+	Address  schemaAddress     `serix:",description=the owner of the output"`
+	Tag      []byte            `serix:",lenPrefix=uint8,maxLen=8,omitempty"`
+	Metadata *schemaMetadata   `serix:",optional"`
+	Features map[string]uint16 `serix:",lenPrefix=uint8,minLen=1"`
+}
+
+type schemaMetadata struct {
+	Name string `serix:"metadataName,lenPrefix=uint8,minLen=1,maxLen=32"`
+}
+
+type schemaTransaction struct {
+	Outputs []*schemaOutput `serix:",lenPrefix=uint16,minLen=1,maxLen=128"`
+}
+
+func newSchemaTestAPI(t *testing.T) *serix.API {
+	api := serix.NewAPI()
+	require.NoError(t, api.RegisterTypeSettings(schemaEd25519Address{}, serix.TypeSettings{}.WithObjectType(uint8(0)).WithFieldKey("pubKeyHash")))
+	require.NoError(t, api.RegisterTypeSettings(schemaAccountAddress{}, serix.TypeSettings{}.WithObjectType(uint8(8)).WithDescription("an account address")))
+	require.NoError(t, api.RegisterInterfaceObjects((*schemaAddress)(nil), (*schemaEd25519Address)(nil), (*schemaAccountAddress)(nil)))
+	require.NoError(t, api.RegisterTypeSettings(schemaTransaction{}, serix.TypeSettings{}.WithObjectType(uint8(1))))
+
+	return api
+}
+
+func TestExportJSONSchema(t *testing.T) {
+	schemaBytes, err := newSchemaTestAPI(t).ExportJSONSchema()
+	require.NoError(t, err)
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs": {
+			"schemaEd25519Address": {
+				"type": "object",
+				"properties": {
+					"type": {"type": "integer", "const": 0},
+					"pubKeyHash": {"type": "string", "pattern": "^(0x[0-9a-fA-F]*)?$", "minLength": 10, "maxLength": 10}
+				},
+				"required": ["type", "pubKeyHash"]
+			},
+			"schemaAccountAddress": {
+				"type": "object",
+				"properties": {
+					"type": {"type": "integer", "const": 8},
+					"accountId": {"type": "string", "pattern": "^(0x[0-9a-fA-F]*)?$", "minLength": 6, "maxLength": 6}
+				},
+				"required": ["type", "accountId"],
+				"description": "an account address"
+			},
+			"schemaTransaction": {
+				"type": "object",
+				"properties": {
+					"type": {"type": "integer", "const": 1},
+					"outputs": {"type": "array", "items": {"$ref": "#/$defs/schemaOutput"}, "minItems": 1, "maxItems": 128}
+				},
+				"required": ["type", "outputs"]
+			},
+			"schemaOutput": {
+				"type": "object",
+				"properties": {
+					"amount": {"type": "string", "pattern": "^[0-9]+$"},
+					"address": {"$ref": "#/$defs/schemaAddress", "description": "the owner of the output"},
+					"tag": {"type": "string", "pattern": "^(0x[0-9a-fA-F]*)?$", "maxLength": 18},
+					"metadata": {"$ref": "#/$defs/schemaMetadata"},
+					"features": {"type": "object", "additionalProperties": {"type": "integer", "minimum": 0, "maximum": 65535}, "minProperties": 1}
+				},
+				"required": ["amount", "address", "features"]
+			},
+			"schemaAddress": {
+				"oneOf": [{"$ref": "#/$defs/schemaEd25519Address"}, {"$ref": "#/$defs/schemaAccountAddress"}]
+			},
+			"schemaMetadata": {
+				"type": "object",
+				"properties": {
+					"metadataName": {"type": "string", "minLength": 1, "maxLength": 32}
+				},
+				"required": ["metadataName"]
+			}
+		}
+	}`
+	require.JSONEq(t, expected, string(schemaBytes))
+}
+
+func TestExportOpenAPIComponents(t *testing.T) {
+	componentsBytes, err := newSchemaTestAPI(t).ExportOpenAPIComponents()
+	require.NoError(t, err)
+
+	var components struct {
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(componentsBytes, &components))
+	require.Len(t, components.Components.Schemas, 6)
+
+	expected := `{
+		"oneOf": [{"$ref": "#/components/schemas/schemaEd25519Address"}, {"$ref": "#/components/schemas/schemaAccountAddress"}],
+		"discriminator": {
+			"propertyName": "type",
+			"mapping": {"0": "#/components/schemas/schemaEd25519Address", "8": "#/components/schemas/schemaAccountAddress"}
+		}
+	}`
+	require.JSONEq(t, expected, string(components.Components.Schemas["schemaAddress"]))
+}
+
+func TestExportJSONSchemaVersions(t *testing.T) {
+	api := newVersionsTestAPI(t)
+	require.NoError(t, api.RegisterTypeSettings(versionedOutput{}, serix.TypeSettings{}))
+
+	schemaBytes, err := api.ExportJSONSchema()
+	require.NoError(t, err)
+
+	var document struct {
+		Definitions map[string]json.RawMessage `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(schemaBytes, &document))
+
+	// the fields that are only part of some protocol versions are not required
+	expected := `{
+		"type": "object",
+		"properties": {
+			"amount": {"type": "string", "pattern": "^[0-9]+$"},
+			"mana": {"type": "string", "pattern": "^[0-9]+$"},
+			"legacy": {"type": "integer", "minimum": 0, "maximum": 65535},
+			"feature": {"$ref": "#/$defs/versionedFeature"}
+		},
+		"required": ["amount", "feature"]
+	}`
+	require.JSONEq(t, expected, string(document.Definitions["versionedOutput"]))
+}
@@ -11,6 +11,9 @@ import (
 )
 
 func (api *API) mapDecode(ctx context.Context, mapVal any, value reflect.Value, ts TypeSettings, opts *options) (err error) {
+	globalTS, _ := api.typeSettingsRegistry.GetByType(value.Type())
+	ts = ts.merge(globalTS)
+
 	var deserializable DeserializableJSON
 
 	if _, ok := value.Interface().(DeserializableJSON); ok {
@@ -41,6 +44,9 @@ func (api *API) mapDecode(ctx context.Context, mapVal any, value reflect.Value,
 	}
 
 	if opts.validation {
+		if err := checkValueRules(value, ts); err != nil {
+			return err
+		}
 		if err := api.callSyntacticValidator(ctx, value, value.Type()); err != nil {
 			return ierrors.Wrap(err, "post-serialization validation failed")
 		}
@@ -49,10 +55,10 @@ func (api *API) mapDecode(ctx context.Context, mapVal any, value reflect.Value,
 	return nil
 }
 
+// mapDecodeBasedOnType decodes the given map value into the given value based on its kind.
+// The given type settings must already be merged with the registered type settings of the value type.
 func (api *API) mapDecodeBasedOnType(ctx context.Context, mapVal any, value reflect.Value,
 	valueType reflect.Type, ts TypeSettings, opts *options) error {
-	globalTS, _ := api.typeSettingsRegistry.GetByType(valueType)
-	ts = ts.merge(globalTS)
 	switch value.Kind() {
 	case reflect.Ptr:
 		if valueType == bigIntPtrType {
@@ -292,9 +298,15 @@ func (api *API) mapDecodeInterface(
 	//nolint:forcetypeassert // false positive
 	objectCode := uint32(objectCodeAny.(float64))
 
-	objectType, exists := iObjects.GetObjectTypeByCode(objectCode)
+	objectType, exists, err := iObjects.objectTypeByCodeInVersion(ctx, objectCode, opts)
+	if err != nil {
+		return ierrors.Wrapf(err, "object code: %d, interface: %s", objectCode, valueType)
+	}
 	if !exists || objectType == nil {
-		return ierrors.Wrapf(ErrInterfaceUnderlyingTypeNotRegistered, "object code: %d, interface: %s", objectCode, valueType)
+		return withTypeCodeMismatch(
+			ierrors.Wrapf(ErrInterfaceUnderlyingTypeNotRegistered, "object code: %d, interface: %s", objectCode, valueType),
+			iObjects.objectCodesInVersion(ctx, opts), objectCode,
+		)
 	}
 
 	objectValue := reflect.New(objectType).Elem()
@@ -335,9 +347,16 @@ func (api *API) mapDecodeStruct(ctx context.Context, mapVal any, value reflect.V
 		if !has {
 			return ierrors.Wrap(err, "missing type key in struct")
 		}
-		if castedMapObjectCode, ok := mapObjectCode.(float64); !ok || uint32(castedMapObjectCode) != objectCode {
+		castedMapObjectCode, ok := mapObjectCode.(float64)
+		if !ok {
 			return ierrors.Errorf("map type key (%d) not equal registered object code (%d)", mapObjectCode, objectCode)
 		}
+		if uint32(castedMapObjectCode) != objectCode {
+			return withTypeCodeMismatch(
+				ierrors.Errorf("map type key (%d) not equal registered object code (%d)", mapObjectCode, objectCode),
+				[]uint32{objectCode}, uint32(castedMapObjectCode),
+			)
+		}
 	}
 
 	if err := api.mapDecodeStructFields(ctx, m, value, valueType, opts); err != nil {
@@ -359,6 +378,15 @@ func (api *API) mapDecodeStructFields(
 	}
 
 	for _, sField := range structFields {
+		inVersion, err := opts.inProtocolVersion(ctx, sField.settings.versionRange)
+		if err != nil {
+			return ierrors.Wrapf(err, "can't deserialize struct field %s", sField.name)
+		}
+		if !inVersion {
+			// the field is not part of the protocol version
+			continue
+		}
+
 		fieldValue := structVal.Field(sField.index)
 		if sField.isEmbedded && !sField.settings.inlined {
 			fieldType := sField.fType
@@ -406,11 +434,11 @@ func (api *API) mapDecodeStructFields(
 				continue
 			}
 
-			return ierrors.Errorf("missing map entry for field %s", sField.name)
+			return withDecodeErrorLocation(ierrors.Errorf("missing map entry for field %s", sField.name), fieldPathElement(sField.name), 0)
 		}
 
 		if err := api.mapDecode(ctx, mapVal, fieldValue, sField.settings.ts, opts); err != nil {
-			return ierrors.Wrapf(err, "failed to deserialize struct field %s", sField.name)
+			return ierrors.Wrapf(withDecodeErrorLocation(err, fieldPathElement(sField.name), 0), "failed to deserialize struct field %s", sField.name)
 		}
 	}
 
@@ -443,7 +471,7 @@ func (api *API) mapDecodeSlice(ctx context.Context, mapVal any, value reflect.Va
 	for i := range refVal.Len() {
 		elemValue := reflect.New(valueType.Elem()).Elem()
 		if err := api.mapDecode(ctx, refVal.Index(i).Interface(), elemValue, TypeSettings{}, opts); err != nil {
-			return ierrors.WithStack(err)
+			return withDecodeErrorLocation(ierrors.WithStack(err), indexPathElement(i), 0)
 		}
 		value.Set(reflect.Append(value, elemValue))
 	}
@@ -493,16 +521,19 @@ func (api *API) mapDecodeMap(ctx context.Context, mapVal any, value reflect.Valu
 		}
 
 		if err := api.mapDecode(ctx, k, keyValue, keyTypeSettings, opts); err != nil {
-			return ierrors.Wrapf(err, "failed to map decode map key of type %s", keyValue.Type())
+			return ierrors.Wrapf(withDecodeErrorLocation(err, indexPathElement(k), 0), "failed to map decode map key of type %s", keyValue.Type())
 		}
 
 		if value.MapIndex(keyValue).IsValid() {
 			// map entry already exists
-			return ierrors.Wrapf(ErrMapValidationViolatesUniqueness, "map entry with key %v already exists", keyValue.Interface())
+			return withDecodeErrorLocation(
+				ierrors.Wrapf(ErrMapValidationViolatesUniqueness, "map entry with key %v already exists", keyValue.Interface()),
+				indexPathElement(k), 0,
+			)
 		}
 
 		if err := api.mapDecode(ctx, v, elemValue, valueTypeSettings, opts); err != nil {
-			return ierrors.Wrapf(err, "failed to map decode map element of type %s", elemValue.Type())
+			return ierrors.Wrapf(withDecodeErrorLocation(err, indexPathElement(k), 0), "failed to map decode map element of type %s", elemValue.Type())
 		}
 
 		value.SetMapIndex(keyValue, elemValue)
@@ -131,7 +131,11 @@ func (api *API) mapEncodeInterface(
 	}
 
 	elemType := elemValue.Type()
-	if exists := registry.HasObjectType(elemType); !exists {
+	exists, err := registry.hasObjectTypeInVersion(ctx, elemType, opts)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "interface: %s", valueType)
+	}
+	if !exists {
 		return nil, ierrors.Wrapf(ErrInterfaceUnderlyingTypeNotRegistered, "type: %s, interface: %s", elemType, valueType)
 	}
 
@@ -158,7 +162,11 @@ func (api *API) mapEncodeStruct(
 	if ts.ObjectType() != nil {
 		obj.Set(keyType, ts.ObjectType())
 	}
-	if err := api.mapEncodeStructFields(ctx, obj, value, valueType, opts); err != nil {
+	if generated, ok := api.generatedSerializableJSON(valueI, opts); ok {
+		if err := generated.SerixEncodeJSON(ctx, api, obj, opts.toOptions()...); err != nil {
+			return nil, ierrors.WithStack(err)
+		}
+	} else if err := api.mapEncodeStructFields(ctx, obj, value, valueType, opts); err != nil {
 		return nil, ierrors.WithStack(err)
 	}
 
@@ -174,54 +182,72 @@ func (api *API) mapEncodeStructFields(
 	}
 
 	for _, sField := range structFields {
-		fieldValue := value.Field(sField.index)
-		if sField.isEmbedded && !sField.settings.inlined {
-			fieldType := sField.fType
-			if fieldValue.Kind() == reflect.Ptr {
-				if fieldValue.IsNil() {
-					continue
-				}
-				fieldValue = fieldValue.Elem()
-				fieldType = fieldType.Elem()
-			}
-			if err := api.mapEncodeStructFields(ctx, obj, fieldValue, fieldType, opts); err != nil {
-				return ierrors.Wrapf(err, "can't serialize embedded struct %s", sField.name)
-			}
-
-			continue
+		if err := api.mapEncodeStructField(ctx, obj, value, sField, opts); err != nil {
+			return err
 		}
+	}
 
-		if sField.settings.omitEmpty && api.isValueEmpty(fieldValue) {
-			continue
-		}
+	return nil
+}
+
+func (api *API) mapEncodeStructField(
+	ctx context.Context, obj *orderedmap.OrderedMap, value reflect.Value, sField structField, opts *options,
+) error {
+	inVersion, err := opts.inProtocolVersion(ctx, sField.settings.versionRange)
+	if err != nil {
+		return ierrors.Wrapf(err, "can't serialize struct field %s", sField.name)
+	}
+	if !inVersion {
+		// the field is not part of the protocol version
+		return nil
+	}
 
-		var eleOut any
-		if sField.settings.isOptional {
+	fieldValue := value.Field(sField.index)
+	if sField.isEmbedded && !sField.settings.inlined {
+		fieldType := sField.fType
+		if fieldValue.Kind() == reflect.Ptr {
 			if fieldValue.IsNil() {
-				continue
+				return nil
 			}
+			fieldValue = fieldValue.Elem()
+			fieldType = fieldType.Elem()
+		}
+		if err := api.mapEncodeStructFields(ctx, obj, fieldValue, fieldType, opts); err != nil {
+			return ierrors.Wrapf(err, "can't serialize embedded struct %s", sField.name)
 		}
 
-		eleOut, err = api.mapEncode(ctx, fieldValue, sField.settings.ts, opts)
-		if err != nil {
-			return ierrors.Wrapf(err, "failed to serialize optional struct field %s", sField.name)
+		return nil
+	}
+
+	if sField.settings.omitEmpty && api.isValueEmpty(fieldValue) {
+		return nil
+	}
+
+	if sField.settings.isOptional {
+		if fieldValue.IsNil() {
+			return nil
 		}
+	}
 
-		switch {
-		case sField.settings.ts.fieldKey != nil:
-			obj.Set(*sField.settings.ts.fieldKey, eleOut)
-		case sField.settings.inlined:
-			castedEleOut, ok := eleOut.(*orderedmap.OrderedMap)
-			if !ok {
-				return ierrors.Errorf("failed to cast inlined struct field %s to map", sField.name)
-			}
+	eleOut, err := api.mapEncode(ctx, fieldValue, sField.settings.ts, opts)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to serialize optional struct field %s", sField.name)
+	}
 
-			for _, k := range castedEleOut.Keys() {
-				obj.Set(k, lo.Return1(castedEleOut.Get(k)))
-			}
-		default:
-			obj.Set(FieldKeyString(sField.name), eleOut)
+	switch {
+	case sField.settings.ts.fieldKey != nil:
+		obj.Set(*sField.settings.ts.fieldKey, eleOut)
+	case sField.settings.inlined:
+		castedEleOut, ok := eleOut.(*orderedmap.OrderedMap)
+		if !ok {
+			return ierrors.Errorf("failed to cast inlined struct field %s to map", sField.name)
 		}
+
+		for _, k := range castedEleOut.Keys() {
+			obj.Set(k, lo.Return1(castedEleOut.Get(k)))
+		}
+	default:
+		obj.Set(FieldKeyString(sField.name), eleOut)
 	}
 
 	return nil
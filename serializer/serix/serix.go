@@ -30,10 +30,11 @@ You can provide the following settings to serix via struct tags:
 	- "omitempty": omit the field in json serialization if it's empty
 		`serix:"example,omitempty"`
 
-	- "maxByteSize": maximum serialized byte size for that field
+	- "maxByteSize": maximum serialized byte size for that field, enforced during encoding and decoding
 		`serix:"example,maxByteSize=100"`
 
-	- "lenPrefix": provide serializer.SeriLengthPrefixType for that field (string, slice, map)
+	- "lenPrefix": provide serializer.SeriLengthPrefixType for that field (string, slice, map).
+				   Possible values are "byte" or "uint8", "uint16", "uint32", "uint64" and "varint" (unsigned LEB128).
 		`serix:"example,lenPrefix=uint32"`
 
 	- "minLen": minimum length for that field (string, slice, map)
@@ -42,6 +43,36 @@ You can provide the following settings to serix via struct tags:
 	- "maxLen": maximum length for that field (string, slice, map)
 		`serix:"example,maxLen=5"`
 
+	- "min", "max": minimum and maximum value for that field (numbers), enforced during decoding with validation
+		`serix:"example,min=1,max=100"`
+
+	- "enum": allowed values of that field separated by "|" (numbers, strings), enforced during decoding with validation
+		`serix:"example,enum=1|2|5"`
+
+	- "nonzero": the field must not be the zero value of its type or empty, enforced during decoding with validation
+		`serix:"example,nonzero"`
+
+	- "pattern": regular expression that the field must match (strings), enforced during decoding with validation.
+				 The pattern must not contain commas.
+		`serix:"example,pattern=^[a-z]+$"`
+
+	- "since", "until": first and last protocol version the field is part of, see WithProtocolVersion
+		`serix:"example,since=2,until=4"`
+
+	- "description": description of the field that is used in the exported JSON schemas, see API.ExportJSONSchema()
+		`serix:"example,description=the example field"`
+
+The reflection based serialization of structs can be replaced by generated code, see API.GenerateCode and the serixgen package.
+
+Large objects can be serialized into an io.Writer and deserialized from an io.Reader incrementally, see API.EncodeTo and API.DecodeFrom.
+The serialized size of objects can be computed without serializing them, see API.Size.
+
+The JSON representation of objects can be encoded in the canonical form of RFC 8785 to hash and sign it, see
+WithCanonicalJSON.
+
+Objects can be merkleized field by field into a hash tree, whose root commits to the object and allows proving single
+fields of it, see API.HashTreeRoot and API.HashTreeProof.
+
 See serix_text.go for more detail.
 */
 package serix
@@ -49,8 +80,10 @@ package serix
 import (
 	"context"
 	"encoding/json"
+	"hash"
 	"math/big"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	// we need to use this orderedmap implementation for serialization instead of our own,
@@ -68,6 +101,8 @@ var (
 	ErrMapValidationViolatesUniqueness = ierrors.New("map elements must be unique")
 	// ErrNonUTF8String gets returned when a non UTF-8 string is being encoded/decoded.
 	ErrNonUTF8String = ierrors.New("non UTF-8 string value")
+	// ErrMaxByteSizeExceeded gets returned when the serialized form of an object exceeds its maximum byte size.
+	ErrMaxByteSizeExceeded = ierrors.New("max byte size exceeded")
 )
 
 var (
@@ -134,8 +169,44 @@ func WithTypeSettings(ts TypeSettings) Option {
 }
 
 type options struct {
-	validation bool
-	ts         TypeSettings
+	validation           bool
+	withoutGeneratedCode bool
+	readLimit            int
+	version              *uint32
+	defaultVersion       *uint32
+	newHash              func() hash.Hash
+	canonicalJSON        bool
+	ts                   TypeSettings
+}
+
+func applyOptions(opts []Option) *options {
+	opt := &options{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return opt
+}
+
+// applyOptions applies the given options on top of the settings of the API.
+func (api *API) applyOptions(opts []Option) *options {
+	opt := &options{
+		defaultVersion: api.defaultProtocolVersion.Load(),
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return opt
+}
+
+// toOptions returns the options as a list of Option that can be passed to the generated methods.
+func (o *options) toOptions() []Option {
+	copied := *o
+
+	return []Option{func(target *options) {
+		*target = copied
+	}}
 }
 
 func (o *options) toMode() serializer.DeSerializationMode {
@@ -164,6 +235,12 @@ type API struct {
 
 	// the cache for the struct fields
 	structFieldsCache *structFieldsCache
+
+	// the cache for the fingerprints of the generated code
+	generatedCodeCache *generatedCodeCache
+
+	// the protocol version that is used if neither the options nor the context set one
+	defaultProtocolVersion atomic.Pointer[uint32]
 }
 
 // NewAPI creates a new instance of the API type.
@@ -173,6 +250,7 @@ func NewAPI() *API {
 		typeSettingsRegistry: NewTypeSettingsRegistry(),
 		validatorsRegistry:   newValidatorsRegistry(),
 		structFieldsCache:    newStructFieldsCache(),
+		generatedCodeCache:   newGeneratedCodeCache(),
 	}
 
 	return api
@@ -229,6 +307,26 @@ func (api *API) checkArrayMustOccur(slice reflect.Value, ts TypeSettings) error
 	return nil
 }
 
+// checkMaxByteSize checks whether the serialized byte size of the given value doesn't exceed the maximum byte size
+// defined by the given type settings, which are already merged with the registered type settings of the value.
+func checkMaxByteSize(valueType reflect.Type, ts TypeSettings, byteSize int) error {
+	if err := ts.checkMaxByteSize(byteSize); err != nil {
+		return ierrors.Wrapf(err, "invalid serialized size of type %s", valueType)
+	}
+
+	return nil
+}
+
+// checkValueRules checks whether the given value satisfies the value rules defined by the given type settings,
+// which are already merged with the registered type settings of the value.
+func checkValueRules(value reflect.Value, ts TypeSettings) error {
+	if err := ts.checkValueRules(value); err != nil {
+		return ierrors.Wrapf(err, "invalid value of type %s", value.Type())
+	}
+
+	return nil
+}
+
 func (api *API) callSyntacticValidator(ctx context.Context, value reflect.Value, valueType reflect.Type) error {
 	vldtrs, exists := api.validatorsRegistry.Get(valueType)
 
@@ -291,6 +389,8 @@ func (api *API) getStructFields(structType reflect.Type) ([]structField, error)
 //
 // api.RegisterValidator(time.Time{}, syntacticValidator).
 func (api *API) RegisterValidator(obj any, syntacticValidatorFn interface{}) error {
+	defer api.generatedCodeCache.Reset()
+
 	return api.validatorsRegistry.RegisterValidator(obj, syntacticValidatorFn)
 }
 
@@ -306,6 +406,13 @@ func (api *API) RegisterInterfaceObjects(iType interface{}, objs ...interface{})
 	return api.interfacesRegistry.RegisterInterfaceObjects(api.typeSettingsRegistry, iType, objs...)
 }
 
+// RegisterInterfaceObjectsWithVersionRange registers the objects for the interface iType like RegisterInterfaceObjects,
+// but the objects are only accepted during the serialization and deserialization in the given range of protocol
+// versions, see WithProtocolVersion. This allows to reuse object codes for different objects in different versions.
+func (api *API) RegisterInterfaceObjectsWithVersionRange(iType interface{}, versionRange VersionRange, objs ...interface{}) error {
+	return api.interfacesRegistry.RegisterInterfaceObjectsWithVersionRange(api.typeSettingsRegistry, iType, versionRange, objs...)
+}
+
 func (api *API) ForEachRegisteredInterfaceObjects(consumer func(objType reflect.Type, interfaceObjects *InterfaceObjects) bool) {
 	api.interfacesRegistry.ForEach(func(objType reflect.Type, interfaceObjects *InterfaceObjects) bool {
 		return consumer(objType, interfaceObjects)
@@ -320,6 +427,8 @@ func (api *API) ForEachRegisteredInterfaceObjects(consumer func(objType reflect.
 // or by type settings provided via option to the Encode/Decode methods.
 // See TypeSettings for more detail.
 func (api *API) RegisterTypeSettings(obj interface{}, ts TypeSettings) error {
+	defer api.generatedCodeCache.Reset()
+
 	return api.typeSettingsRegistry.RegisterTypeSettings(obj, ts)
 }
 
@@ -340,22 +449,30 @@ func (api *API) Encode(ctx context.Context, obj interface{}, opts ...Option) ([]
 	if !value.IsValid() {
 		return nil, ierrors.New("invalid value for destination")
 	}
-	opt := &options{}
-	for _, o := range opts {
-		o(opt)
-	}
+	opt := api.applyOptions(opts)
 
 	return api.encode(ctx, value, opt.ts, opt)
 }
 
 // JSONEncode serializes the provided object obj into its JSON representation.
+// The fields of structs are written in the order of the struct, use WithCanonicalJSON to produce the canonical form
+// of the JSON representation instead.
 func (api *API) JSONEncode(ctx context.Context, obj any, opts ...Option) ([]byte, error) {
 	orderedMap, err := api.MapEncode(ctx, obj, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return json.Marshal(orderedMap)
+	data, err := json.Marshal(orderedMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if applyOptions(opts).canonicalJSON {
+		return canonicalizeJSON(data)
+	}
+
+	return data, nil
 }
 
 // MapEncode serializes the provided object obj into an ordered map.
@@ -366,10 +483,8 @@ func (api *API) MapEncode(ctx context.Context, obj interface{}, opts ...Option)
 	if !value.IsValid() {
 		return nil, ierrors.New("invalid value for destination")
 	}
-	opt := &options{}
-	for _, o := range opts {
-		o(opt)
-	}
+	opt := api.applyOptions(opts)
+
 	m, err := api.mapEncode(ctx, value, opt.ts, opt)
 	if err != nil {
 		return nil, err
@@ -388,18 +503,22 @@ func (api *API) MapEncode(ctx context.Context, obj interface{}, opts ...Option)
 // serix traverses the object recursively and deserializes everything based on its type.
 // If a type implements the custom Deserializable interface serix delegates the deserialization to that type.
 // During the decoding process serix also performs the validation if such option was provided.
+// Errors that occur while decoding the object are returned as *DecodeError, which holds the path and the offset of the
+// value that failed to decode.
 // Use the options list opts to customize the deserialization behavior.
 func (api *API) Decode(ctx context.Context, b []byte, obj interface{}, opts ...Option) (int, error) {
 	value := reflect.ValueOf(obj)
 	if err := checkDecodeDestination(obj, value); err != nil {
 		return 0, err
 	}
-	opt := &options{}
-	for _, o := range opts {
-		o(opt)
+	opt := api.applyOptions(opts)
+
+	bytesRead, err := api.decode(ctx, b, value, opt.ts, opt)
+	if err != nil {
+		return 0, newDecodeError(err, true)
 	}
 
-	return api.decode(ctx, b, value, opt.ts, opt)
+	return bytesRead, nil
 }
 
 // JSONDecode deserializes json data into the provided object obj.
@@ -415,16 +534,15 @@ func (api *API) JSONDecode(ctx context.Context, data []byte, obj interface{}, op
 // MapDecode deserializes generic map m into the provided object obj.
 // obj must be a non-nil pointer for serix to deserialize into it.
 // serix traverses the object recursively and deserializes everything based on its type.
+// Errors that occur while decoding the object are returned as *DecodeError, which holds the path of the value that
+// failed to decode.
 // Use the options list opts to customize the deserialization behavior.
 func (api *API) MapDecode(ctx context.Context, m map[string]any, obj interface{}, opts ...Option) error {
 	value := reflect.ValueOf(obj)
 	if err := checkDecodeDestination(obj, value); err != nil {
 		return err
 	}
-	opt := &options{}
-	for _, o := range opts {
-		o(opt)
-	}
+	opt := api.applyOptions(opts)
 
-	return api.mapDecode(ctx, m, value, opt.ts, opt)
+	return newDecodeError(api.mapDecode(ctx, m, value, opt.ts, opt), false)
 }
@@ -1,6 +1,8 @@
 package serix
 
 import (
+	"math/big"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -29,6 +31,34 @@ func parseStructTagValueUint(name string, keyValue []string, currentPart string)
 	return uint(result), nil
 }
 
+func parseStructTagValueVersion(name string, keyValue []string, currentPart string) (uint32, error) {
+	value, err := parseStructTagValue(name, keyValue, currentPart)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, ierrors.Wrapf(err, "failed to parse %s %s", name, currentPart)
+	}
+
+	return uint32(result), nil
+}
+
+func parseStructTagValueNumber(name string, keyValue []string, currentPart string) (*big.Float, error) {
+	value, err := parseStructTagValue(name, keyValue, currentPart)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ParseValueRulesNumber(value)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to parse %s %s", name, currentPart)
+	}
+
+	return result, nil
+}
+
 func parseLengthPrefixType(prefixTypeRaw string) (LengthPrefixType, error) {
 	switch prefixTypeRaw {
 	case "byte", "uint8":
@@ -39,6 +69,8 @@ func parseLengthPrefixType(prefixTypeRaw string) (LengthPrefixType, error) {
 		return LengthPrefixTypeAsUint32, nil
 	case "uint64":
 		return LengthPrefixTypeAsUint64, nil
+	case "varint":
+		return LengthPrefixTypeAsVarint, nil
 	default:
 		return LengthPrefixTypeAsByte, ierrors.Wrapf(ErrUnknownLengthPrefixType, "%s", prefixTypeRaw)
 	}
@@ -63,7 +95,9 @@ type TagSettings struct {
 	isOptional bool
 	inlined    bool
 	omitEmpty  bool
-	ts         TypeSettings
+	// versionRange defines the protocol versions the field is part of.
+	versionRange VersionRange
+	ts           TypeSettings
 }
 
 func (ts TagSettings) Position() int {
@@ -82,6 +116,10 @@ func (ts TagSettings) OmitEmpty() bool {
 	return ts.omitEmpty
 }
 
+func (ts TagSettings) VersionRange() VersionRange {
+	return ts.versionRange
+}
+
 func (ts TagSettings) TypeSettings() TypeSettings {
 	return ts.ts
 }
@@ -154,6 +192,66 @@ func ParseSerixSettings(tag string, serixPosition int) (TagSettings, error) {
 			}
 			settings.ts = settings.ts.WithMaxLen(value)
 
+		case "maxByteSize":
+			value, err := parseStructTagValueUint("maxByteSize", keyValue, currentPart)
+			if err != nil {
+				return TagSettings{}, err
+			}
+			settings.ts = settings.ts.WithMaxByteSize(value)
+
+		case "min":
+			value, err := parseStructTagValueNumber("min", keyValue, currentPart)
+			if err != nil {
+				return TagSettings{}, err
+			}
+			settings.ts = settings.ts.updateValueRules(func(rules *ValueRules) { rules.Min = value })
+
+		case "max":
+			value, err := parseStructTagValueNumber("max", keyValue, currentPart)
+			if err != nil {
+				return TagSettings{}, err
+			}
+			settings.ts = settings.ts.updateValueRules(func(rules *ValueRules) { rules.Max = value })
+
+		case "enum":
+			value, err := parseStructTagValue("enum", keyValue, currentPart)
+			if err != nil {
+				return TagSettings{}, err
+			}
+			settings.ts = settings.ts.updateValueRules(func(rules *ValueRules) { rules.Enum = strings.Split(value, "|") })
+
+		case "nonzero":
+			settings.ts = settings.ts.updateValueRules(func(rules *ValueRules) { rules.NonZero = true })
+
+		case "pattern":
+			// the pattern itself might contain "="
+			value, err := parseStructTagValue("pattern", strings.SplitN(currentPart, "=", 2), currentPart)
+			if err != nil {
+				return TagSettings{}, err
+			}
+			pattern, err := regexp.Compile(value)
+			if err != nil {
+				return TagSettings{}, ierrors.Wrapf(err, "failed to parse pattern %s", currentPart)
+			}
+			settings.ts = settings.ts.updateValueRules(func(rules *ValueRules) { rules.Pattern = pattern })
+
+		case "since":
+			value, err := parseStructTagValueVersion("since", keyValue, currentPart)
+			if err != nil {
+				return TagSettings{}, err
+			}
+			settings.versionRange.Since = value
+
+		case "until":
+			value, err := parseStructTagValueVersion("until", keyValue, currentPart)
+			if err != nil {
+				return TagSettings{}, err
+			}
+			if value == 0 {
+				return TagSettings{}, ierrors.Errorf("incorrect until tag: %s, until must be greater than 0", currentPart)
+			}
+			settings.versionRange.Until = value
+
 		default:
 			return TagSettings{}, ierrors.Errorf("unknown tag part: %s", currentPart)
 		}
@@ -161,5 +259,9 @@ func ParseSerixSettings(tag string, serixPosition int) (TagSettings, error) {
 		seenParts[partName] = struct{}{}
 	}
 
+	if settings.versionRange.Until != 0 && settings.versionRange.Until < settings.versionRange.Since {
+		return TagSettings{}, ierrors.Errorf("incorrect struct tag format: %s, until must not be less than since", tag)
+	}
+
 	return settings, nil
 }
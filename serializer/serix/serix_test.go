@@ -1,6 +1,7 @@
 package serix_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"reflect"
@@ -319,6 +320,49 @@ func TestSerixSerializeString(t *testing.T) {
 	}
 }
 
+func TestSerixLengthPrefixVarint(t *testing.T) {
+	type TestStruct struct {
+		Name  string   `serix:",lenPrefix=varint"`
+		Data  []byte   `serix:",lenPrefix=varint"`
+		Items []uint16 `serix:",lenPrefix=varint"`
+	}
+
+	source := &TestStruct{
+		Name:  "varint",
+		Data:  make([]byte, 200),
+		Items: []uint16{1, 2},
+	}
+
+	encoded, err := testAPI.Encode(ctx, source)
+	require.NoError(t, err)
+
+	expected := append([]byte{6}, "varint"...)
+	expected = append(expected, 0xc8, 0x01)
+	expected = append(expected, make([]byte, 200)...)
+	expected = append(expected, 2, 1, 0, 2, 0)
+	require.Equal(t, expected, encoded)
+
+	size, err := testAPI.Size(ctx, source)
+	require.NoError(t, err)
+	require.Equal(t, len(encoded), size)
+
+	target := &TestStruct{}
+	bytesRead, err := testAPI.Decode(ctx, encoded, target, serix.WithValidation())
+	require.NoError(t, err)
+	require.Equal(t, len(encoded), bytesRead)
+	require.Equal(t, source, target)
+
+	target = &TestStruct{}
+	bytesRead, err = testAPI.DecodeFrom(ctx, bytes.NewReader(encoded), target)
+	require.NoError(t, err)
+	require.Equal(t, len(encoded), bytesRead)
+	require.Equal(t, source, target)
+
+	prefixSize, err := serix.LengthPrefixTypeSize(serix.LengthPrefixTypeAsVarint)
+	require.NoError(t, err)
+	require.Equal(t, serializer.MaxVarintByteSize, prefixSize)
+}
+
 type deSerializeTest struct {
 	name      string
 	source    any
@@ -0,0 +1,77 @@
+// Package serixgen allows to generate the reflection-free serialization methods of serix structs using the
+// go:generate command.
+//
+// The generator is a small program in the package of the structs, which registers the type settings of the structs
+// on a serix.API and calls Main with representative instances of the structs, e.g.:
+//
+//	//go:build ignore
+//
+//	package main
+//
+//	func main() {
+//		serixgen.Main(mypackage.NewAPI(), mypackage.Output{Amount: 1}, mypackage.Transaction{...})
+//	}
+//
+// and is invoked via "//go:generate go run serixgen.go [-verify] [outputFile]".
+// With -verify, the existing output file is checked against the freshly generated code instead of being overwritten,
+// and the generated methods are compared with the serialization via reflection using the given instances.
+package serixgen
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+// DefaultOutputFile is the file the code is written to if no output file is given.
+const DefaultOutputFile = "serix.gen.go"
+
+// Main generates the code of the given structs and writes it to the output file, or verifies it if the -verify flag is
+// set. The package name of the generated file is taken from the GOPACKAGE environment variable set by go generate.
+func Main(api *serix.API, objs ...any) {
+	flagSet := flag.NewFlagSet("serixgen", flag.ExitOnError)
+	verify := flagSet.Bool("verify", false, "verify the existing output file and the generated methods instead of writing the file")
+	_ = flagSet.Parse(os.Args[1:])
+
+	outputFile := DefaultOutputFile
+	if flagSet.NArg() > 0 {
+		outputFile = flagSet.Arg(0)
+	}
+
+	if err := run(api, os.Getenv("GOPACKAGE"), outputFile, *verify, objs...); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "serixgen: %s\n", err)
+
+		os.Exit(1)
+	}
+}
+
+func run(api *serix.API, packageName string, outputFile string, verify bool, objs ...any) error {
+	if packageName == "" {
+		return ierrors.New("GOPACKAGE is not set, serixgen needs to be run via go generate")
+	}
+
+	source, err := api.GenerateCode(packageName, objs...)
+	if err != nil {
+		return err
+	}
+
+	if !verify {
+		return os.WriteFile(outputFile, source, 0600)
+	}
+
+	existingSource, err := os.ReadFile(outputFile)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to read %s", outputFile)
+	}
+
+	if !bytes.Equal(existingSource, source) {
+		return ierrors.Errorf("%s is outdated and needs to be regenerated", outputFile)
+	}
+
+	return api.VerifyGeneratedCode(context.Background(), objs...)
+}
@@ -0,0 +1,349 @@
+package serix
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"reflect"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2"
+)
+
+// Size returns the amount of bytes the provided object obj is serialized into by Encode, without serializing it.
+// The size is computed by walking the types and values of the object, only objects that serialize themselves
+// (see Serializable) are still serialized to determine their size.
+// Size doesn't validate the object, but it fails wherever Encode fails without validation, e.g. if a length prefix
+// type is missing or the maxByteSize of a value is exceeded.
+func (api *API) Size(ctx context.Context, obj any, opts ...Option) (int, error) {
+	value := reflect.ValueOf(obj)
+	if !value.IsValid() {
+		return 0, ierrors.New("invalid value for size")
+	}
+	opt := api.applyOptions(opts)
+
+	return api.size(ctx, value, opt.ts, opt)
+}
+
+func (api *API) size(ctx context.Context, value reflect.Value, ts TypeSettings, opts *options) (int, error) {
+	valueType := value.Type()
+	globalTS, _ := api.typeSettingsRegistry.GetByType(valueType)
+	ts = ts.merge(globalTS)
+
+	var size int
+	if valueType.Kind() != reflect.Interface && valueType.Implements(serializableType) {
+		prefixSize, err := objectTypeSize(ts)
+		if err != nil {
+			return 0, err
+		}
+
+		//nolint:forcetypeassert // false positive, we already checked the type
+		encoded, err := value.Interface().(Serializable).Encode()
+		if err != nil {
+			return 0, ierrors.Wrap(err, "object failed to serialize itself")
+		}
+		size = prefixSize + len(encoded)
+	} else {
+		var err error
+		if size, err = api.sizeBasedOnType(ctx, value, valueType, ts, opts); err != nil {
+			return 0, ierrors.WithStack(err)
+		}
+	}
+
+	if err := checkMaxByteSize(valueType, ts, size); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// sizeBasedOnType returns the serialized size of the given value based on its kind.
+// The given type settings must already be merged with the registered type settings of the value type.
+func (api *API) sizeBasedOnType(ctx context.Context, value reflect.Value, valueType reflect.Type, ts TypeSettings, opts *options) (int, error) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if valueType == bigIntPtrType {
+			return sizeBigInt(value)
+		}
+		elemValue := value.Elem()
+		if !elemValue.IsValid() {
+			return 0, ierrors.Errorf("unexpected nil pointer for type %s", valueType)
+		}
+
+		switch elemValue.Kind() {
+		case reflect.Struct:
+			return api.sizeStruct(ctx, elemValue, elemValue.Type(), ts, opts)
+		case reflect.Array:
+			return api.sizeArray(ctx, elemValue, elemValue.Type(), ts, opts)
+		}
+
+	case reflect.Struct:
+		if valueType == timeType {
+			return serializer.UInt64ByteSize, nil
+		}
+
+		return api.sizeStruct(ctx, value, valueType, ts, opts)
+	case reflect.Slice:
+		return api.sizeSlice(ctx, value, valueType, ts, opts)
+	case reflect.Map:
+		return api.sizeMap(ctx, value, valueType, ts, opts)
+	case reflect.Array:
+		return api.sizeArray(ctx, value, valueType, ts, opts)
+	case reflect.Interface:
+		return api.sizeInterface(ctx, value, valueType, ts, opts)
+	case reflect.String:
+		if _, set := ts.LengthPrefixType(); !set {
+			return 0, ierrors.New("can't serialize 'string' type: no LengthPrefixType was provided")
+		}
+
+		return sizeWithLengthPrefix(value.Len(), value.Len(), ts)
+	case reflect.Bool:
+		return serializer.OneByte, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		_, typeToConvert, _ := getNumberTypeToConvert(valueType.Kind())
+
+		return int(typeToConvert.Size()), nil
+	default:
+	}
+
+	return 0, ierrors.Errorf("can't encode: unsupported type %s", valueType)
+}
+
+func (api *API) sizeInterface(ctx context.Context, value reflect.Value, valueType reflect.Type, ts TypeSettings, opts *options) (int, error) {
+	elemValue := value.Elem()
+	if !elemValue.IsValid() {
+		return 0, ierrors.Errorf("can't serialize interface %s it must have underlying value", valueType)
+	}
+
+	registry := api.getInterfaceObjects(valueType)
+	if registry == nil {
+		return 0, ierrors.Errorf("interface %s isn't registered", valueType)
+	}
+
+	elemType := elemValue.Type()
+	exists, err := registry.hasObjectTypeInVersion(ctx, elemType, opts)
+	if err != nil {
+		return 0, ierrors.Wrapf(err, "interface: %s", valueType)
+	}
+	if !exists {
+		return 0, ierrors.Wrapf(ErrInterfaceUnderlyingTypeNotRegistered, "type: %s, interface: %s", elemType, valueType)
+	}
+
+	size, err := api.size(ctx, elemValue, ts, opts)
+	if err != nil {
+		return 0, ierrors.Wrapf(err, "failed to encode interface element %s", elemType)
+	}
+
+	return size, nil
+}
+
+func (api *API) sizeStruct(ctx context.Context, value reflect.Value, valueType reflect.Type, ts TypeSettings, opts *options) (int, error) {
+	prefixSize, err := objectTypeSize(ts)
+	if err != nil {
+		return 0, err
+	}
+
+	fieldsSize, err := api.sizeStructFields(ctx, value, valueType, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	return prefixSize + fieldsSize, nil
+}
+
+func (api *API) sizeStructFields(ctx context.Context, value reflect.Value, valueType reflect.Type, opts *options) (int, error) {
+	structFields, err := api.getStructFields(valueType)
+	if err != nil {
+		return 0, ierrors.Wrapf(err, "can't parse struct type %s", valueType)
+	}
+
+	var size int
+	for _, sField := range structFields {
+		inVersion, err := opts.inProtocolVersion(ctx, sField.settings.versionRange)
+		if err != nil {
+			return 0, ierrors.Wrapf(err, "can't compute the size of struct field %s", sField.name)
+		}
+		if !inVersion {
+			// the field is not part of the protocol version
+			continue
+		}
+
+		fieldValue := value.Field(sField.index)
+
+		switch {
+		case sField.isEmbedded && !sField.settings.inlined:
+			fieldType := sField.fType
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+				fieldType = fieldType.Elem()
+			}
+
+			embeddedSize, err := api.sizeStructFields(ctx, fieldValue, fieldType, opts)
+			if err != nil {
+				return 0, ierrors.Wrapf(err, "can't serialize embedded struct %s", sField.name)
+			}
+			size += embeddedSize
+
+		case sField.settings.isOptional:
+			// optional fields are prefixed with their length
+			size += serializer.PayloadLengthByteSize
+			if fieldValue.IsNil() {
+				continue
+			}
+
+			fieldSize, err := api.size(ctx, fieldValue, sField.settings.ts, opts)
+			if err != nil {
+				return 0, ierrors.Wrapf(err, "failed to serialize optional struct field %s", sField.name)
+			}
+			size += fieldSize
+
+		default:
+			fieldSize, err := api.size(ctx, fieldValue, sField.settings.ts, opts)
+			if err != nil {
+				return 0, ierrors.Wrapf(err, "failed to serialize struct field %s", sField.name)
+			}
+			size += fieldSize
+		}
+	}
+
+	return size, nil
+}
+
+func (api *API) sizeArray(ctx context.Context, value reflect.Value, valueType reflect.Type, ts TypeSettings, opts *options) (int, error) {
+	// check if it is an array of bytes
+	if valueType.Elem() == bytesType.Elem() {
+		prefixSize, err := objectTypeSize(ts)
+		if err != nil {
+			return 0, err
+		}
+
+		return prefixSize + value.Len(), nil
+	}
+
+	// if it is an array of objects, handle the array like a slice
+	return api.sizeElements(ctx, value, valueType, ts, opts)
+}
+
+func (api *API) sizeSlice(ctx context.Context, value reflect.Value, valueType reflect.Type, ts TypeSettings, opts *options) (int, error) {
+	if valueType.AssignableTo(bytesType) {
+		if _, set := ts.LengthPrefixType(); !set {
+			return 0, ierrors.Errorf("no LengthPrefixType was provided for slice type %s", valueType)
+		}
+
+		// the bounds of byte slices are checked even without validation
+		sliceLen := value.Len()
+		minLen, maxLen := ts.MinMaxLen()
+		switch {
+		case maxLen > 0 && sliceLen > maxLen:
+			return 0, ierrors.Wrapf(serializer.ErrSliceLengthTooLong, "slice (len %d) exceeds max length of %d ", sliceLen, maxLen)
+		case minLen > 0 && sliceLen < minLen:
+			return 0, ierrors.Wrapf(serializer.ErrSliceLengthTooShort, "slice (len %d) is less than min length of %d ", sliceLen, minLen)
+		}
+
+		return sizeWithLengthPrefix(sliceLen, sliceLen, ts)
+	}
+
+	return api.sizeElements(ctx, value, valueType, ts, opts)
+}
+
+// sizeElements returns the size of the elements of a slice or an array of objects including their length prefix.
+func (api *API) sizeElements(ctx context.Context, value reflect.Value, valueType reflect.Type, ts TypeSettings, opts *options) (int, error) {
+	if _, set := ts.LengthPrefixType(); !set {
+		return 0, ierrors.Errorf("no LengthPrefixType was provided for type %s", valueType)
+	}
+
+	var elementsSize int
+	for i := range value.Len() {
+		elemSize, err := api.size(ctx, value.Index(i), TypeSettings{}, opts)
+		if err != nil {
+			return 0, ierrors.Wrapf(err, "failed to encode element with index %d of slice %s", i, valueType)
+		}
+		elementsSize += elemSize
+	}
+
+	return sizeWithLengthPrefix(value.Len(), elementsSize, ts)
+}
+
+func (api *API) sizeMap(ctx context.Context, value reflect.Value, valueType reflect.Type, ts TypeSettings, opts *options) (int, error) {
+	if _, set := ts.LengthPrefixType(); !set {
+		return 0, ierrors.Errorf("no LengthPrefixType was provided for type %s", valueType)
+	}
+
+	var entriesSize int
+	iter := value.MapRange()
+	for iter.Next() {
+		key := iter.Key()
+		keySize, err := api.size(ctx, key, api.typeSettingsRegistry.GetByValue(key), opts)
+		if err != nil {
+			return 0, ierrors.Wrapf(err, "failed to encode map key of type %s", key.Type())
+		}
+
+		elem := iter.Value()
+		elemSize, err := api.size(ctx, elem, api.typeSettingsRegistry.GetByValue(elem), opts)
+		if err != nil {
+			return 0, ierrors.Wrapf(err, "failed to encode map element of type %s", elem.Type())
+		}
+
+		entriesSize += keySize + elemSize
+	}
+
+	return sizeWithLengthPrefix(value.Len(), entriesSize, ts)
+}
+
+// sizeWithLengthPrefix returns the given size of a collection with the given length plus the size of its length
+// prefix, which must be set in the type settings.
+func sizeWithLengthPrefix(length int, size int, ts TypeSettings) (int, error) {
+	lengthPrefixType, _ := ts.LengthPrefixType()
+	if lengthPrefixType == LengthPrefixTypeAsVarint {
+		return serializer.VarintSize(uint64(length)) + size, nil
+	}
+
+	prefixSize, err := LengthPrefixTypeSize(lengthPrefixType)
+	if err != nil {
+		return 0, ierrors.Wrapf(err, "can't determine the size of length prefix type %d", lengthPrefixType)
+	}
+
+	if prefixSize < serializer.UInt64ByteSize {
+		if maxLength := uint64(1)<<(8*prefixSize) - 1; uint64(length) > maxLength {
+			return 0, ierrors.Errorf("unable to serialize collection length: length %d is out of range (0-%d)", length, maxLength)
+		}
+	}
+
+	return prefixSize + size, nil
+}
+
+// objectTypeSize returns the size of the object type code of the given type settings, or 0 if it isn't set.
+func objectTypeSize(ts TypeSettings) (int, error) {
+	objectType := ts.ObjectType()
+	if objectType == nil {
+		return 0, nil
+	}
+
+	size := binary.Size(objectType)
+	if size < 0 {
+		return 0, ierrors.Errorf("unsupported object type code %T", objectType)
+	}
+
+	return size, nil
+}
+
+func sizeBigInt(value reflect.Value) (int, error) {
+	//nolint:forcetypeassert // false positive, we already checked the type
+	valueBigInt := value.Interface().(*big.Int)
+
+	switch {
+	case valueBigInt == nil:
+		return 0, ierrors.Wrap(serializer.ErrUint256Nil, "failed to write math big int to serializer")
+	case valueBigInt.Sign() == -1:
+		return 0, ierrors.Wrap(serializer.ErrUint256NumNegative, "failed to write math big int to serializer")
+	case valueBigInt.BitLen() > serializer.UInt256ByteSize*8:
+		return 0, ierrors.Wrap(serializer.ErrUint256TooBig, "failed to write math big int to serializer")
+	}
+
+	return serializer.UInt256ByteSize, nil
+}
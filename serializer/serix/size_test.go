@@ -0,0 +1,87 @@
+package serix_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+func TestSize(t *testing.T) {
+	ctx := context.Background()
+	streamAPI := newStreamTestAPI(t)
+	versionsAPI := newVersionsTestAPI(t)
+
+	tests := []struct {
+		name string
+		api  *serix.API
+		obj  any
+		opts []serix.Option
+	}{
+		{name: "simple struct", api: testAPI, obj: NewSimpleStruct()},
+		{name: "pointer", api: testAPI, obj: &SimpleStruct{Time: NewSimpleStruct().Time, BigInt: NewSimpleStruct().BigInt}},
+		{name: "slice", api: testAPI, obj: Bools{true, false, true}, opts: []serix.Option{serix.WithTypeSettings(serix.TypeSettings{}.WithLengthPrefixType(boolsLenType))}},
+		{name: "map", api: testAPI, obj: Map{1: 2, 3: 4}, opts: []serix.Option{serix.WithTypeSettings(serix.TypeSettings{}.WithLengthPrefixType(mapLenType))}},
+		{name: "interface", api: testAPI, obj: StructWithInterface{Interface: &InterfaceImpl{interfaceImpl{A: 1, B: 2}}}},
+		{name: "optional nil", api: testAPI, obj: StructWithOptionalField{}},
+		{name: "optional", api: testAPI, obj: StructWithOptionalField{Optional: &ExportedStruct{Bar: 1}}},
+		{name: "embedded structs", api: testAPI, obj: StructWithEmbeddedStructs{unexportedStruct: unexportedStruct{Foo: 1}, ExportedStruct: ExportedStruct{Bar: 2}}},
+		{name: "serializable", api: testAPI, obj: CustomSerializable(2)},
+		{name: "snapshot", api: streamAPI, obj: newStreamSnapshot(100)},
+		{name: "version 1", api: versionsAPI, obj: &versionedOutput{Amount: 1, Feature: &versionedTagFeature{}}, opts: []serix.Option{serix.WithProtocolVersion(1)}},
+		{name: "version 2", api: versionsAPI, obj: &versionedOutput{Amount: 1, Feature: &versionedTagFeatureV2{}}, opts: []serix.Option{serix.WithProtocolVersion(2)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encoded, err := test.api.Encode(ctx, test.obj, test.opts...)
+			require.NoError(t, err)
+
+			size, err := test.api.Size(ctx, test.obj, test.opts...)
+			require.NoError(t, err)
+			require.Equal(t, len(encoded), size)
+		})
+	}
+}
+
+func TestSizeErrors(t *testing.T) {
+	ctx := context.Background()
+	api := newStreamTestAPI(t)
+
+	// the maxByteSize of the data is exceeded
+	snapshot := newStreamSnapshot(1)
+	snapshot.Outputs[0].Data = make([]byte, 33)
+	_, err := api.Size(ctx, snapshot)
+	require.ErrorIs(t, err, serix.ErrMaxByteSizeExceeded)
+
+	// the length doesn't fit into the length prefix
+	snapshot = newStreamSnapshot(1)
+	snapshot.IDs = make(streamIDs, 256)
+	_, err = api.Size(ctx, snapshot)
+	require.Error(t, err)
+
+	// the interface object is not part of the protocol version
+	_, err = newVersionsTestAPI(t).Size(ctx, &versionedOutput{Feature: &versionedTagFeatureV2{}}, serix.WithProtocolVersion(1))
+	require.ErrorIs(t, err, serix.ErrInterfaceUnderlyingTypeNotRegistered)
+}
+
+func TestSizeAllocations(t *testing.T) {
+	ctx := context.Background()
+	api := newStreamTestAPI(t)
+
+	small := newStreamSnapshot(10)
+	large := newStreamSnapshot(1000)
+	_, err := api.Size(ctx, small)
+	require.NoError(t, err)
+
+	// the allocations don't depend on the size of the object, since it is never serialized
+	smallAllocs := testing.AllocsPerRun(10, func() {
+		_, _ = api.Size(ctx, small)
+	})
+	largeAllocs := testing.AllocsPerRun(10, func() {
+		_, _ = api.Size(ctx, large)
+	})
+	require.Equal(t, smallAllocs, largeAllocs)
+}
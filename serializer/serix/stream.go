@@ -0,0 +1,665 @@
+package serix
+
+import (
+	"context"
+	"io"
+	"math"
+	"reflect"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hive.go/serializer/v2/stream"
+)
+
+var (
+	// ErrReadLimitExceeded gets returned when more bytes than allowed by the read limit need to be read from a reader.
+	ErrReadLimitExceeded = ierrors.New("read limit exceeded")
+)
+
+// streamReadChunkSize is the minimum amount of bytes that is read from the reader at once.
+const streamReadChunkSize = 4096
+
+// WithReadLimit returns an Option that limits the amount of bytes API.DecodeFrom reads from the reader.
+func WithReadLimit(limit int) Option {
+	return func(o *options) {
+		o.readLimit = limit
+	}
+}
+
+// EncodeTo serializes the provided object obj into the writer and returns the amount of bytes written.
+// It produces exactly the same bytes as Encode, but structs and slices of objects are written field by field and
+// element by element, so that the serialized form of the whole object is never held in memory.
+// Slices that need to be lexically ordered and objects that serialize themselves are still serialized at once.
+// If an error occurs, parts of the object might already have been written to the writer.
+func (api *API) EncodeTo(ctx context.Context, writer io.Writer, obj any, opts ...Option) (int, error) {
+	value := reflect.ValueOf(obj)
+	if !value.IsValid() {
+		return 0, ierrors.New("invalid value for destination")
+	}
+	opt := api.applyOptions(opts)
+
+	encoder := &streamEncoder{writer: writer}
+	err := api.encodeTo(ctx, encoder, value, opt.ts, opt)
+
+	return encoder.written, err
+}
+
+// DecodeFrom deserializes the provided object obj from the reader and returns the amount of bytes the serialized
+// object consists of. obj must be a non-nil pointer for serix to deserialize into it.
+// It produces exactly the same result as Decode, but the fields of structs and the elements of slices of objects are
+// read one after another, so that only the currently decoded value is buffered instead of the whole serialized object.
+// The amount of bytes read from the reader can be limited via WithReadLimit, and the maxByteSize of the decoded values
+// limits the amount of bytes that are buffered for them.
+// The reader is read in chunks, so bytes after the serialized object might be consumed as well, unless the reader
+// implements io.Seeker, in which case DecodeFrom seeks back to the end of the object.
+func (api *API) DecodeFrom(ctx context.Context, reader io.Reader, obj any, opts ...Option) (int, error) {
+	value := reflect.ValueOf(obj)
+	if err := checkDecodeDestination(obj, value); err != nil {
+		return 0, err
+	}
+	opt := api.applyOptions(opts)
+
+	decoder := newStreamDecoder(reader, opt.readLimit)
+	if err := api.decodeFrom(ctx, decoder, value, opt.ts, opt); err != nil {
+		return 0, newDecodeError(err, true)
+	}
+
+	if seeker, ok := reader.(io.Seeker); ok && len(decoder.buffer) > 0 {
+		if _, err := seeker.Seek(-int64(len(decoder.buffer)), io.SeekCurrent); err != nil {
+			return 0, ierrors.Wrap(err, "failed to seek back to the end of the object")
+		}
+	}
+
+	return decoder.consumed, nil
+}
+
+// streamEncoder counts the bytes that are written to the underlying writer.
+type streamEncoder struct {
+	writer  io.Writer
+	written int
+}
+
+// Write writes the given bytes to the underlying writer.
+func (e *streamEncoder) Write(p []byte) (int, error) {
+	n, err := e.writer.Write(p)
+	e.written += n
+
+	return n, err
+}
+
+func (api *API) encodeTo(ctx context.Context, encoder *streamEncoder, value reflect.Value, ts TypeSettings, opts *options) error {
+	valueType := value.Type()
+	globalTS, _ := api.typeSettingsRegistry.GetByType(valueType)
+	mergedTS := ts.merge(globalTS)
+
+	structValue, isStruct := api.streamableStruct(value)
+	isSlice := api.streamableSlice(value, mergedTS, opts)
+	if !isStruct && !isSlice {
+		encodedBytes, err := api.encode(ctx, value, ts, opts)
+		if err != nil {
+			return err
+		}
+
+		return writeTo(encoder, encodedBytes)
+	}
+
+	if opts.validation {
+		if err := api.callSyntacticValidator(ctx, value, valueType); err != nil {
+			return ierrors.Errorf("pre-serialization validation failed: %w", err)
+		}
+		if err := mergedTS.checkMinMaxBounds(value); err != nil {
+			return err
+		}
+	}
+
+	startOffset := encoder.written
+	if isStruct {
+		if err := api.encodeStructTo(ctx, encoder, structValue, mergedTS, opts); err != nil {
+			return err
+		}
+	} else if err := api.encodeSliceTo(ctx, encoder, value, valueType, mergedTS, opts); err != nil {
+		return err
+	}
+
+	return checkMaxByteSize(valueType, mergedTS, encoder.written-startOffset)
+}
+
+func (api *API) encodeStructTo(ctx context.Context, encoder *streamEncoder, value reflect.Value, ts TypeSettings, opts *options) error {
+	if objectType := ts.ObjectType(); objectType != nil {
+		prefixBytes, err := serializer.NewSerializer().WriteNum(objectType, func(err error) error {
+			return ierrors.Wrap(err, "failed to write object type code into serializer")
+		}).Serialize()
+		if err != nil {
+			return err
+		}
+
+		if err := writeTo(encoder, prefixBytes); err != nil {
+			return err
+		}
+	}
+
+	return api.encodeStructFieldsTo(ctx, encoder, value, value.Type(), opts)
+}
+
+func (api *API) encodeStructFieldsTo(ctx context.Context, encoder *streamEncoder, value reflect.Value, valueType reflect.Type, opts *options) error {
+	structFields, err := api.getStructFields(valueType)
+	if err != nil {
+		return ierrors.Wrapf(err, "can't parse struct type %s", valueType)
+	}
+
+	for _, sField := range structFields {
+		inVersion, err := opts.inProtocolVersion(ctx, sField.settings.versionRange)
+		if err != nil {
+			return ierrors.Wrapf(err, "can't serialize struct field %s", sField.name)
+		}
+		if !inVersion {
+			// the field is not part of the protocol version
+			continue
+		}
+
+		fieldValue := value.Field(sField.index)
+
+		switch {
+		case sField.isEmbedded && !sField.settings.inlined:
+			fieldType := sField.fType
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+				fieldType = fieldType.Elem()
+			}
+			if err := api.encodeStructFieldsTo(ctx, encoder, fieldValue, fieldType, opts); err != nil {
+				return ierrors.Wrapf(err, "can't serialize embedded struct %s", sField.name)
+			}
+
+		case !sField.settings.isOptional:
+			if err := api.encodeTo(ctx, encoder, fieldValue, sField.settings.ts, opts); err != nil {
+				return ierrors.Wrapf(err, "failed to serialize struct field %s", sField.name)
+			}
+
+		default:
+			// optional fields are prefixed with their length, so they need to be serialized at once
+			seri := serializer.NewSerializer()
+			if err := api.encodeStructField(ctx, seri, value, sField, opts); err != nil {
+				return err
+			}
+
+			fieldBytes, err := seri.Serialize()
+			if err != nil {
+				return err
+			}
+
+			if err := writeTo(encoder, fieldBytes); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (api *API) encodeSliceTo(ctx context.Context, encoder *streamEncoder, value reflect.Value, valueType reflect.Type, ts TypeSettings, opts *options) error {
+	if opts.validation {
+		if err := api.checkArrayMustOccur(value, ts); err != nil {
+			return ierrors.Wrapf(err, "can't serialize '%s' type", value.Kind())
+		}
+	}
+
+	lengthPrefixType, _ := ts.LengthPrefixType()
+	serializerArrayRules := serializerArrayRules(ts)
+
+	var elementValidationFunc serializer.ElementValidationFunc
+	if opts.validation {
+		if err := serializerArrayRules.CheckBounds(uint(value.Len())); err != nil {
+			return ierrors.Wrapf(err, "serializer failed to write %s as slice of bytes", valueType)
+		}
+		elementValidationFunc = serializerArrayRules.ElementValidationFunc()
+	}
+
+	if err := stream.WriteSize(encoder, value.Len(), serializer.SeriLengthPrefixType(lengthPrefixType)); err != nil {
+		return ierrors.Wrapf(err, "failed to write length of slice %s", valueType)
+	}
+
+	for i := range value.Len() {
+		// the elements only need to be serialized at once if their bytes need to be validated
+		if elementValidationFunc == nil {
+			if err := api.encodeTo(ctx, encoder, value.Index(i), TypeSettings{}, opts); err != nil {
+				return ierrors.Wrapf(err, "failed to encode element with index %d of slice %s", i, valueType)
+			}
+
+			continue
+		}
+
+		elemBytes, err := api.encode(ctx, value.Index(i), TypeSettings{}, opts)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to encode element with index %d of slice %s", i, valueType)
+		}
+
+		if err := elementValidationFunc(i, elemBytes); err != nil {
+			return ierrors.Wrapf(err, "serializer failed to write %s as slice of bytes", valueType)
+		}
+
+		if err := writeTo(encoder, elemBytes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// streamDecoder buffers the bytes that are read from the underlying reader until they are consumed by the decoder.
+type streamDecoder struct {
+	reader io.Reader
+	// buffer holds the bytes that were read from the reader but not consumed yet.
+	buffer []byte
+	// consumed is the amount of bytes consumed by the decoder.
+	consumed int
+	// read is the amount of bytes read from the reader.
+	read int
+	// readLimit is the maximum amount of bytes that can be read from the reader.
+	readLimit int
+	// consumeLimit is the offset up to which the currently decoded value can consume bytes.
+	consumeLimit int
+	eof          bool
+}
+
+func newStreamDecoder(reader io.Reader, readLimit int) *streamDecoder {
+	if readLimit <= 0 {
+		readLimit = math.MaxInt
+	}
+
+	return &streamDecoder{
+		reader:       reader,
+		readLimit:    readLimit,
+		consumeLimit: math.MaxInt,
+	}
+}
+
+// Read reads the buffered bytes and reads from the underlying reader if there are none.
+func (d *streamDecoder) Read(p []byte) (int, error) {
+	if err := d.fill(len(p)); err != nil {
+		return 0, err
+	}
+
+	if len(d.buffer) == 0 {
+		if !d.eof {
+			return 0, ierrors.Wrapf(ErrReadLimitExceeded, "limit %d", d.readLimit)
+		}
+
+		return 0, io.EOF
+	}
+
+	n := copy(p, d.buffer)
+	d.consume(n)
+
+	return n, nil
+}
+
+// fill reads from the underlying reader until the given amount of bytes is buffered or the end of the reader or the
+// read limit is reached.
+func (d *streamDecoder) fill(size int) error {
+	for len(d.buffer) < size && !d.eof && d.read < d.readLimit {
+		// we never overwrite bytes that were handed out to the decoder, because decoded values might reference them
+		chunkSize := min(max(size-len(d.buffer), streamReadChunkSize), d.readLimit-d.read)
+		if cap(d.buffer)-len(d.buffer) < chunkSize {
+			buffer := make([]byte, len(d.buffer), len(d.buffer)+chunkSize)
+			copy(buffer, d.buffer)
+			d.buffer = buffer
+		}
+
+		n, err := d.reader.Read(d.buffer[len(d.buffer) : len(d.buffer)+chunkSize])
+		d.buffer = d.buffer[:len(d.buffer)+n]
+		d.read += n
+
+		if ierrors.Is(err, io.EOF) {
+			d.eof = true
+		} else if err != nil {
+			return ierrors.Wrap(err, "failed to read from reader")
+		}
+	}
+
+	return nil
+}
+
+func (d *streamDecoder) consume(n int) {
+	d.buffer = d.buffer[n:]
+	d.consumed += n
+}
+
+// decodeBuffered decodes a value from the buffered bytes via the given function. If the buffered bytes are not
+// sufficient, more bytes are read from the reader and the value is decoded again.
+func (d *streamDecoder) decodeBuffered(decodeFunc func(b []byte) (int, error)) error {
+	startOffset := d.consumed
+	if err := d.retryDecodeBuffered(decodeFunc); err != nil {
+		// the offsets of the decode errors are relative to the buffered bytes
+		return withDecodeErrorLocation(err, "", startOffset)
+	}
+
+	return nil
+}
+
+func (d *streamDecoder) retryDecodeBuffered(decodeFunc func(b []byte) (int, error)) error {
+	available := d.consumeLimit - d.consumed
+	for {
+		bytesRead, err := decodeFunc(d.buffer[:min(len(d.buffer), available)])
+		if err == nil {
+			d.consume(bytesRead)
+
+			return nil
+		}
+
+		switch {
+		case !ierrors.Is(err, serializer.ErrDeserializationNotEnoughData):
+			return err
+		case len(d.buffer) >= available:
+			return ierrors.Wrapf(ErrMaxByteSizeExceeded, "value exceeds the remaining %d bytes", available)
+		case d.eof:
+			return err
+		case d.read >= d.readLimit:
+			return ierrors.Wrapf(ErrReadLimitExceeded, "limit %d", d.readLimit)
+		}
+
+		if err := d.fill(min(max(2*len(d.buffer), streamReadChunkSize), available)); err != nil {
+			return err
+		}
+	}
+}
+
+func (api *API) decodeFrom(ctx context.Context, decoder *streamDecoder, value reflect.Value, ts TypeSettings, opts *options) error {
+	valueType := value.Type()
+	globalTS, _ := api.typeSettingsRegistry.GetByType(valueType)
+	mergedTS := ts.merge(globalTS)
+
+	startOffset := decoder.consumed
+	if maxByteSize, has := mergedTS.MaxByteSize(); has {
+		consumeLimit := decoder.consumeLimit
+		defer func() { decoder.consumeLimit = consumeLimit }()
+
+		decoder.consumeLimit = min(consumeLimit, startOffset+int(maxByteSize))
+	}
+
+	target := value
+	if !isDeserializable(value) && value.Kind() == reflect.Ptr && valueType != bigIntPtrType {
+		if elemKind := valueType.Elem().Kind(); elemKind == reflect.Struct || elemKind == reflect.Slice {
+			if value.IsNil() {
+				value.Set(reflect.New(valueType.Elem()))
+			}
+			target = value.Elem()
+		}
+	}
+
+	_, isStruct := api.streamableStruct(target)
+	isSlice := api.streamableSlice(target, mergedTS, opts)
+	if !isStruct && !isSlice {
+		restoreValue := restorableValue(value)
+
+		return decoder.decodeBuffered(func(b []byte) (int, error) {
+			restoreValue()
+
+			return api.decode(ctx, b, value, ts, opts)
+		})
+	}
+
+	if isStruct {
+		if contextAwareDeserializable, ok := value.Interface().(ContextAwareDeserializable); ok {
+			contextAwareDeserializable.SetDeserializationContext(ctx)
+		}
+
+		if err := api.decodeStructFrom(ctx, decoder, target, mergedTS, opts); err != nil {
+			return err
+		}
+	} else if err := api.decodeSliceFrom(ctx, decoder, target, target.Type(), mergedTS, opts); err != nil {
+		return err
+	}
+
+	if err := checkMaxByteSize(valueType, mergedTS, decoder.consumed-startOffset); err != nil {
+		return withDecodeErrorLocation(err, "", startOffset)
+	}
+
+	if opts.validation {
+		if err := checkValueRules(value, mergedTS); err != nil {
+			return withDecodeErrorLocation(err, "", startOffset)
+		}
+		if err := api.callSyntacticValidator(ctx, value, valueType); err != nil {
+			return withDecodeErrorLocation(ierrors.Errorf("post-deserialization validation failed: %w", err), "", startOffset)
+		}
+	}
+
+	return nil
+}
+
+func (api *API) decodeStructFrom(ctx context.Context, decoder *streamDecoder, value reflect.Value, ts TypeSettings, opts *options) error {
+	if objectType := ts.ObjectType(); objectType != nil {
+		typeDen, objectCode, err := getTypeDenotationAndCode(objectType)
+		if err != nil {
+			return ierrors.WithStack(err)
+		}
+
+		if err := decoder.decodeBuffered(func(b []byte) (int, error) {
+			return serializer.NewDeserializer(b).CheckTypePrefix(objectCode, typeDen, typePrefixErrProducer(b, typeDen, objectCode)).Done()
+		}); err != nil {
+			return err
+		}
+	}
+
+	return api.decodeStructFieldsFrom(ctx, decoder, value, value.Type(), opts)
+}
+
+func (api *API) decodeStructFieldsFrom(ctx context.Context, decoder *streamDecoder, value reflect.Value, valueType reflect.Type, opts *options) error {
+	structFields, err := api.getStructFields(valueType)
+	if err != nil {
+		return ierrors.Wrapf(err, "can't parse struct type %s", valueType)
+	}
+
+	for _, sField := range structFields {
+		inVersion, err := opts.inProtocolVersion(ctx, sField.settings.versionRange)
+		if err != nil {
+			return ierrors.Wrapf(err, "can't deserialize struct field %s", sField.name)
+		}
+		if !inVersion {
+			// the field is not part of the protocol version
+			continue
+		}
+
+		fieldValue := value.Field(sField.index)
+
+		switch {
+		case sField.isEmbedded && !sField.settings.inlined:
+			fieldType := sField.fType
+			if fieldType.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					if sField.isUnexported {
+						return ierrors.Errorf(
+							"embedded field %s is a nil pointer, can't initialize because it's unexported",
+							sField.name,
+						)
+					}
+					fieldValue.Set(reflect.New(fieldType.Elem()))
+				}
+				fieldValue = fieldValue.Elem()
+				fieldType = fieldType.Elem()
+			}
+			if err := api.decodeStructFieldsFrom(ctx, decoder, fieldValue, fieldType, opts); err != nil {
+				return ierrors.Wrapf(err, "can't deserialize embedded struct %s", sField.name)
+			}
+
+		case !sField.settings.isOptional:
+			if err := api.decodeFrom(ctx, decoder, fieldValue, sField.settings.ts, opts); err != nil {
+				return ierrors.Wrapf(withDecodeErrorLocation(err, fieldPathElement(sField.name), 0), "failed to deserialize struct field %s", sField.name)
+			}
+
+		default:
+			// optional fields are prefixed with their length, so they are decoded at once
+			restoreFieldValue := restorableValue(fieldValue)
+
+			if err := decoder.decodeBuffered(func(b []byte) (int, error) {
+				restoreFieldValue()
+
+				deseri := serializer.NewDeserializer(b)
+				if err := api.decodeStructField(ctx, deseri, value, sField, opts); err != nil {
+					return 0, err
+				}
+
+				return deseri.Done()
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (api *API) decodeSliceFrom(ctx context.Context, decoder *streamDecoder, value reflect.Value, valueType reflect.Type, ts TypeSettings, opts *options) error {
+	lengthPrefixType, _ := ts.LengthPrefixType()
+	serializerArrayRules := serializerArrayRules(ts)
+	startOffset := decoder.consumed
+	errProducer := func(err error) error {
+		return ierrors.Wrapf(withDecodeErrorLocation(err, "", startOffset), "failed to read sequence of objects %s from the deserialized", valueType)
+	}
+
+	sliceLength, err := stream.ReadSize(decoder, serializer.SeriLengthPrefixType(lengthPrefixType))
+	if err != nil {
+		if ierrors.Is(err, io.EOF) || ierrors.Is(err, io.ErrUnexpectedEOF) {
+			err = ierrors.Join(err, serializer.ErrDeserializationNotEnoughData)
+		}
+
+		return errProducer(err)
+	}
+
+	var elementValidationFunc serializer.ElementValidationFunc
+	if opts.validation {
+		if err := serializerArrayRules.CheckBounds(uint(sliceLength)); err != nil {
+			return errProducer(err)
+		}
+		elementValidationFunc = serializerArrayRules.ElementValidationFunc()
+	}
+
+	for i := range sliceLength {
+		elemValue := reflect.New(valueType.Elem()).Elem()
+
+		// the elements only need to be decoded at once if their bytes need to be validated
+		if elementValidationFunc == nil {
+			if err := api.decodeFrom(ctx, decoder, elemValue, TypeSettings{}, opts); err != nil {
+				return ierrors.Wrapf(withDecodeErrorLocation(err, indexPathElement(i), 0), "failed to read sequence of objects %s from the deserialized", valueType)
+			}
+		} else if err := decoder.decodeBuffered(func(b []byte) (int, error) {
+			elemValue = reflect.New(valueType.Elem()).Elem()
+
+			bytesRead, err := api.decode(ctx, b, elemValue, TypeSettings{}, opts)
+			if err != nil {
+				return 0, err
+			}
+
+			return bytesRead, elementValidationFunc(i, b[:bytesRead])
+		}); err != nil {
+			return ierrors.Wrapf(withDecodeErrorLocation(err, indexPathElement(i), 0), "failed to read sequence of objects %s from the deserialized", valueType)
+		}
+
+		value.Set(reflect.Append(value, elemValue))
+	}
+
+	// check if the slice is a nil pointer to the slice type (in case the sliceLength is zero and the slice was not initialized before)
+	if value.IsNil() {
+		// initialize a new empty slice
+		value.Set(reflect.MakeSlice(valueType, 0, 0))
+	}
+
+	if opts.validation {
+		if err := api.checkArrayMustOccur(value, ts); err != nil {
+			return ierrors.Wrapf(withDecodeErrorLocation(err, "", startOffset), "can't deserialize '%s' type", value.Kind())
+		}
+	}
+
+	return nil
+}
+
+// restorableValue returns a function that restores the current state of the given value (or the value it points to),
+// so that it can be decoded again if the buffered bytes were not sufficient.
+func restorableValue(value reflect.Value) func() {
+	if !value.CanSet() {
+		if value.Kind() != reflect.Ptr || value.IsNil() {
+			return func() {}
+		}
+		value = value.Elem()
+	}
+
+	initialValue := reflect.New(value.Type()).Elem()
+	initialValue.Set(value)
+
+	return func() {
+		value.Set(initialValue)
+	}
+}
+
+// streamableStruct returns the struct value of the given value if its fields can be serialized one after another.
+func (api *API) streamableStruct(value reflect.Value) (reflect.Value, bool) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() || isSerializable(value) {
+			return reflect.Value{}, false
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct || value.Type() == timeType || isSerializable(value) {
+		return reflect.Value{}, false
+	}
+
+	return value, true
+}
+
+// streamableSlice returns whether the elements of the given slice can be serialized one after another.
+func (api *API) streamableSlice(value reflect.Value, ts TypeSettings, opts *options) bool {
+	if value.Kind() != reflect.Slice || value.Type().AssignableTo(bytesType) || isSerializable(value) {
+		return false
+	}
+
+	if _, set := ts.LengthPrefixType(); !set {
+		return false
+	}
+
+	// lexically ordered slices need to be sorted before they are written
+	arrayRules := serializerArrayRules(ts)
+
+	return !ts.toMode(opts).HasMode(serializer.DeSeriModePerformLexicalOrdering) ||
+		!arrayRules.ValidationMode.HasMode(serializer.ArrayValidationModeLexicalOrdering)
+}
+
+// isSerializable returns whether the given value or a pointer to it serializes or deserializes itself.
+func isSerializable(value reflect.Value) bool {
+	if _, ok := value.Interface().(Serializable); ok {
+		return true
+	}
+
+	return isDeserializable(value)
+}
+
+// isDeserializable returns whether the given value, a pointer to it or the value it points to deserializes itself.
+func isDeserializable(value reflect.Value) bool {
+	valueType := value.Type()
+	if valueType.Implements(deserializableType) || reflect.PointerTo(valueType).Implements(deserializableType) {
+		return true
+	}
+
+	return valueType.Kind() == reflect.Ptr && valueType.Elem().Kind() == reflect.Ptr && valueType.Elem().Implements(deserializableType)
+}
+
+// serializerArrayRules returns the array rules of the given type settings.
+func serializerArrayRules(ts TypeSettings) *serializer.ArrayRules {
+	arrayRules := ts.ArrayRules()
+	if arrayRules == nil {
+		arrayRules = new(ArrayRules)
+	}
+	serializerArrayRules := serializer.ArrayRules(*arrayRules)
+
+	return &serializerArrayRules
+}
+
+func writeTo(writer io.Writer, b []byte) error {
+	if _, err := writer.Write(b); err != nil {
+		return ierrors.Wrap(err, "failed to write to writer")
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package serix_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+type streamSnapshot struct {
+	Version  uint8            `serix:""`
+	Outputs  []*streamOutput  `serix:",lenPrefix=uint32"`
+	IDs      streamIDs        `serix:""`
+	Address  schemaAddress    `serix:""`
+	Metadata *codegenMetadata `serix:",optional"`
+}
+
+type streamOutput struct {
+	Amount uint64 `serix:""`
+	Data   []byte `serix:",lenPrefix=uint16,maxByteSize=34"`
+}
+
+type streamIDs []schemaEd25519Address
+
+func newStreamTestAPI(t *testing.T) *serix.API {
+	api := newSchemaTestAPI(t)
+	require.NoError(t, api.RegisterTypeSettings(streamSnapshot{}, serix.TypeSettings{}.WithObjectType(uint8(3))))
+	require.NoError(t, api.RegisterTypeSettings(streamIDs{}, serix.TypeSettings{}.WithLengthPrefixType(serix.LengthPrefixTypeAsByte).WithArrayRules(&serix.ArrayRules{
+		ValidationMode: serializer.ArrayValidationModeNoDuplicates,
+	})))
+
+	return api
+}
+
+func newStreamSnapshot(outputCount int) *streamSnapshot {
+	snapshot := &streamSnapshot{
+		Version:  1,
+		IDs:      streamIDs{{1}, {2}, {3}},
+		Address:  &schemaAccountAddress{AccountID: [2]byte{4, 2}},
+		Metadata: &codegenMetadata{Note: "snapshot", Count: int64(outputCount)},
+	}
+	for i := range outputCount {
+		snapshot.Outputs = append(snapshot.Outputs, &streamOutput{Amount: uint64(i), Data: bytes.Repeat([]byte{byte(i)}, i%32)})
+	}
+
+	return snapshot
+}
+
+func TestEncodeToDecodeFrom(t *testing.T) {
+	api := newStreamTestAPI(t)
+	ctx := context.Background()
+	snapshot := newStreamSnapshot(100)
+
+	for _, opts := range [][]serix.Option{{}, {serix.WithValidation()}} {
+		encoded, err := api.Encode(ctx, snapshot, opts...)
+		require.NoError(t, err)
+
+		buffer := new(bytes.Buffer)
+		bytesWritten, err := api.EncodeTo(ctx, buffer, snapshot, opts...)
+		require.NoError(t, err)
+		require.Equal(t, len(encoded), bytesWritten)
+		require.Equal(t, encoded, buffer.Bytes())
+
+		// the reader is read byte by byte to make sure that every value is decoded from partial data
+		decoded := new(streamSnapshot)
+		bytesRead, err := api.DecodeFrom(ctx, iotest.OneByteReader(bytes.NewReader(encoded)), decoded, opts...)
+		require.NoError(t, err)
+		require.Equal(t, len(encoded), bytesRead)
+		require.Equal(t, snapshot, decoded)
+
+		// the reader is positioned at the end of the object if it implements io.Seeker
+		reader := bytes.NewReader(append(encoded, 1, 2, 3))
+		_, err = api.DecodeFrom(ctx, reader, new(streamSnapshot), opts...)
+		require.NoError(t, err)
+		require.Equal(t, 3, reader.Len())
+	}
+}
+
+func TestDecodeFromErrors(t *testing.T) {
+	api := newStreamTestAPI(t)
+	ctx := context.Background()
+
+	encoded, err := api.Encode(ctx, newStreamSnapshot(10))
+	require.NoError(t, err)
+
+	_, err = api.DecodeFrom(ctx, bytes.NewReader(encoded), new(streamSnapshot), serix.WithReadLimit(len(encoded)-1))
+	require.True(t, ierrors.Is(err, serix.ErrReadLimitExceeded))
+
+	_, err = api.DecodeFrom(ctx, bytes.NewReader(encoded), new(streamSnapshot), serix.WithReadLimit(len(encoded)))
+	require.NoError(t, err)
+
+	_, err = api.DecodeFrom(ctx, bytes.NewReader(encoded[:len(encoded)-1]), new(streamSnapshot))
+	require.True(t, ierrors.Is(err, serializer.ErrDeserializationNotEnoughData))
+
+	// duplicates are detected in streamed slices as well
+	duplicates := newStreamSnapshot(1)
+	duplicates.IDs = streamIDs{{1}, {1}}
+	encoded, err = api.Encode(ctx, duplicates)
+	require.NoError(t, err)
+
+	_, err = api.DecodeFrom(ctx, bytes.NewReader(encoded), new(streamSnapshot), serix.WithValidation())
+	require.True(t, ierrors.Is(err, serializer.ErrArrayValidationViolatesUniqueness))
+}
+
+func TestMaxByteSize(t *testing.T) {
+	api := newStreamTestAPI(t)
+	ctx := context.Background()
+
+	tooLarge := &streamOutput{Amount: 1, Data: make([]byte, 33)}
+	_, err := api.Encode(ctx, tooLarge)
+	require.True(t, ierrors.Is(err, serix.ErrMaxByteSizeExceeded))
+
+	_, err = api.EncodeTo(ctx, new(bytes.Buffer), tooLarge)
+	require.True(t, ierrors.Is(err, serix.ErrMaxByteSizeExceeded))
+
+	// the limit is enforced during decoding as well
+	encoded, err := api.Encode(ctx, tooLarge, serix.WithTypeSettings(serix.TypeSettings{}.WithMaxByteSize(100)))
+	require.True(t, ierrors.Is(err, serix.ErrMaxByteSizeExceeded))
+	require.Nil(t, encoded)
+
+	encoded = append([]byte{1, 0, 0, 0, 0, 0, 0, 0, 33, 0}, make([]byte, 33)...)
+	_, err = api.Decode(ctx, encoded, new(streamOutput))
+	require.True(t, ierrors.Is(err, serix.ErrMaxByteSizeExceeded))
+
+	_, err = api.DecodeFrom(ctx, bytes.NewReader(encoded), new(streamOutput))
+	require.True(t, ierrors.Is(err, serix.ErrMaxByteSizeExceeded))
+
+	// the max byte size of the whole object can be passed via the type settings
+	_, err = api.Encode(ctx, newStreamSnapshot(10), serix.WithTypeSettings(serix.TypeSettings{}.WithMaxByteSize(10)))
+	require.True(t, ierrors.Is(err, serix.ErrMaxByteSizeExceeded))
+
+	encoded, err = api.Encode(ctx, newStreamSnapshot(10))
+	require.NoError(t, err)
+
+	_, err = api.DecodeFrom(ctx, bytes.NewReader(encoded), new(streamSnapshot), serix.WithTypeSettings(serix.TypeSettings{}.WithMaxByteSize(10)))
+	require.True(t, ierrors.Is(err, serix.ErrMaxByteSizeExceeded))
+}
@@ -26,8 +26,12 @@ const (
 	LengthPrefixTypeAsUint32 = LengthPrefixType(serializer.SeriLengthPrefixTypeAsUint32)
 	// LengthPrefixTypeAsUint64 defines a collection length to be denoted by a uint64.
 	LengthPrefixTypeAsUint64 = LengthPrefixType(serializer.SeriLengthPrefixTypeAsUint64)
+	// LengthPrefixTypeAsVarint defines a collection length to be denoted by an unsigned LEB128 variable-length integer.
+	LengthPrefixTypeAsVarint = LengthPrefixType(serializer.SeriLengthPrefixTypeAsVarint)
 )
 
+// LengthPrefixTypeSize returns the byte size of the given LengthPrefixType.
+// The size of LengthPrefixTypeAsVarint depends on the length it denotes, so its maximum size is returned.
 func LengthPrefixTypeSize(t LengthPrefixType) (int, error) {
 	switch t {
 	case LengthPrefixTypeAsByte:
@@ -38,6 +42,8 @@ func LengthPrefixTypeSize(t LengthPrefixType) (int, error) {
 		return 4, nil
 	case LengthPrefixTypeAsUint64:
 		return 8, nil
+	case LengthPrefixTypeAsVarint:
+		return serializer.MaxVarintByteSize, nil
 	default:
 		return 0, ErrUnknownLengthPrefixType
 	}
@@ -69,6 +75,10 @@ type TypeSettings struct {
 	lexicalOrdering *bool
 	// arrayRules defines rules around a to be deserialized array.
 	arrayRules *ArrayRules
+	// maxByteSize defines the maximum serialized byte size of the object (0 means unbounded).
+	maxByteSize uint
+	// valueRules defines rules around the value of a to be deserialized object.
+	valueRules *ValueRules
 }
 
 func NewTypeSettings() TypeSettings {
@@ -223,6 +233,45 @@ func (ts TypeSettings) MinMaxLen() (int, int) {
 	return min, max
 }
 
+// WithMaxByteSize specifies the maximum serialized byte size of the object.
+func (ts TypeSettings) WithMaxByteSize(maxByteSize uint) TypeSettings {
+	ts.maxByteSize = maxByteSize
+
+	return ts
+}
+
+// MaxByteSize returns the maximum serialized byte size of the object.
+func (ts TypeSettings) MaxByteSize() (uint, bool) {
+	if ts.maxByteSize == 0 {
+		return 0, false
+	}
+
+	return ts.maxByteSize, true
+}
+
+// WithValueRules specifies ValueRules.
+func (ts TypeSettings) WithValueRules(rules *ValueRules) TypeSettings {
+	ts.valueRules = rules
+
+	return ts
+}
+
+// ValueRules returns ValueRules.
+func (ts TypeSettings) ValueRules() *ValueRules {
+	return ts.valueRules
+}
+
+// updateValueRules updates a copy of the ValueRules, so that the rules of other type settings are not modified.
+func (ts TypeSettings) updateValueRules(update func(rules *ValueRules)) TypeSettings {
+	rules := new(ValueRules)
+	if ts.valueRules != nil {
+		*rules = *ts.valueRules
+	}
+	update(rules)
+
+	return ts.WithValueRules(rules)
+}
+
 func (ts TypeSettings) ensureOrdering() TypeSettings {
 	newTS := ts.WithLexicalOrdering(true)
 	arrayRules := newTS.ArrayRules()
@@ -251,6 +300,12 @@ func (ts TypeSettings) merge(other TypeSettings) TypeSettings {
 	if ts.fieldKey == nil {
 		ts.fieldKey = other.fieldKey
 	}
+	if ts.maxByteSize == 0 {
+		ts.maxByteSize = other.maxByteSize
+	}
+	if ts.valueRules == nil {
+		ts.valueRules = other.valueRules
+	}
 
 	return ts
 }
@@ -294,6 +349,24 @@ func (ts TypeSettings) checkMinMaxBounds(v reflect.Value) error {
 	return nil
 }
 
+// checkMaxByteSize checks whether the given serialized byte size doesn't exceed the defined maximum.
+func (ts TypeSettings) checkMaxByteSize(byteSize int) error {
+	if maxByteSize, ok := ts.MaxByteSize(); ok && uint(byteSize) > maxByteSize {
+		return ierrors.Wrapf(ErrMaxByteSizeExceeded, "max byte size %d exceeded (size %d)", maxByteSize, byteSize)
+	}
+
+	return nil
+}
+
+// checkValueRules checks whether the given value satisfies its defined value rules.
+func (ts TypeSettings) checkValueRules(value reflect.Value) error {
+	if ts.valueRules == nil {
+		return nil
+	}
+
+	return ts.valueRules.check(value)
+}
+
 type TypeSettingsRegistry struct {
 	// the registered type settings for the known objects
 	registryMutex sync.RWMutex
@@ -0,0 +1,173 @@
+package serix
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"slices"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// valueRulesPrecision is the precision of the numbers of the value rules, which is high enough to exactly
+	// represent all integer types and uint256 numbers.
+	valueRulesPrecision = 512
+)
+
+var (
+	// ErrValueOutOfBounds gets returned when a number is less than its min or greater than its max value.
+	ErrValueOutOfBounds = ierrors.New("value out of bounds")
+	// ErrValueNotInEnum gets returned when a value is not one of its allowed values.
+	ErrValueNotInEnum = ierrors.New("value is not one of the allowed values")
+	// ErrValueZero gets returned when a value that must not be zero is zero.
+	ErrValueZero = ierrors.New("value must not be zero")
+	// ErrValuePatternMismatch gets returned when a string doesn't match its pattern.
+	ErrValuePatternMismatch = ierrors.New("value doesn't match the pattern")
+)
+
+// ValueRules defines rules around the value of a to be deserialized object.
+// The rules are checked during the deserialization if the validation is enabled.
+type ValueRules struct {
+	// Min is the minimum value of a number (nil means unbounded).
+	Min *big.Float
+	// Max is the maximum value of a number (nil means unbounded).
+	Max *big.Float
+	// Enum holds the allowed values of a number (in base 10) or a string (empty means all values are allowed).
+	Enum []string
+	// NonZero defines whether the value must not be the zero value of its type.
+	// Strings, slices and maps must not be empty.
+	NonZero bool
+	// Pattern is the regular expression a string must match (nil means all strings are allowed).
+	Pattern *regexp.Regexp
+}
+
+// ParseValueRulesNumber parses the given base 10 number with the precision used by the value rules.
+func ParseValueRulesNumber(number string) (*big.Float, error) {
+	result, _, err := big.ParseFloat(number, 10, valueRulesPrecision, big.ToNearestEven)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "invalid number %s", number)
+	}
+
+	return result, nil
+}
+
+// check checks whether the given value satisfies the rules.
+func (r *ValueRules) check(value reflect.Value) error {
+	// resolve indirections, except for big.Int, which is handled as a number
+	for (value.Kind() == reflect.Ptr && value.Type() != bigIntPtrType) || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			if r.NonZero {
+				return ErrValueZero
+			}
+
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if r.NonZero && isZeroValue(value) {
+		return ErrValueZero
+	}
+
+	if r.Min != nil || r.Max != nil {
+		number, err := numberValue(value)
+		if err != nil {
+			return err
+		}
+		if r.Min != nil && number.Cmp(r.Min) < 0 {
+			return ierrors.Wrapf(ErrValueOutOfBounds, "value %s is less than min %s", number.Text('g', -1), r.Min.Text('g', -1))
+		}
+		if r.Max != nil && number.Cmp(r.Max) > 0 {
+			return ierrors.Wrapf(ErrValueOutOfBounds, "value %s is greater than max %s", number.Text('g', -1), r.Max.Text('g', -1))
+		}
+	}
+
+	if len(r.Enum) > 0 {
+		if err := r.checkEnum(value); err != nil {
+			return err
+		}
+	}
+
+	if r.Pattern != nil {
+		if value.Kind() != reflect.String {
+			return ierrors.Errorf("pattern is only supported for strings, got %s", value.Type())
+		}
+		if !r.Pattern.MatchString(value.String()) {
+			return ierrors.Wrapf(ErrValuePatternMismatch, "value %q doesn't match %s", value.String(), r.Pattern)
+		}
+	}
+
+	return nil
+}
+
+func (r *ValueRules) checkEnum(value reflect.Value) error {
+	if value.Kind() == reflect.String {
+		if !slices.Contains(r.Enum, value.String()) {
+			return ierrors.Wrapf(ErrValueNotInEnum, "value %q is not one of %v", value.String(), r.Enum)
+		}
+
+		return nil
+	}
+
+	number, err := numberValue(value)
+	if err != nil {
+		return err
+	}
+
+	for _, allowed := range r.Enum {
+		allowedNumber, err := ParseValueRulesNumber(allowed)
+		if err != nil {
+			return ierrors.Wrap(err, "invalid enum value")
+		}
+		if number.Cmp(allowedNumber) == 0 {
+			return nil
+		}
+	}
+
+	return ierrors.Wrapf(ErrValueNotInEnum, "value %s is not one of %v", number.Text('g', -1), r.Enum)
+}
+
+// numberValue returns the given number value as a big.Float.
+func numberValue(value reflect.Value) (*big.Float, error) {
+	number := new(big.Float).SetPrec(valueRulesPrecision)
+
+	switch value.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number.SetInt64(value.Int()), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return number.SetUint64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(value.Float()) {
+			return nil, ierrors.Wrap(ErrValueOutOfBounds, "value is NaN")
+		}
+
+		return number.SetFloat64(value.Float()), nil
+	case reflect.Ptr:
+		if value.Type() == bigIntPtrType {
+			//nolint:forcetypeassert // false positive, we already checked the type via reflect
+			return number.SetInt(value.Interface().(*big.Int)), nil
+		}
+	default:
+	}
+
+	return nil, ierrors.Errorf("min, max and numeric enum values are only supported for numbers, got %s", value.Type())
+}
+
+// isZeroValue returns whether the given value is the zero value of its type or an empty string, slice or map.
+func isZeroValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Ptr:
+		if value.Type() == bigIntPtrType {
+			//nolint:forcetypeassert // false positive, we already checked the type via reflect
+			return value.IsNil() || value.Interface().(*big.Int).Sign() == 0
+		}
+
+		return value.IsNil()
+	default:
+		return value.IsZero()
+	}
+}
@@ -0,0 +1,121 @@
+package serix_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+type valueRulesOutput struct {
+	Kind   uint8   `serix:",enum=1|2|5"`
+	Amount uint64  `serix:",min=1,max=1000"`
+	Delta  int16   `serix:",min=-10,max=10"`
+	Name   string  `serix:",lenPrefix=uint8,pattern=^[a-z]+(=[0-9])?$"`
+	Unit   string  `serix:",lenPrefix=uint8,enum=iota|smr"`
+	ID     [4]byte `serix:",nonzero"`
+}
+
+func newValueRulesOutput() *valueRulesOutput {
+	return &valueRulesOutput{Kind: 2, Amount: 1000, Delta: -10, Name: "name=1", Unit: "smr", ID: [4]byte{0, 0, 0, 1}}
+}
+
+func TestValueRules(t *testing.T) {
+	api := serix.NewAPI()
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		modify func(output *valueRulesOutput)
+		path   string
+		err    error
+	}{
+		{name: "valid", modify: func(*valueRulesOutput) {}},
+		{name: "not in enum", modify: func(o *valueRulesOutput) { o.Kind = 3 }, path: "Kind", err: serix.ErrValueNotInEnum},
+		{name: "less than min", modify: func(o *valueRulesOutput) { o.Amount = 0 }, path: "Amount", err: serix.ErrValueOutOfBounds},
+		{name: "greater than max", modify: func(o *valueRulesOutput) { o.Amount = 1001 }, path: "Amount", err: serix.ErrValueOutOfBounds},
+		{name: "negative less than min", modify: func(o *valueRulesOutput) { o.Delta = -11 }, path: "Delta", err: serix.ErrValueOutOfBounds},
+		{name: "pattern mismatch", modify: func(o *valueRulesOutput) { o.Name = "Name" }, path: "Name", err: serix.ErrValuePatternMismatch},
+		{name: "string not in enum", modify: func(o *valueRulesOutput) { o.Unit = "btc" }, path: "Unit", err: serix.ErrValueNotInEnum},
+		{name: "zero", modify: func(o *valueRulesOutput) { o.ID = [4]byte{} }, path: "ID", err: serix.ErrValueZero},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := newValueRulesOutput()
+			test.modify(output)
+
+			encoded, err := api.Encode(ctx, output)
+			require.NoError(t, err)
+			encodedJSON, err := api.JSONEncode(ctx, output)
+			require.NoError(t, err)
+
+			// the rules are only enforced with validation
+			decoded := new(valueRulesOutput)
+			_, err = api.Decode(ctx, encoded, decoded)
+			require.NoError(t, err)
+			require.Equal(t, output, decoded)
+			require.NoError(t, api.JSONDecode(ctx, encodedJSON, new(valueRulesOutput)))
+
+			_, err = api.Decode(ctx, encoded, new(valueRulesOutput), serix.WithValidation())
+			errJSON := api.JSONDecode(ctx, encodedJSON, new(valueRulesOutput), serix.WithValidation())
+			if test.err == nil {
+				require.NoError(t, err)
+				require.NoError(t, errJSON)
+
+				return
+			}
+
+			require.ErrorIs(t, err, test.err)
+			require.ErrorIs(t, errJSON, test.err)
+			requireDecodeError(t, errJSON, test.path, -1)
+		})
+	}
+}
+
+func TestValueRulesTags(t *testing.T) {
+	for _, tag := range []string{",min=abc", ",max=", ",enum", ",pattern=(", ",pattern"} {
+		_, err := serix.ParseSerixSettings(tag, 0)
+		require.Error(t, err, tag)
+	}
+
+	settings, err := serix.ParseSerixSettings(",min=1,max=1.5,enum=1|2,nonzero,pattern=a=b", 0)
+	require.NoError(t, err)
+
+	rules := settings.TypeSettings().ValueRules()
+	require.NotNil(t, rules)
+	require.Equal(t, "1", rules.Min.Text('g', -1))
+	require.Equal(t, "1.5", rules.Max.Text('g', -1))
+	require.Equal(t, []string{"1", "2"}, rules.Enum)
+	require.True(t, rules.NonZero)
+	require.Equal(t, "a=b", rules.Pattern.String())
+}
+
+func TestValueRulesJSONSchema(t *testing.T) {
+	api := serix.NewAPI()
+	require.NoError(t, api.RegisterTypeSettings(valueRulesOutput{}, serix.TypeSettings{}))
+
+	schemaBytes, err := api.ExportJSONSchema()
+	require.NoError(t, err)
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs": {
+			"valueRulesOutput": {
+				"type": "object",
+				"properties": {
+					"kind": {"type": "integer", "minimum": 0, "maximum": 255, "enum": [1, 2, 5]},
+					"amount": {"type": "string", "pattern": "^[0-9]+$"},
+					"delta": {"type": "integer", "minimum": -10, "maximum": 10},
+					"name": {"type": "string", "pattern": "^[a-z]+(=[0-9])?$"},
+					"unit": {"type": "string", "enum": ["iota", "smr"]},
+					"id": {"type": "string", "pattern": "^(0x[0-9a-fA-F]*)?$", "minLength": 10, "maxLength": 10}
+				},
+				"required": ["kind", "amount", "delta", "name", "unit", "id"]
+			}
+		}
+	}`
+	require.JSONEq(t, expected, string(schemaBytes))
+}
@@ -0,0 +1,96 @@
+package serix
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+var (
+	// ErrProtocolVersionMissing gets returned if a versioned struct field or interface object is serialized or
+	// deserialized without a protocol version.
+	ErrProtocolVersionMissing = ierrors.New("no protocol version set for a versioned struct field or interface object")
+)
+
+// protocolVersionContextKey is the context key of the protocol version.
+type protocolVersionContextKey struct{}
+
+// VersionRange defines the range of protocol versions from Since to Until (both inclusive) a struct field or an
+// interface object is part of.
+// Until at 0 defines a range without an upper bound.
+type VersionRange struct {
+	Since uint32
+	Until uint32
+}
+
+// Contains returns whether the given protocol version is within the range.
+func (r VersionRange) Contains(version uint32) bool {
+	return version >= r.Since && (r.Until == 0 || version <= r.Until)
+}
+
+// isUnbounded returns whether the range contains all protocol versions.
+func (r VersionRange) isUnbounded() bool {
+	return r.Since == 0 && r.Until == 0
+}
+
+// WithProtocolVersion returns an Option that sets the protocol version the objects are serialized and deserialized
+// in. Struct fields and interface objects that are not part of the protocol version are skipped or rejected.
+// The protocol version of the option takes precedence over the one of the context, see ContextWithProtocolVersion,
+// which takes precedence over the default protocol version of the API, see API.SetDefaultProtocolVersion.
+// If no protocol version is set, versioned struct fields and interface objects fail with ErrProtocolVersionMissing.
+func WithProtocolVersion(version uint32) Option {
+	return func(o *options) {
+		o.version = &version
+	}
+}
+
+// ContextWithProtocolVersion returns a copy of the context that holds the protocol version the objects are
+// serialized and deserialized in, see WithProtocolVersion.
+func ContextWithProtocolVersion(ctx context.Context, version uint32) context.Context {
+	return context.WithValue(ctx, protocolVersionContextKey{}, version)
+}
+
+// ProtocolVersionFromContext returns the protocol version that was set via ContextWithProtocolVersion.
+func ProtocolVersionFromContext(ctx context.Context) (uint32, bool) {
+	version, ok := ctx.Value(protocolVersionContextKey{}).(uint32)
+
+	return version, ok
+}
+
+// SetDefaultProtocolVersion sets the protocol version the objects are serialized and deserialized in if neither the
+// options nor the context set one, see WithProtocolVersion.
+func (api *API) SetDefaultProtocolVersion(version uint32) {
+	api.defaultProtocolVersion.Store(&version)
+}
+
+// protocolVersion returns the protocol version that is set via the options, the context or the API.
+func (o *options) protocolVersion(ctx context.Context) (uint32, bool) {
+	if o.version != nil {
+		return *o.version, true
+	}
+
+	if version, has := ProtocolVersionFromContext(ctx); has {
+		return version, true
+	}
+
+	if o.defaultVersion != nil {
+		return *o.defaultVersion, true
+	}
+
+	return 0, false
+}
+
+// inProtocolVersion returns whether the given version range contains the protocol version that is set via the
+// options, the context or the API. Bounded version ranges can't be checked without a protocol version.
+func (o *options) inProtocolVersion(ctx context.Context, versionRange VersionRange) (bool, error) {
+	if versionRange.isUnbounded() {
+		return true, nil
+	}
+
+	version, has := o.protocolVersion(ctx)
+	if !has {
+		return false, ierrors.Wrapf(ErrProtocolVersionMissing, "version range since %d until %d", versionRange.Since, versionRange.Until)
+	}
+
+	return versionRange.Contains(version), nil
+}
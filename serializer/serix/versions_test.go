@@ -0,0 +1,175 @@
+package serix_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+type versionedFeature interface {
+	Feature() uint8
+}
+
+type versionedTagFeature struct {
+	Tag uint8 `serix:""`
+}
+
+func (f *versionedTagFeature) Feature() uint8 { return f.Tag }
+
+type versionedTagFeatureV2 struct {
+	Tag   uint8 `serix:""`
+	Extra uint8 `serix:""`
+}
+
+func (f *versionedTagFeatureV2) Feature() uint8 { return f.Tag }
+
+type versionedOutput struct {
+	Amount  uint64           `serix:""`
+	Mana    uint64           `serix:",since=2"`
+	Legacy  uint16           `serix:",until=1"`
+	Feature versionedFeature `serix:""`
+}
+
+type versionedTagFeatureHolder struct {
+	Feature versionedFeature `serix:""`
+}
+
+func newVersionsTestAPI(t *testing.T) *serix.API {
+	api := serix.NewAPI()
+	require.NoError(t, api.RegisterTypeSettings(versionedTagFeature{}, serix.TypeSettings{}.WithObjectType(uint8(1))))
+	require.NoError(t, api.RegisterTypeSettings(versionedTagFeatureV2{}, serix.TypeSettings{}.WithObjectType(uint8(1))))
+	require.NoError(t, api.RegisterInterfaceObjectsWithVersionRange((*versionedFeature)(nil), serix.VersionRange{Until: 1}, (*versionedTagFeature)(nil)))
+	require.NoError(t, api.RegisterInterfaceObjectsWithVersionRange((*versionedFeature)(nil), serix.VersionRange{Since: 2}, (*versionedTagFeatureV2)(nil)))
+
+	return api
+}
+
+func TestProtocolVersions(t *testing.T) {
+	api := newVersionsTestAPI(t)
+	ctx := context.Background()
+
+	t.Run("version 1", func(t *testing.T) {
+		output := &versionedOutput{Amount: 1, Mana: 5, Legacy: 7, Feature: &versionedTagFeature{Tag: 3}}
+
+		encoded, err := api.Encode(ctx, output, serix.WithProtocolVersion(1))
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0, 7, 0, 1, 3}, encoded)
+
+		decoded := new(versionedOutput)
+		bytesRead, err := api.Decode(ctx, encoded, decoded, serix.WithProtocolVersion(1))
+		require.NoError(t, err)
+		require.Len(t, encoded, bytesRead)
+		require.Equal(t, &versionedOutput{Amount: 1, Legacy: 7, Feature: &versionedTagFeature{Tag: 3}}, decoded)
+
+		decoded = new(versionedOutput)
+		_, err = api.DecodeFrom(ctx, bytes.NewReader(encoded), decoded, serix.WithProtocolVersion(1))
+		require.NoError(t, err)
+		require.Equal(t, &versionedOutput{Amount: 1, Legacy: 7, Feature: &versionedTagFeature{Tag: 3}}, decoded)
+
+		encodedJSON, err := api.JSONEncode(ctx, output, serix.WithProtocolVersion(1))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"amount":"1","legacy":7,"feature":{"type":1,"tag":3}}`, string(encodedJSON))
+
+		decoded = new(versionedOutput)
+		require.NoError(t, api.JSONDecode(ctx, encodedJSON, decoded, serix.WithProtocolVersion(1)))
+		require.Equal(t, &versionedOutput{Amount: 1, Legacy: 7, Feature: &versionedTagFeature{Tag: 3}}, decoded)
+
+		// the object of version 2 is not part of version 1
+		_, err = api.Encode(ctx, &versionedOutput{Feature: &versionedTagFeatureV2{}}, serix.WithProtocolVersion(1))
+		require.ErrorIs(t, err, serix.ErrInterfaceUnderlyingTypeNotRegistered)
+	})
+
+	t.Run("version 2", func(t *testing.T) {
+		// the protocol version is passed via the context
+		versionCtx := serix.ContextWithProtocolVersion(ctx, 2)
+		output := &versionedOutput{Amount: 1, Mana: 5, Legacy: 7, Feature: &versionedTagFeatureV2{Tag: 3, Extra: 4}}
+
+		encoded, err := api.Encode(versionCtx, output)
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 1, 3, 4}, encoded)
+
+		decoded := new(versionedOutput)
+		_, err = api.Decode(versionCtx, encoded, decoded)
+		require.NoError(t, err)
+		require.Equal(t, &versionedOutput{Amount: 1, Mana: 5, Feature: &versionedTagFeatureV2{Tag: 3, Extra: 4}}, decoded)
+
+		encodedJSON, err := api.JSONEncode(versionCtx, output)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"amount":"1","mana":"5","feature":{"type":1,"tag":3,"extra":4}}`, string(encodedJSON))
+
+		decoded = new(versionedOutput)
+		require.NoError(t, api.JSONDecode(versionCtx, encodedJSON, decoded))
+		require.Equal(t, &versionedOutput{Amount: 1, Mana: 5, Feature: &versionedTagFeatureV2{Tag: 3, Extra: 4}}, decoded)
+
+		// the option takes precedence over the context
+		_, err = api.Encode(versionCtx, output, serix.WithProtocolVersion(1))
+		require.ErrorIs(t, err, serix.ErrInterfaceUnderlyingTypeNotRegistered)
+	})
+
+	t.Run("without version", func(t *testing.T) {
+		output := &versionedOutput{Amount: 1, Mana: 5, Legacy: 7, Feature: &versionedTagFeature{Tag: 3}}
+
+		// versioned struct fields and interface objects can't be used without a protocol version
+		_, err := api.Encode(ctx, output)
+		require.ErrorIs(t, err, serix.ErrProtocolVersionMissing)
+
+		_, err = api.JSONEncode(ctx, output)
+		require.ErrorIs(t, err, serix.ErrProtocolVersionMissing)
+
+		_, err = api.Decode(ctx, []byte{1, 0, 0, 0, 0, 0, 0, 0, 7, 0, 1, 3}, new(versionedOutput))
+		require.ErrorIs(t, err, serix.ErrProtocolVersionMissing)
+
+		_, err = api.Encode(ctx, &versionedTagFeatureHolder{Feature: &versionedTagFeature{Tag: 3}})
+		require.ErrorIs(t, err, serix.ErrProtocolVersionMissing)
+
+		_, err = api.Decode(ctx, []byte{1, 3}, new(versionedTagFeatureHolder))
+		require.ErrorIs(t, err, serix.ErrProtocolVersionMissing)
+
+		// objects without versioned struct fields and interface objects don't need a protocol version
+		encoded, err := api.Encode(ctx, &versionedTagFeatureV2{Tag: 3, Extra: 4})
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 3, 4}, encoded)
+	})
+
+	t.Run("default version", func(t *testing.T) {
+		defaultAPI := newVersionsTestAPI(t)
+		defaultAPI.SetDefaultProtocolVersion(1)
+		output := &versionedOutput{Amount: 1, Mana: 5, Legacy: 7, Feature: &versionedTagFeature{Tag: 3}}
+
+		encoded, err := defaultAPI.Encode(ctx, output)
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0, 7, 0, 1, 3}, encoded)
+
+		decoded := new(versionedOutput)
+		_, err = defaultAPI.Decode(ctx, encoded, decoded)
+		require.NoError(t, err)
+		require.Equal(t, &versionedOutput{Amount: 1, Legacy: 7, Feature: &versionedTagFeature{Tag: 3}}, decoded)
+
+		// the context and the option take precedence over the default version
+		encoded, err = defaultAPI.Encode(serix.ContextWithProtocolVersion(ctx, 2), &versionedOutput{Feature: &versionedTagFeatureV2{}})
+		require.NoError(t, err)
+		require.Len(t, encoded, 19)
+
+		_, err = defaultAPI.Encode(ctx, &versionedOutput{Feature: &versionedTagFeatureV2{}}, serix.WithProtocolVersion(2))
+		require.NoError(t, err)
+	})
+}
+
+func TestProtocolVersionsTags(t *testing.T) {
+	settings, err := serix.ParseSerixSettings(",since=2,until=4", 0)
+	require.NoError(t, err)
+	require.Equal(t, serix.VersionRange{Since: 2, Until: 4}, settings.VersionRange())
+	require.True(t, settings.VersionRange().Contains(4))
+	require.False(t, settings.VersionRange().Contains(5))
+
+	for _, tag := range []string{",since=4,until=2", ",until=0", ",since=-1", ",since=4294967296"} {
+		_, err := serix.ParseSerixSettings(tag, 0)
+		require.Error(t, err, tag)
+	}
+
+	require.Error(t, serix.NewAPI().RegisterInterfaceObjectsWithVersionRange((*versionedFeature)(nil), serix.VersionRange{Since: 2, Until: 1}, (*versionedTagFeature)(nil)))
+}
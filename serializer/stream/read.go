@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 
 	"github.com/iotaledger/hive.go/ierrors"
 	"github.com/iotaledger/hive.go/serializer/v2"
@@ -43,6 +44,11 @@ func ReadBytesWithSize(reader io.Reader, lenType serializer.SeriLengthPrefixType
 	return ReadBytes(reader, size)
 }
 
+// ReadSize reads the size of a collection from the reader where lenType specifies the serialization length prefix type.
+func ReadSize(reader io.Reader, lenType serializer.SeriLengthPrefixType) (int, error) {
+	return readFixedSize(reader, lenType)
+}
+
 // ReadObject reads a type from the reader as specified by objectFromBytesFunc. A fixed length for the deserialized type must be specified.
 func ReadObject[T any](reader io.Reader, fixedLen int, objectFromBytesFunc func(bytes []byte) (T, int, error)) (T, error) {
 	var result T
@@ -149,8 +155,47 @@ func readFixedSize(reader io.Reader, lenType serializer.SeriLengthPrefixType) (i
 			return 0, ierrors.Wrap(err, "failed to read length prefix")
 		}
 
+		return int(result), nil
+	case serializer.SeriLengthPrefixTypeAsVarint:
+		result, err := readVarint(reader)
+		if err != nil {
+			return 0, ierrors.Wrap(err, "failed to read length prefix")
+		}
+		if result > math.MaxInt {
+			return 0, ierrors.Wrapf(serializer.ErrDeserializationLengthInvalid, "length prefix %d is out of range", result)
+		}
+
 		return int(result), nil
 	default:
 		panic(fmt.Sprintf("unknown slice length type %v", lenType))
 	}
 }
+
+// readVarint reads an unsigned LEB128 variable-length integer byte by byte from the reader, so that no bytes after
+// the integer are consumed.
+func readVarint(reader io.Reader) (uint64, error) {
+	varintBytes := make([]byte, 0, serializer.MaxVarintByteSize)
+	for {
+		b, err := Read[uint8](reader)
+		if err != nil {
+			if len(varintBytes) > 0 && ierrors.Is(err, io.EOF) {
+				return 0, io.ErrUnexpectedEOF
+			}
+
+			return 0, err
+		}
+		varintBytes = append(varintBytes, b)
+
+		// the most significant bit of the last byte is not set
+		if b < 0x80 {
+			break
+		}
+		if len(varintBytes) == serializer.MaxVarintByteSize {
+			return 0, ierrors.Wrap(serializer.ErrVarintInvalid, "variable-length integer overflows uint64")
+		}
+	}
+
+	value, _, err := serializer.ReadVarint(varintBytes)
+
+	return value, err
+}
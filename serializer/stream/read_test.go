@@ -2,6 +2,7 @@ package stream_test
 
 import (
 	"bytes"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -38,6 +39,35 @@ func TestReadBytesWithSize(t *testing.T) {
 	require.EqualValues(t, []byte{1, 2, 3, 4, 5}, readBytes)
 }
 
+func TestReadSize(t *testing.T) {
+	buffer := bytes.NewReader([]byte{2, 1, 0, 0})
+
+	size, err := stream.ReadSize(buffer, serializer.SeriLengthPrefixTypeAsUint32)
+	require.NoError(t, err)
+	require.EqualValues(t, 258, size)
+}
+
+func TestReadVarintSize(t *testing.T) {
+	buffer := bytes.NewReader([]byte{3, 1, 2, 3, 4})
+
+	readBytes, err := stream.ReadBytesWithSize(buffer, serializer.SeriLengthPrefixTypeAsVarint)
+	require.NoError(t, err)
+	require.EqualValues(t, []byte{1, 2, 3}, readBytes)
+
+	// the bytes after the varint are not consumed
+	buffer = bytes.NewReader([]byte{0xac, 0x02, 4})
+	size, err := stream.ReadSize(buffer, serializer.SeriLengthPrefixTypeAsVarint)
+	require.NoError(t, err)
+	require.EqualValues(t, 300, size)
+	require.Equal(t, 1, buffer.Len())
+
+	_, err = stream.ReadSize(bytes.NewReader([]byte{0x80, 0x00}), serializer.SeriLengthPrefixTypeAsVarint)
+	require.ErrorIs(t, err, serializer.ErrVarintInvalid)
+
+	_, err = stream.ReadSize(bytes.NewReader([]byte{0x80}), serializer.SeriLengthPrefixTypeAsVarint)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
 func TestReadObject(t *testing.T) {
 	buffer := bytes.NewReader([]byte{42, 0, 57, 5, 0, 0, 0, 0, 0, 0})
 
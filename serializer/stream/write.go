@@ -33,6 +33,11 @@ func WriteBytesWithSize(writer io.Writer, bytes []byte, lenType serializer.SeriL
 	return nil
 }
 
+// WriteSize writes the size of a collection to the writer where lenType specifies the serialization length prefix type.
+func WriteSize(writer io.Writer, size int, lenType serializer.SeriLengthPrefixType) error {
+	return writeFixedSize(writer, size, lenType)
+}
+
 // WriteObject writes a type to the writer as specified by the objectToBytesFunc.
 func WriteObject[T any](writer io.Writer, target T, objectToBytesFunc func(T) ([]byte, error)) error {
 	serializedBytes, err := objectToBytesFunc(target)
@@ -137,6 +142,16 @@ func writeFixedSize(writer io.Writer, l int, lenType serializer.SeriLengthPrefix
 
 		return nil
 
+	case serializer.SeriLengthPrefixTypeAsVarint:
+		if l < 0 {
+			return ierrors.Errorf("unable to serialize collection length: length %d is negative", l)
+		}
+		if _, err := writer.Write(binary.AppendUvarint(nil, uint64(l))); err != nil {
+			return ierrors.Wrap(err, "unable to write length")
+		}
+
+		return nil
+
 	default:
 		panic(fmt.Sprintf("unknown slice length type %v", lenType))
 	}
@@ -84,6 +84,32 @@ func TestWriteBytesWithSize(t *testing.T) {
 	requireBufferBytes(t, buffer, expected)
 }
 
+func TestWriteSize(t *testing.T) {
+	buffer := stream.NewByteBuffer()
+
+	err := stream.WriteSize(buffer, 258, serializer.SeriLengthPrefixTypeAsUint16)
+	require.NoError(t, err)
+
+	expected := []byte{2, 1}
+	requireBufferBytes(t, buffer, expected)
+
+	err = stream.WriteSize(stream.NewByteBuffer(), 256, serializer.SeriLengthPrefixTypeAsByte)
+	require.Error(t, err)
+}
+
+func TestWriteVarintSize(t *testing.T) {
+	buffer := stream.NewByteBuffer()
+
+	err := stream.WriteBytesWithSize(buffer, []byte{1, 2, 3}, serializer.SeriLengthPrefixTypeAsVarint)
+	require.NoError(t, err)
+	requireBufferBytes(t, buffer, []byte{3, 1, 2, 3})
+
+	buffer = stream.NewByteBuffer()
+	err = stream.WriteSize(buffer, 300, serializer.SeriLengthPrefixTypeAsVarint)
+	require.NoError(t, err)
+	requireBufferBytes(t, buffer, []byte{0xac, 0x02})
+}
+
 func TestWriteObject(t *testing.T) {
 	buffer := stream.NewByteBuffer()
 
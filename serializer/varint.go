@@ -0,0 +1,31 @@
+package serializer
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// VarintSize returns the byte size of the given value encoded as unsigned LEB128 variable-length integer.
+func VarintSize(value uint64) int {
+	// every byte holds 7 bits of the value, zero takes a single byte
+	return max(1, (bits.Len64(value)+6)/7)
+}
+
+// ReadVarint reads an unsigned LEB128 variable-length integer from the beginning of data and returns it together with
+// the amount of bytes it was encoded in.
+// Integers that are not encoded in their shortest form are rejected, so that every value has exactly one encoding.
+func ReadVarint(data []byte) (uint64, int, error) {
+	value, bytesRead := binary.Uvarint(data)
+	switch {
+	case bytesRead == 0:
+		return 0, 0, ierrors.Wrap(ErrDeserializationNotEnoughData, "can't read variable-length integer")
+	case bytesRead < 0:
+		return 0, 0, ierrors.Wrap(ErrVarintInvalid, "variable-length integer overflows uint64")
+	case bytesRead != VarintSize(value):
+		return 0, 0, ierrors.Wrapf(ErrVarintInvalid, "variable-length integer %d is not encoded in its shortest form", value)
+	}
+
+	return value, bytesRead, nil
+}